@@ -0,0 +1,66 @@
+package attr
+
+import "reflect"
+
+// errorType is the reflect type of the error interface.
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// getterValue calls the "Get<fieldName>" method of 'obj', for GetValue with
+// the WithAccessors option. The method must take no arguments and return a
+// value, optionally followed by an error which is returned as is. It returns
+// ErrNoField if there is no such method.
+func getterValue(obj interface{}, fieldName string) (interface{}, error) {
+	method := valueOf(obj).MethodByName("Get" + fieldName)
+	if !method.IsValid() {
+		return nil, ErrNoField
+	}
+
+	methodType := method.Type()
+	numOut := methodType.NumOut()
+	if methodType.NumIn() != 0 || numOut == 0 || numOut > 2 ||
+		(numOut == 2 && methodType.Out(1) != errorType) {
+		return nil, ErrNoField
+	}
+
+	results := method.Call(nil)
+	if numOut == 2 && !results[1].IsNil() {
+		return nil, results[1].Interface().(error)
+	}
+	return results[0].Interface(), nil
+}
+
+// callSetter calls the "Set<fieldName>" method of 'obj' with a value, for
+// SetValue with the WithAccessors option. The method must take a single
+// argument and return nothing or an error, which is returned as is. It returns
+// ErrNoField if there is no such method, and ErrMismatchValue if the value is
+// not assignable to the argument.
+func callSetter(obj interface{}, fieldName string, newValue interface{}) error {
+	method := valueOf(obj).MethodByName("Set" + fieldName)
+	if !method.IsValid() {
+		return ErrNoField
+	}
+
+	methodType := method.Type()
+	numOut := methodType.NumOut()
+	if methodType.NumIn() != 1 || methodType.IsVariadic() || numOut > 1 ||
+		(numOut == 1 && methodType.Out(0) != errorType) {
+		return ErrNoField
+	}
+
+	paramType := methodType.In(0)
+	value := reflect.Zero(paramType)
+	if newValue != nil {
+		value = reflect.ValueOf(newValue)
+		if !value.Type().AssignableTo(paramType) {
+			return ErrMismatchValue
+		}
+	} else if !isNillable(paramType.Kind()) {
+		return ErrMismatchValue
+	}
+
+	results := method.Call([]reflect.Value{value})
+	if numOut == 1 && !results[0].IsNil() {
+		return results[0].Interface().(error)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package attr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Rect struct {
+	Width  int
+	Height int
+}
+
+func (r Rect) GetArea() int {
+	return r.Width * r.Height
+}
+
+func (r *Rect) SetSize(size [2]int) {
+	r.Width, r.Height = size[0], size[1]
+}
+
+func (r *Rect) SetSquare(side int) error {
+	if side < 0 {
+		return errors.New("negative side")
+	}
+	r.Width, r.Height = side, side
+	return nil
+}
+
+func (r Rect) GetRatio() (float64, error) {
+	if r.Height == 0 {
+		return 0, errors.New("zero height")
+	}
+	return float64(r.Width) / float64(r.Height), nil
+}
+
+func TestAccessors(t *testing.T) {
+	rect := Rect{Width: 4, Height: 2}
+
+	_, err := GetValue(rect, "Area")
+	require.Equal(t, ErrNoField, err, "Getter is called without the option")
+
+	got, err := GetValue(rect, "Area", WithAccessors())
+	require.Nil(t, err)
+	require.Equal(t, 8, got, "Getter value is not correct")
+
+	got, err = GetValue(&rect, "Ratio", WithAccessors())
+	require.Nil(t, err)
+	require.Equal(t, 2.0, got, "Getter value is not correct")
+
+	_, err = GetValue(Rect{}, "Ratio", WithAccessors())
+	require.EqualError(t, err, "zero height")
+
+	_, err = GetValue(rect, "Perimeter", WithAccessors())
+	require.Equal(t, ErrNoField, err)
+
+	err = SetValue(&rect, "Size", [2]int{3, 5}, WithAccessors())
+	require.Nil(t, err)
+	require.Equal(t, Rect{3, 5}, rect, "Setter is not called")
+
+	err = SetValue(&rect, "Square", -1, WithAccessors())
+	require.EqualError(t, err, "negative side")
+
+	err = SetValue(&rect, "Square", "2", WithAccessors())
+	require.Equal(t, ErrMismatchValue, err)
+
+	err = SetValue(&rect, "Square", 2)
+	require.Equal(t, ErrNoField, err, "Setter is called without the option")
+
+	err = SetValue(&rect, "Area", 2, WithAccessors())
+	require.Equal(t, ErrNoField, err)
+
+	err = SetValue(&rect, "Width", 6, WithAccessors())
+	require.Nil(t, err)
+	require.Equal(t, Rect{6, 5}, rect, "Field is not set")
+}
+
+func ExampleWithAccessors() {
+	rect := Rect{Width: 4, Height: 2}
+
+	area, err := GetValue(rect, "Area", WithAccessors())
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Println(area)
+	// Output: 8
+}
@@ -0,0 +1,86 @@
+package attr
+
+import (
+	"reflect"
+	"strings"
+)
+
+// aclTagKey is the tag key listing the roles which can access a field.
+const aclTagKey = "acl"
+
+// FilterByRole returns a map of the exported (public) field names of a struct
+// with their values, like Values, but only with the fields visible to the
+// given role. The roles which can access a field are listed in its "acl" tag,
+// such as `acl:"admin,self"`, and a field without the tag is visible to every
+// role. A field tagged with `acl:"-"` is not visible to any role.
+//
+// Use WithSquash to promote the fields of the embedded structs, so that their
+// own "acl" tags are applied. The fields promoted from an embedded struct which
+// is not visible to the role are left out, whatever their own tags are.
+func FilterByRole(obj interface{}, role string, opts ...Option) (map[string]interface{}, error) {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	o := newOptions(opts)
+	o.embedFilter = func(field reflect.StructField) bool {
+		return canAccess(field.Tag, role)
+	}
+	valueMap := map[string]interface{}{}
+	for _, field := range structFields(objValue, o) {
+		if !field.value.CanInterface() || !canAccess(field.Tag, role) {
+			continue
+		}
+		valueMap[field.Name] = field.value.Interface()
+	}
+
+	return valueMap, nil
+}
+
+// SetValueAs sets the given value to a field of a struct like SetValue, but
+// only if the field is accessible by the given role as listed in its "acl"
+// tag (see FilterByRole), and by the embedded structs it is promoted from.
+// ErrAccessDenied is returned otherwise.
+//
+// NOTE: 'obj' struct must be passed by pointer for this API to work. Passing by
+// value results in ErrNotPtr.
+func SetValueAs(obj interface{}, role, fieldName string, newValue interface{}) error {
+	objValue, err := getStructByPtr(obj)
+	if err != nil {
+		return err
+	}
+
+	objType := objValue.Type()
+	field, found := cachedField(objType, fieldName)
+	if found && field.PkgPath == "" {
+		// Check the embedded structs on the way to a promoted field too.
+		for _, idx := range field.Index {
+			if objType.Kind() == reflect.Ptr {
+				objType = objType.Elem()
+			}
+			embedded := objType.Field(idx)
+			if !canAccess(embedded.Tag, role) {
+				return ErrAccessDenied
+			}
+			objType = embedded.Type
+		}
+	}
+	return SetValue(obj, fieldName, newValue)
+}
+
+// canAccess returns true if a field with the given tag is accessible by a
+// role.
+func canAccess(tag reflect.StructTag, role string) bool {
+	roles, found := tag.Lookup(aclTagKey)
+	if !found {
+		return true
+	}
+
+	for _, allowed := range strings.Split(roles, ",") {
+		if allowed != "-" && strings.TrimSpace(allowed) == role {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package attr
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Member struct {
+	Name   string
+	Email  string  `acl:"admin,self"`
+	Salary float64 `acl:"admin"`
+	Token  string  `acl:"-"`
+	notes  string
+}
+
+func TestFilterByRole(t *testing.T) {
+	member := Member{Name: "srathi", Email: "a@b.c", Salary: 10, Token: "t", notes: "n"}
+	for _, test := range []struct {
+		role string
+		want map[string]interface{}
+	}{
+		{"admin", map[string]interface{}{"Name": "srathi", "Email": "a@b.c", "Salary": 10.0}},
+		{"self", map[string]interface{}{"Name": "srathi", "Email": "a@b.c"}},
+		{"guest", map[string]interface{}{"Name": "srathi"}},
+		{"-", map[string]interface{}{"Name": "srathi"}},
+	} {
+		got, err := FilterByRole(&member, test.role)
+		require.Nil(t, err)
+		require.Equal(t, test.want, got, "Fields visible to %q are not correct", test.role)
+	}
+
+	type Secrets struct {
+		Key string
+	}
+	type Account struct {
+		Name    string
+		Secrets `acl:"admin"`
+	}
+	account := Account{Name: "srathi", Secrets: Secrets{Key: "k"}}
+	got, err := FilterByRole(&account, "user", WithSquash())
+	require.Nil(t, err)
+	require.Equal(t, map[string]interface{}{"Name": "srathi"}, got, "Fields of a hidden embedded struct are visible")
+	got, err = FilterByRole(&account, "admin", WithSquash())
+	require.Nil(t, err)
+	require.Equal(t, map[string]interface{}{"Name": "srathi", "Key": "k"}, got, "Fields of an embedded struct are hidden")
+
+	_, err = FilterByRole([]Member{}, "admin")
+	require.Equal(t, ErrNotStruct, err, "Able to filter a non-struct")
+}
+
+func TestSetValueAs(t *testing.T) {
+	member := Member{Name: "srathi"}
+	require.Nil(t, SetValueAs(&member, "self", "Email", "a@b.c"))
+	require.Equal(t, "a@b.c", member.Email, "Field not set by an allowed role")
+
+	require.Equal(t, ErrAccessDenied, SetValueAs(&member, "self", "Salary", 20.0),
+		"Able to set a protected field")
+	require.Zero(t, member.Salary, "Protected field is set")
+
+	require.Nil(t, SetValueAs(&member, "guest", "Name", "shyam"))
+
+	type Secrets struct {
+		Key string
+	}
+	type Account struct {
+		*Secrets `acl:"admin"`
+	}
+	account := Account{&Secrets{Key: "k"}}
+	require.Equal(t, ErrAccessDenied, SetValueAs(&account, "user", "Key", "hacked"),
+		"Able to set a field of a protected embedded struct")
+	require.Equal(t, "k", account.Key, "Field of a protected embedded struct is set")
+	require.Nil(t, SetValueAs(&account, "admin", "Key", "new"))
+	require.Equal(t, "new", account.Key, "Field not set by an allowed role")
+	require.Equal(t, ErrNoField, SetValueAs(&member, "admin", "ABC", 1), "Able to set a missing field")
+	require.Equal(t, ErrUnexportedField, SetValueAs(&member, "admin", "notes", "x"), "Able to set a private field")
+	require.Equal(t, ErrNotPtr, SetValueAs(member, "admin", "Name", "x"), "Able to set a struct passed by value")
+}
+
+func ExampleFilterByRole() {
+	type Profile struct {
+		Name  string
+		Email string `acl:"admin,self"`
+	}
+
+	visible, err := FilterByRole(Profile{"srathi", "a@b.c"}, "guest")
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Println(visible)
+	// Output: map[Name:srathi]
+}
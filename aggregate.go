@@ -0,0 +1,178 @@
+package attr
+
+import (
+	"math"
+	"reflect"
+)
+
+// Reflect types of the accumulators of the integer fields.
+var (
+	int64Type  = reflect.TypeOf(int64(0))
+	uint64Type = reflect.TypeOf(uint64(0))
+)
+
+// numericFields returns the values of a numeric field of all the elements of a
+// slice of structs (or pointers to structs).
+func numericFields(slice interface{}, fieldName string) ([]reflect.Value, error) {
+	sliceValue, err := getSliceValue(slice)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldType, err := getFieldTypeByPath(sliceValue.Type().Elem(), fieldName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isNumber(fieldType.Kind()) {
+		return nil, ErrMismatchValue
+	}
+
+	return sliceFields(sliceValue, fieldName)
+}
+
+// sumNumbers returns the sum of the values of a numeric field. The integer
+// values are added as int64 (or uint64 for the unsigned kinds), and a
+// *FieldError with an *OverflowError is returned if the sum doesn't fit in it.
+func sumNumbers(numbers []reflect.Value, fieldName string) (float64, error) {
+	if len(numbers) == 0 {
+		return 0, nil
+	}
+
+	switch numbers[0].Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		sum := int64(0)
+		for _, number := range numbers {
+			n := number.Int()
+			if (n > 0 && sum > math.MaxInt64-n) || (n < 0 && sum < math.MinInt64-n) {
+				return 0, &FieldError{fieldName, &OverflowError{number.Interface(), int64Type}}
+			}
+			sum += n
+		}
+		return float64(sum), nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		sum := uint64(0)
+		for _, number := range numbers {
+			n := number.Uint()
+			if sum > math.MaxUint64-n {
+				return 0, &FieldError{fieldName, &OverflowError{number.Interface(), uint64Type}}
+			}
+			sum += n
+		}
+		return float64(sum), nil
+	}
+
+	sum := 0.0
+	for _, number := range numbers {
+		sum += number.Float()
+	}
+	return sum, nil
+}
+
+// lessNumber reports whether a numeric value is less than another one of the
+// same kind, comparing the integers exactly.
+func lessNumber(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() < b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() < b.Uint()
+	}
+	return a.Float() < b.Float()
+}
+
+// numberFloat returns a numeric value as a float64.
+func numberFloat(number reflect.Value) float64 {
+	return number.Convert(reflect.TypeOf(float64(0))).Float()
+}
+
+// SumField returns the sum of a numeric field of all the elements of a slice of
+// structs (or pointers to structs), such as the total of the "Amount" fields of
+// a []Order. A nested field can be given by a dot separated path, such as
+// "Stats.Count".
+//
+// The field can be of any int, uint or float kind. Integers are added exactly
+// as int64 (or uint64 for the unsigned kinds) before the sum is converted to
+// float64, and a *FieldError with an *OverflowError is returned if the sum
+// doesn't fit in them. Floats are added as float64.
+//
+// ErrMismatchValue is returned if the field is not a number.
+func SumField(slice interface{}, fieldName string) (float64, error) {
+	numbers, err := numericFields(slice, fieldName)
+	if err != nil {
+		return 0, err
+	}
+
+	return sumNumbers(numbers, fieldName)
+}
+
+// AvgField returns the average of a numeric field of all the elements of a
+// slice of structs. The values are added like SumField. See SumField for more
+// details.
+//
+// ErrNoValues is returned for an empty slice.
+func AvgField(slice interface{}, fieldName string) (float64, error) {
+	numbers, err := numericFields(slice, fieldName)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(numbers) == 0 {
+		return 0, ErrNoValues
+	}
+
+	sum, err := sumNumbers(numbers, fieldName)
+	if err != nil {
+		return 0, err
+	}
+	return sum / float64(len(numbers)), nil
+}
+
+// MinField returns the minimum value of a numeric field of all the elements of
+// a slice of structs. Integers are compared exactly. See SumField for more
+// details.
+//
+// ErrNoValues is returned for an empty slice.
+func MinField(slice interface{}, fieldName string) (float64, error) {
+	numbers, err := numericFields(slice, fieldName)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(numbers) == 0 {
+		return 0, ErrNoValues
+	}
+
+	minimum := numbers[0]
+	for _, number := range numbers[1:] {
+		if lessNumber(number, minimum) {
+			minimum = number
+		}
+	}
+	return numberFloat(minimum), nil
+}
+
+// MaxField returns the maximum value of a numeric field of all the elements of
+// a slice of structs. Integers are compared exactly. See SumField for more
+// details.
+//
+// ErrNoValues is returned for an empty slice.
+func MaxField(slice interface{}, fieldName string) (float64, error) {
+	numbers, err := numericFields(slice, fieldName)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(numbers) == 0 {
+		return 0, ErrNoValues
+	}
+
+	maximum := numbers[0]
+	for _, number := range numbers[1:] {
+		if lessNumber(maximum, number) {
+			maximum = number
+		}
+	}
+	return numberFloat(maximum), nil
+}
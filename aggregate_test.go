@@ -0,0 +1,100 @@
+package attr
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Order struct {
+	Quantity uint8
+	Price    float32
+	Discount int64
+	Item     string
+}
+
+var orders = []*Order{
+	{2, 10.5, -1, "pen"},
+	{1, 99, 0, "book"},
+	{5, 1.25, -3, "clip"},
+}
+
+func TestSumField(t *testing.T) {
+	for _, test := range []struct {
+		attrName string
+		want     float64
+	}{
+		{"Quantity", 8},
+		{"Price", 110.75},
+		{"Discount", -4},
+	} {
+		got, err := SumField(orders, test.attrName)
+		require.Nil(t, err)
+		require.Equal(t, test.want, got, "Sum of %s is not correct", test.attrName)
+	}
+
+	got, err := SumField([]Order{}, "Price")
+	require.Nil(t, err)
+	require.Equal(t, 0.0, got, "Sum of an empty slice is not correct")
+
+	_, err = SumField(orders, "Item")
+	require.Equal(t, ErrMismatchValue, err, "Able to sum a string field")
+
+	// Integers are added exactly, and an overflow is reported.
+	type Counter struct {
+		N int64
+		U uint64
+	}
+	counters := []Counter{{1 << 60, 1 << 63}, {1, 1 << 63}, {-1 << 60, 0}}
+	got, err = SumField(counters, "N")
+	require.Nil(t, err)
+	require.Equal(t, 1.0, got, "Sum of large integers is not exact")
+
+	got, err = AvgField(counters[:2], "N")
+	require.Nil(t, err)
+	require.Equal(t, float64(1<<59), got, "Average of large integers is not correct")
+
+	_, err = SumField(counters, "U")
+	require.Equal(t, &FieldError{"U", &OverflowError{uint64(1 << 63), reflect.TypeOf(uint64(0))}}, err,
+		"Overflow of the sum not reported")
+	_, err = SumField([]Counter{{N: math.MinInt64}, {N: -1}}, "N")
+	require.True(t, errors.Is(err, ErrOverflow), "Overflow of the sum not reported")
+}
+
+func TestAvgMinMaxField(t *testing.T) {
+	got, err := AvgField(orders, "Quantity")
+	require.Nil(t, err)
+	require.InDelta(t, 8.0/3, got, 1e-9, "Average is not correct")
+
+	got, err = MinField(orders, "Discount")
+	require.Nil(t, err)
+	require.Equal(t, -3.0, got, "Minimum is not correct")
+
+	got, err = MaxField(orders, "Price")
+	require.Nil(t, err)
+	require.Equal(t, 99.0, got, "Maximum is not correct")
+
+	for _, fn := range []func(interface{}, string) (float64, error){AvgField, MinField, MaxField} {
+		_, err := fn([]Order{}, "Price")
+		require.Equal(t, ErrNoValues, err, "Able to aggregate an empty slice")
+	}
+}
+
+func ExampleSumField() {
+	orders := []Order{{Quantity: 2, Price: 10}, {Quantity: 1, Price: 5.5}}
+
+	total, err := SumField(orders, "Price")
+	if err != nil {
+		// Handle error.
+	}
+	max, err := MaxField(orders, "Quantity")
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Printf("Total: %.2f, max quantity: %.0f\n", total, max)
+	// Output: Total: 15.50, max quantity: 2
+}
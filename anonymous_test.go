@@ -0,0 +1,129 @@
+package attr
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newPoint returns an anonymous struct value, whose type has no name.
+func newPoint() *struct {
+	X     int    `json:"x" url:"x"`
+	Y     int    `json:"y,omitempty" url:"y"`
+	Label string `json:"label" url:"label"`
+	Inner struct {
+		Z int `json:"z" url:"z"`
+	} `json:"inner" url:"inner"`
+	hidden bool
+} {
+	point := &struct {
+		X     int    `json:"x" url:"x"`
+		Y     int    `json:"y,omitempty" url:"y"`
+		Label string `json:"label" url:"label"`
+		Inner struct {
+			Z int `json:"z" url:"z"`
+		} `json:"inner" url:"inner"`
+		hidden bool
+	}{X: 1, Label: "origin"}
+	point.Inner.Z = 3
+	return point
+}
+
+func TestAnonymousStructs(t *testing.T) {
+	point := newPoint()
+
+	value, err := GetValue(point, "X")
+	require.Nil(t, err)
+	require.Equal(t, 1, value, "Value is not correct")
+	require.Nil(t, SetValue(point, "Y", 2))
+	require.Equal(t, 2, point.Y, "Value is not set")
+	require.Equal(t, ErrUnexportedField, SetValue(point, "hidden", true))
+
+	names, err := Names(point)
+	require.Nil(t, err)
+	require.Equal(t, []string{"X", "Y", "Label", "Inner"}, names, "Names are not correct")
+
+	values, err := Values(*point)
+	require.Nil(t, err)
+	require.Equal(t, 2, values["Y"], "Values are not correct")
+
+	tags, err := Tags(point, "json")
+	require.Nil(t, err)
+	require.Equal(t, "x", tags["X"], "Tags are not correct")
+
+	inner, ok := TryGet[int](point, "Inner.Z")
+	require.True(t, ok)
+	require.Equal(t, 3, inner, "Nested value is not correct")
+
+	jsonValues, err := JSONValues(point)
+	require.Nil(t, err)
+	require.Equal(t, map[string]interface{}{"x": 1, "y": 2, "label": "origin",
+		"inner": map[string]interface{}{"z": 3}}, jsonValues, "JSON values are not correct")
+
+	query, err := ToValues(point, "url")
+	require.Nil(t, err)
+	require.Equal(t, url.Values{"x": {"1"}, "y": {"2"}, "label": {"origin"}, "inner.z": {"3"}}, query,
+		"URL values are not correct")
+
+	err = FromMap(point, map[string]interface{}{"x": 5, "inner": map[string]interface{}{"z": 6}}, "json")
+	require.Nil(t, err)
+	require.Equal(t, 5, point.X, "Value is not set from the map")
+	require.Equal(t, 6, point.Inner.Z, "Nested value is not set from the map")
+
+	info, err := Describe(point)
+	require.Nil(t, err)
+	require.Equal(t, "", info.Name, "Name of an anonymous struct is not empty")
+	require.True(t, strings.HasPrefix(info.Type, "struct {"), "Type is not correct")
+
+	changes, err := Diff(newPoint(), point)
+	require.Nil(t, err)
+	require.Len(t, changes, 3, "Changes are not correct")
+
+	accessor := For[struct{ A, B int }]()
+	pair := struct{ A, B int }{1, 2}
+	require.Nil(t, accessor.Set(&pair, "B", 4))
+	require.Equal(t, 4, pair.B, "Value is not set with the accessor")
+}
+
+func TestAnonymousStructCache(t *testing.T) {
+	// Two anonymous struct types with the same field names but different
+	// field types must not share the cached field lookups.
+	a := struct{ V int }{1}
+	b := struct{ V string }{"one"}
+	for i := 0; i < 2; i++ {
+		value, err := GetValue(a, "V")
+		require.Nil(t, err)
+		require.Equal(t, 1, value)
+
+		value, err = GetValue(b, "V")
+		require.Nil(t, err)
+		require.Equal(t, "one", value)
+	}
+
+	kind, err := GetKind(b, "V")
+	require.Nil(t, err)
+	require.Equal(t, "string", kind)
+}
+
+func TestRetagInterop(t *testing.T) {
+	retagged, err := Retag(newPoint(), func(_ string, tag reflect.StructTag) reflect.StructTag {
+		return reflect.StructTag(strings.ReplaceAll(string(tag), "json:", "bson:"))
+	})
+	require.Nil(t, err)
+
+	tags, err := Tags(retagged, "bson")
+	require.Nil(t, err)
+	require.Equal(t, "label", tags["Label"], "Tags of the built type are not correct")
+
+	require.Nil(t, SetValue(retagged, "Label", "moved"))
+	values, err := JSONValues(retagged)
+	require.Nil(t, err)
+	require.Equal(t, "moved", values["Label"], "Values of the built type are not correct")
+
+	graph, err := GraphMermaid(retagged)
+	require.Nil(t, err)
+	require.Contains(t, graph, "class Struct0", "Diagram of the built type is not correct")
+}
@@ -0,0 +1,82 @@
+package attr
+
+import (
+	"reflect"
+	"sync"
+)
+
+// fieldLocks serializes the atomic field operations. A struct is mapped to one
+// of the locks by its address, so that the operations on different structs
+// rarely contend, without having to keep a lock per struct alive forever.
+var fieldLocks [64]sync.Mutex
+
+// lockFor returns the lock guarding the atomic field operations on a struct.
+func lockFor(objValue reflect.Value) *sync.Mutex {
+	// Drop the low bits as structs are at least word aligned.
+	return &fieldLocks[(objValue.Pointer()>>4)%uintptr(len(fieldLocks))]
+}
+
+// CompareAndSwapField sets the given field of a struct to 'newValue' only if
+// its current value is equal to 'oldValue', and returns true if the swap was
+// done. The comparison and the update are done atomically with respect to the
+// other CompareAndSwapField and SwapField calls on the same struct.
+// Only exported (public) fields can be set using this API.
+//
+// NOTE: 'obj' struct must be passed by pointer for this API to work. Passing by
+// value results in ErrNotPtr.
+func CompareAndSwapField(obj interface{}, fieldName string, oldValue, newValue interface{}) (bool, error) {
+	fieldValue, err := getFieldByPtr(obj, fieldName)
+	if err != nil {
+		return false, err
+	}
+
+	if fieldValue.Type() != reflect.TypeOf(oldValue) ||
+		fieldValue.Type() != reflect.TypeOf(newValue) {
+		return false, ErrMismatchValue
+	}
+
+	if !fieldValue.CanSet() {
+		return false, ErrUnexportedField
+	}
+
+	lock := lockFor(valueOf(obj))
+	lock.Lock()
+	defer lock.Unlock()
+
+	if !reflect.DeepEqual(fieldValue.Interface(), oldValue) {
+		return false, nil
+	}
+
+	fieldValue.Set(reflect.ValueOf(newValue))
+	return true, nil
+}
+
+// SwapField sets the given field of a struct to 'newValue' and returns its
+// previous value. The swap is done atomically with respect to the other
+// CompareAndSwapField and SwapField calls on the same struct.
+// Only exported (public) fields can be set using this API.
+//
+// NOTE: 'obj' struct must be passed by pointer for this API to work. Passing by
+// value results in ErrNotPtr.
+func SwapField(obj interface{}, fieldName string, newValue interface{}) (interface{}, error) {
+	fieldValue, err := getFieldByPtr(obj, fieldName)
+	if err != nil {
+		return nil, err
+	}
+
+	if fieldValue.Type() != reflect.TypeOf(newValue) {
+		return nil, ErrMismatchValue
+	}
+
+	if !fieldValue.CanSet() {
+		return nil, ErrUnexportedField
+	}
+
+	lock := lockFor(valueOf(obj))
+	lock.Lock()
+	defer lock.Unlock()
+
+	oldValue := fieldValue.Interface()
+	fieldValue.Set(reflect.ValueOf(newValue))
+	return oldValue, nil
+}
@@ -0,0 +1,81 @@
+package attr
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareAndSwapField(t *testing.T) {
+	testUser := User{Username: "srathi", Age: 30}
+	for _, test := range []struct {
+		attrName string
+		oldValue interface{}
+		newValue interface{}
+		want     bool
+		wantErr  error
+		errMsg   string
+	}{
+		{"Age", 40, 50, false, nil, "Swapped a field with a different current value"},
+		{"Age", 30, 40, true, nil, "Field not swapped"},
+		{"Age", 40, "50", false, ErrMismatchValue, "Able to swap a value of a different type"},
+		{"password", "", "abc", false, ErrUnexportedField, "Able to swap a private field"},
+		{"ABC", 1, 2, false, ErrNoField, "Able to swap a non-existent field"},
+	} {
+		got, err := CompareAndSwapField(&testUser, test.attrName, test.oldValue, test.newValue)
+		require.Equal(t, test.wantErr, err, test.errMsg)
+		require.Equal(t, test.want, got, test.errMsg)
+	}
+	require.Equal(t, 40, testUser.Age, "Swapped value not set in the struct")
+
+	_, err := CompareAndSwapField(testUser, "Age", 40, 50)
+	require.Equal(t, ErrNotPtr, err, "Able to swap a field of a struct by value")
+
+	// Concurrent increments must not lose any update.
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for age := 40; ; age++ {
+				if ok, _ := CompareAndSwapField(&testUser, "Age", age, age+1); ok {
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, 50, testUser.Age, "Concurrent swaps lost an update")
+}
+
+func TestSwapField(t *testing.T) {
+	testUser := User{Username: "srathi", Age: 30}
+	got, err := SwapField(&testUser, "Username", "new-srathi")
+	require.Nil(t, err)
+	require.Equal(t, "srathi", got, "Old value is not correct")
+	require.Equal(t, "new-srathi", testUser.Username, "New value not set in the struct")
+
+	_, err = SwapField(&testUser, "Username", 10)
+	require.Equal(t, ErrMismatchValue, err, "Able to swap a value of a different type")
+}
+
+func ExampleCompareAndSwapField() {
+	testUser := User{Username: "srathi", password: "secret", Age: 30}
+
+	swapped, err := CompareAndSwapField(&testUser, "Age", 30, 31)
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Printf("Swapped: %v, Age: %d\n", swapped, testUser.Age)
+
+	swapped, err = CompareAndSwapField(&testUser, "Age", 30, 32)
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Printf("Swapped: %v, Age: %d\n", swapped, testUser.Age)
+	// Output:
+	// Swapped: true, Age: 31
+	// Swapped: false, Age: 31
+}
@@ -27,11 +27,18 @@
 //
 // 	err = attr.SetValue(&user, "Username", "srathi-alt")
 // 	fmt.Printf("New username: %s\n", user.Username) // prints "srathi-alt"
+//
+// The APIs also accept a reflect.Value of a struct (or a slice) in place of
+// the object itself, to compose with existing reflection code. An addressable
+// struct value, such as reflect.ValueOf(&user).Elem(), works like a pointer to
+// the struct, so its fields can be set too.
 package attr
 
 import (
 	"errors"
+	"fmt"
 	"reflect"
+	"regexp"
 )
 
 // Error values.
@@ -41,28 +48,123 @@ var (
 	ErrNotStruct       = errors.New("Given object is not a struct or a pointer to a struct")
 	ErrUnexportedField = errors.New("Specified field is not an exported or public field")
 	ErrMismatchValue   = errors.New("Specified value to set is of a different type")
+	ErrInvalidTag      = errors.New("Specified field has an invalid tag value")
+	ErrNilPointer      = errors.New("Specified struct or field is a nil pointer")
+	ErrNoValues        = errors.New("Specified struct has no field values to use")
+	ErrInvalidValue    = errors.New("Specified value can't be parsed as the field type")
+	ErrInvalidExpr     = errors.New("Specified expression is not valid")
+	ErrNotSlice        = errors.New("Given object is not a slice of structs or a pointer to it")
+	ErrCycleDetected   = errors.New("Specified struct references itself through a pointer")
+	ErrUnknownKey      = errors.New("Specified key doesn't match any struct field")
+	ErrInvalidHook     = errors.New("Specified decode hook is not a supported function type")
+	ErrAccessDenied    = errors.New("Specified field is not accessible by the given role")
+	ErrNameInUse       = errors.New("Specified name is already published")
+	ErrOverflow        = errors.New("Specified value overflows the field type")
+	ErrOutOfRange      = errors.New("Specified index is out of range of the slice or array")
+	ErrFixedSize       = errors.New("Specified field is a fixed size array")
+	ErrFuncField       = errors.New("Specified field is a func or a channel")
+	ErrNotCallable     = errors.New("Specified field is not a func or is nil")
+	ErrNotMap          = errors.New("Given object is not a map of structs or a pointer to it")
+	ErrNoKey           = errors.New("Specified key is not present in the map")
+	ErrIndirectField   = errors.New("Specified field is promoted through an embedded pointer")
 )
 
+// FieldError is returned by the APIs which process many fields at once, to
+// tell which field caused the error. Field is the field name or a dot separated
+// path of names for a nested field, and Err is one of the error values above
+// (or the error returned by a DecodeHook). Use errors.Is to check for a
+// specific error value.
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+// Error returns the error message prefixed by the field name, or the message
+// given by the function set with SetErrorFormatter.
+func (e *FieldError) Error() string {
+	if msg, ok := formatFieldError(e); ok {
+		return msg
+	}
+	return e.Field + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error value.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// NilPathError is returned by the APIs which read a nested field through a
+// path (such as RawField), when a pointer field in the path is nil. Path is the
+// path of the nil field and Segment is its position in the path, starting
+// from 1. For a field promoted through a nil embedded pointer, Path ends with
+// that field. It wraps ErrNilPointer, so use errors.Is to check for it.
+type NilPathError struct {
+	Path    string
+	Segment int
+}
+
+// Error returns the error message with the path of the nil field.
+func (e *NilPathError) Error() string {
+	return fmt.Sprintf("%s is nil at segment %d", e.Path, e.Segment)
+}
+
+// Unwrap returns ErrNilPointer.
+func (e *NilPathError) Unwrap() error {
+	return ErrNilPointer
+}
+
+// OverflowError is returned by the APIs which decode a struct from loosely
+// typed data (such as FromMap), when a number doesn't fit in the numeric type
+// of a field or loses its fractional part, such as 300 for an int8 field, -1
+// for a uint field or 1.5 for an int field. Value is the given value and Type
+// is the field type. It is wrapped in a *FieldError with the field name, and
+// wraps ErrOverflow, so use errors.Is or errors.As to check for it.
+type OverflowError struct {
+	Value interface{}
+	Type  reflect.Type
+}
+
+// Error returns the error message with the value and the field type.
+func (e *OverflowError) Error() string {
+	return fmt.Sprintf("%v overflows %s", e.Value, e.Type)
+}
+
+// Unwrap returns ErrOverflow.
+func (e *OverflowError) Unwrap() error {
+	return ErrOverflow
+}
+
 // GetValue returns the value of a given field of a structure given by 'obj'.
 // 'obj' can be passed by value or by pointer.
 // Only exported (public) field values can be found (else ErrUnexportedField is raised).
 //
 // If the field is not found, then an error is returned.
-func GetValue(obj interface{}, fieldName string) (interface{}, error) {
+//
+// Use WithNullValues to get the value held by a sql.Null* field, or nil, and
+// WithAccessors to call a "Get<fieldName>" method if there is no such field.
+func GetValue(obj interface{}, fieldName string, opts ...Option) (interface{}, error) {
 	objValue, err := getReflectValue(obj)
 	if err != nil {
 		return nil, err
 	}
 
-	fieldValue := objValue.FieldByName(fieldName)
-	if !fieldValue.IsValid() {
-		return nil, ErrNoField
+	fieldValue, err := fieldByName(objValue, fieldName)
+	if err == ErrNoField && len(opts) > 0 && newOptions(opts).accessors {
+		return getterValue(obj, fieldName)
+	}
+	if err != nil {
+		return nil, err
 	}
 
 	if !fieldValue.CanInterface() {
 		return nil, ErrUnexportedField
 	}
 
+	if len(opts) > 0 && newOptions(opts).nullValues {
+		if value, ok := nullValue(fieldValue); ok {
+			return value, nil
+		}
+	}
 	return fieldValue.Interface(), nil
 }
 
@@ -74,88 +176,147 @@ func Has(obj interface{}, fieldName string) (bool, error) {
 		return false, err
 	}
 
-	structType := objValue.Type()
-	_, found := structType.FieldByName(fieldName)
+	_, found := cachedField(objValue.Type(), fieldName)
 	return found, nil
 }
 
 // SetValue sets the given value to the fieldName field in the given struct 'obj'.
 // Only exported (public) fields can be set using this API.
 //
+// If the field type implements sql.Scanner (such as sql.NullString or a
+// decimal type), a value of any other type is set through its Scan method, and
+// the error returned by Scan is returned as is. So a sql.Null* field can be set
+// with the value it holds (such as a string for sql.NullString), or with nil to
+// make it invalid.
+//
+// Use WithChangeHook to get a ChangeRecord of the change, and WithAccessors to
+// call a "Set<fieldName>" method if there is no such field.
+//
 // NOTE: 'obj' struct must be passed by pointer for this API to work. Passing by
 // value results in ErrPassedByValue.
-func SetValue(obj interface{}, fieldName string, newValue interface{}) error {
-	objValue := reflect.ValueOf(obj)
-	if objValue.Kind() != reflect.Ptr {
-		return ErrNotPtr
+func SetValue(obj interface{}, fieldName string, newValue interface{}, opts ...Option) error {
+	fieldValue, err := getFieldByPtr(obj, fieldName)
+	if err == ErrNoField && len(opts) > 0 && newOptions(opts).accessors {
+		return callSetter(obj, fieldName, newValue)
+	}
+	if err != nil {
+		return err
 	}
 
-	objValue = objValue.Elem()
-	if objValue.Kind() != reflect.Struct {
-		return ErrNotStruct
+	value, err := assignableValue(fieldValue, newValue)
+	if err != nil {
+		return err
 	}
 
-	fieldValue := objValue.FieldByName(fieldName)
-	if !fieldValue.IsValid() {
-		return ErrNoField
+	if len(opts) == 0 {
+		fieldValue.Set(value)
+		return nil
 	}
 
-	if fieldValue.Type() != reflect.TypeOf(newValue) {
-		return ErrMismatchValue
+	oldValue := fieldValue.Interface()
+	fieldValue.Set(value)
+	newOptions(opts).emitChange(fieldName, oldValue, value.Interface())
+	return nil
+}
+
+// assignableValue returns the reflect-value to set to a field for a new value,
+// converting it through sql.Scanner if the field type implements it, like
+// SetValue does.
+func assignableValue(fieldValue reflect.Value, newValue interface{}) (reflect.Value, error) {
+	scan := fieldValue.Type() != reflect.TypeOf(newValue)
+	if scan && !isScanner(fieldValue.Type()) {
+		return reflect.Value{}, ErrMismatchValue
 	}
 
 	if !fieldValue.CanSet() {
-		return ErrUnexportedField
+		return reflect.Value{}, ErrUnexportedField
 	}
 
-	fieldValue.Set(reflect.ValueOf(newValue))
-	return nil
+	if scan {
+		return scanValue(newValue, fieldValue.Type())
+	}
+	return reflect.ValueOf(newValue), nil
 }
 
 // Names returns a slice of all field names of a given struct.
 // Only the exportable (public) field names are returned.
-func Names(obj interface{}) ([]string, error) {
+// Use WithSquash to list the fields of the embedded structs instead of the
+// embedded structs themselves, WithUnexported to list the unexported fields
+// too, and WithFieldPattern to list only the fields matching a pattern.
+func Names(obj interface{}, opts ...Option) ([]string, error) {
 	objValue, err := getReflectValue(obj)
 	if err != nil {
 		return nil, err
 	}
 
 	fieldNames := []string{}
-	objType := objValue.Type()
-	for i := 0; i < objValue.NumField(); i++ {
-		fieldType := objType.Field(i)
-		fieldValue := objValue.Field(i)
-
-		if fieldValue.CanInterface() {
-			fieldNames = append(fieldNames, fieldType.Name)
-		}
+	for _, field := range structFields(objValue, newOptions(opts)) {
+		fieldNames = append(fieldNames, field.Name)
 	}
 
 	return fieldNames, nil
 }
 
+// NamesMatching returns the names of the fields of a struct which match the
+// given regular expression, such as "^Secret" or "At$", like Names with the
+// WithFieldPattern option. ErrInvalidExpr is returned if the pattern is not a
+// valid regular expression.
+func NamesMatching(obj interface{}, pattern string, opts ...Option) ([]string, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, ErrInvalidExpr
+	}
+	return Names(obj, append(opts, WithFieldPattern(re))...)
+}
+
 // Values returns a map of all field names with the value of each field.
 // Only the exportable (public) field name-value pairs are returned.
-func Values(obj interface{}) (map[string]interface{}, error) {
+// Use WithSquash to promote the fields of the embedded structs. The promoted
+// fields of a nil embedded pointer get the zero value of their types.
+// Use WithNullValues to get the values held by the sql.Null* fields, or nil,
+// WithFuncPolicy to skip or reject the func and chan fields, and
+// WithFieldPattern to get only the fields matching a pattern.
+func Values(obj interface{}, opts ...Option) (map[string]interface{}, error) {
 	objValue, err := getReflectValue(obj)
 	if err != nil {
 		return nil, err
 	}
 
-	valueMap := map[string]interface{}{}
-	objType := objValue.Type()
-	for i := 0; i < objValue.NumField(); i++ {
-		fieldType := objType.Field(i)
-		fieldValue := objValue.Field(i)
-
-		if fieldValue.CanInterface() {
-			valueMap[fieldType.Name] = fieldValue.Interface()
+	o := newOptions(opts)
+	fields := structFields(objValue, o)
+	valueMap := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		value, ok, err := fieldValueOf(field, o)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			valueMap[field.Name] = value
 		}
 	}
 
 	return valueMap, nil
 }
 
+// fieldValueOf returns the value of a field for Values, after applying the
+// options. It returns false if the field is to be skipped.
+func fieldValueOf(field structField, o *options) (interface{}, bool, error) {
+	if !field.value.CanInterface() {
+		return nil, false, nil
+	}
+
+	if skip, err := o.skipFunc(field.value, field.Name); skip {
+		return nil, false, err
+	}
+
+	if o.nullValues {
+		if value, ok := nullValue(field.value); ok {
+			return value, true, nil
+		}
+	}
+	return field.value.Interface(), true, nil
+}
+
 // GetTag returns the value of a specified tag on a specified struct field.
 // Specified field must be an exportable (public) filed of the struct.
 func GetTag(obj interface{}, fieldName, tagKey string) (string, error) {
@@ -164,8 +325,7 @@ func GetTag(obj interface{}, fieldName, tagKey string) (string, error) {
 		return "", err
 	}
 
-	structType := objValue.Type()
-	field, found := structType.FieldByName(fieldName)
+	field, found := cachedField(objValue.Type(), fieldName)
 	if !found {
 		return "", ErrNoField
 	}
@@ -179,21 +339,18 @@ func GetTag(obj interface{}, fieldName, tagKey string) (string, error) {
 
 // Tags returns a map of all the tag values of a given tag key from all
 // the exported (public) struct fields.
-func Tags(obj interface{}, tagKey string) (map[string]string, error) {
+// Use WithSquash to promote the fields of the embedded structs,
+// WithUnexported to include the unexported fields, and WithFieldPattern to
+// include only the fields matching a pattern.
+func Tags(obj interface{}, tagKey string, opts ...Option) (map[string]string, error) {
 	objValue, err := getReflectValue(obj)
 	if err != nil {
 		return nil, err
 	}
 
 	tagMap := map[string]string{}
-	objType := objValue.Type()
-	for i := 0; i < objValue.NumField(); i++ {
-		fieldType := objType.Field(i)
-		fieldValue := objValue.Field(i)
-
-		if fieldValue.CanInterface() {
-			tagMap[fieldType.Name] = fieldType.Tag.Get(tagKey)
-		}
+	for _, field := range structFields(objValue, newOptions(opts)) {
+		tagMap[field.Name] = field.Tag.Get(tagKey)
 	}
 
 	return tagMap, nil
@@ -207,9 +364,9 @@ func GetKind(obj interface{}, fieldName string) (string, error) {
 		return "", err
 	}
 
-	fieldValue := objValue.FieldByName(fieldName)
-	if !fieldValue.IsValid() {
-		return "", ErrNoField
+	fieldValue, err := fieldByName(objValue, fieldName)
+	if err != nil {
+		return "", err
 	}
 
 	if !fieldValue.CanInterface() {
@@ -221,37 +378,187 @@ func GetKind(obj interface{}, fieldName string) (string, error) {
 
 // Kinds returns the 'kind' of all the public fields of a struct. "Kind" is
 // the in-built type of a variable, such as Uint64, Slice, Struct, Ptr, etc.
-func Kinds(obj interface{}) (map[string]string, error) {
+// Use WithSquash to promote the fields of the embedded structs,
+// WithUnexported to include the unexported fields, and WithFieldPattern to
+// include only the fields matching a pattern.
+func Kinds(obj interface{}, opts ...Option) (map[string]string, error) {
 	objValue, err := getReflectValue(obj)
 	if err != nil {
 		return nil, err
 	}
 
 	kindMap := map[string]string{}
+	for _, field := range structFields(objValue, newOptions(opts)) {
+		kindMap[field.Name] = field.value.Kind().String()
+	}
+
+	return kindMap, nil
+}
+
+// structField is a struct field along with its value, as returned by
+// structFields.
+type structField struct {
+	reflect.StructField
+	value reflect.Value
+	depth int
+}
+
+// structFields returns the exported (public) fields of a struct in their
+// declaration order, and the unexported ones too with the unexported option.
+// With the squash option, the fields of the embedded structs are promoted in
+// place of the embedded structs, following the Go rule that a shallower field
+// hides a deeper field of the same name. With the field pattern option, only
+// the fields whose names match the pattern are returned.
+func structFields(objValue reflect.Value, o *options) []structField {
+	fields := make([]structField, 0, objValue.NumField())
+	collectFields(objValue, o, 0, map[reflect.Type]bool{}, &fields)
+	if o.fieldPattern == nil {
+		return fields
+	}
+
+	matched := fields[:0]
+	for _, field := range fields {
+		if o.fieldPattern.MatchString(field.Name) {
+			matched = append(matched, field)
+		}
+	}
+	return matched
+}
+
+// collectFields appends the fields of a struct at a given embedding
+// depth to 'fields'. 'inProgress' stops the recursion on recursive embedding.
+func collectFields(objValue reflect.Value, o *options, depth int,
+	inProgress map[reflect.Type]bool, fields *[]structField) {
 	objType := objValue.Type()
+	inProgress[objType] = true
+	defer delete(inProgress, objType)
+
 	for i := 0; i < objValue.NumField(); i++ {
 		fieldType := objType.Field(i)
 		fieldValue := objValue.Field(i)
 
-		if fieldValue.CanInterface() {
-			kindMap[fieldType.Name] = fieldValue.Kind().String()
+		exported := fieldType.PkgPath == ""
+		if o.squash && fieldType.Anonymous && o.embedFilter != nil && !o.embedFilter(fieldType) {
+			continue
+		}
+		if o.squash && fieldType.Anonymous && (exported || o.unexported) {
+			embedded := fieldValue
+			if embedded.Kind() == reflect.Ptr {
+				if embedded.IsNil() {
+					embedded = reflect.Zero(embedded.Type().Elem())
+				} else {
+					embedded = embedded.Elem()
+				}
+			}
+			if embedded.Kind() == reflect.Struct && embedded.Type() != timeType &&
+				!inProgress[embedded.Type()] {
+				collectFields(embedded, o, depth+1, inProgress, fields)
+				continue
+			}
+		}
+
+		if !fieldValue.CanInterface() && !o.unexported {
+			continue
+		}
+
+		field := structField{fieldType, fieldValue, depth}
+		if !o.squash {
+			// The field names of a single struct are unique.
+			*fields = append(*fields, field)
+			continue
+		}
+
+		found := false
+		for j := range *fields {
+			if (*fields)[j].Name != fieldType.Name {
+				continue
+			}
+			if (*fields)[j].depth > depth {
+				(*fields)[j] = field
+			}
+			found = true
+			break
+		}
+		if !found {
+			*fields = append(*fields, field)
 		}
 	}
+}
 
-	return kindMap, nil
+// getStructByPtr gets the reflect-value of a struct passed by pointer, so that
+// its fields are addressable and can be set.
+//
+// Returns an error if obj is not a pointer to a struct, and ErrNilPointer if
+// it is a nil pointer to a struct.
+func getStructByPtr(obj interface{}) (reflect.Value, error) {
+	objValue := valueOf(obj)
+	if objValue.Kind() != reflect.Ptr {
+		return reflect.Value{}, ErrNotPtr
+	}
+
+	if objValue.IsNil() && objValue.Type().Elem().Kind() == reflect.Struct {
+		return reflect.Value{}, ErrNilPointer
+	}
+
+	objValue = objValue.Elem()
+	if objValue.Kind() != reflect.Struct {
+		return reflect.Value{}, ErrNotStruct
+	}
+
+	return objValue, nil
+}
+
+// getFieldByPtr gets the reflect-value of a given field of a struct passed by
+// pointer, so that the returned field value is addressable.
+//
+// Returns an error if obj is not a pointer to a struct or the field is not found.
+func getFieldByPtr(obj interface{}, fieldName string) (reflect.Value, error) {
+	var retval reflect.Value
+	objValue, err := getStructByPtr(obj)
+	if err != nil {
+		return retval, err
+	}
+
+	fieldValue, err := fieldByName(objValue, fieldName)
+	if err != nil {
+		return retval, err
+	}
+
+	return fieldValue, nil
+}
+
+// valueOf returns the reflect-value of an object given to the APIs. A
+// reflect.Value is used as it is instead of being wrapped again, so that the
+// callers holding one don't lose its addressability with an Interface() round
+// trip. An addressable struct value is given as a pointer to the struct, like a
+// struct passed by pointer.
+func valueOf(obj interface{}) reflect.Value {
+	value, ok := obj.(reflect.Value)
+	if !ok {
+		return reflect.ValueOf(obj)
+	}
+	if value.Kind() == reflect.Struct && value.CanAddr() {
+		return value.Addr()
+	}
+	return value
 }
 
 // getReflectValue gets a reflect-value of a given struct. If it is a pointer
 // to a struct, then it gives the reflect-value of the underlying structure.
 //
-// Returns an error if the given obj is not a struct or a pointer to a struct.
+// Returns an error if the given obj is not a struct or a pointer to a struct,
+// and ErrNilPointer if it is a nil pointer to a struct, such as (*User)(nil).
 func getReflectValue(obj interface{}) (reflect.Value, error) {
-	value := reflect.ValueOf(obj)
+	value := valueOf(obj)
 
 	if value.Kind() == reflect.Struct {
 		return value, nil
 	}
 
+	if value.Kind() == reflect.Ptr && value.IsNil() && value.Type().Elem().Kind() == reflect.Struct {
+		return reflect.Value{}, ErrNilPointer
+	}
+
 	if value.Kind() == reflect.Ptr && value.Elem().Kind() == reflect.Struct {
 		return value.Elem(), nil
 	}
@@ -2,6 +2,9 @@ package attr
 
 import (
 	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -293,3 +296,208 @@ func ExampleKinds() {
 	fmt.Printf("Field kinds: %v", kinds)
 	// Output: Field kinds: map[Age:int Username:string]
 }
+
+type Admin struct {
+	*User
+	Manager
+	Level int `json:"level"`
+}
+
+func TestSquash(t *testing.T) {
+	manager := Manager{User: user}
+
+	got, err := Names(&manager)
+	require.Nil(t, err)
+	require.Equal(t, []string{"User", "Reports"}, got, "Embedded struct is squashed by default")
+
+	got, err = Names(&manager, WithSquash())
+	require.Nil(t, err)
+	require.Equal(t, []string{"Username", "Age", "Reports"}, got, "Embedded struct is not squashed")
+
+	values, err := Values(&manager, WithSquash())
+	require.Nil(t, err)
+	require.Equal(t, map[string]interface{}{
+		"Username": "srathi", "Age": 30, "Reports": []*User(nil)}, values,
+		"Squashed values are not correct")
+
+	// The shallower "User" fields hide the ones promoted through "Manager", and
+	// the fields of a nil embedded pointer get zero values.
+	admin := Admin{Manager: manager, Level: 2}
+	values, err = Values(&admin, WithSquash())
+	require.Nil(t, err)
+	require.Equal(t, map[string]interface{}{
+		"Username": "", "Age": 0, "Reports": []*User(nil), "Level": 2}, values,
+		"Shadowed values are not correct")
+
+	tags, err := Tags(&admin, "json", WithSquash())
+	require.Nil(t, err)
+	require.Equal(t, map[string]string{
+		"Username": "username", "Age": "age", "Reports": "reports", "Level": "level"}, tags,
+		"Squashed tags are not correct")
+
+	kinds, err := Kinds(&admin, WithSquash())
+	require.Nil(t, err)
+	require.Equal(t, "slice", kinds["Reports"], "Squashed kinds are not correct")
+}
+
+func ExampleWithSquash() {
+	// type Manager struct {
+	// 	User
+	// 	Reports []*User `json:"reports"`
+	// }
+	manager := Manager{User: User{Username: "srathi", password: "secret", Age: 30}}
+
+	fields, err := Names(&manager)
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Printf("Nested: %v\n", fields)
+
+	fields, err = Names(&manager, WithSquash())
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Printf("Squashed: %v\n", fields)
+	// Output:
+	// Nested: [User Reports]
+	// Squashed: [Username Age Reports]
+}
+
+func TestUnexported(t *testing.T) {
+	names, err := Names(&user, WithUnexported())
+	require.Nil(t, err)
+	require.Equal(t, []string{"Username", "Age", "password"}, names, "Unexported field not listed")
+
+	kinds, err := Kinds(user, WithUnexported())
+	require.Nil(t, err)
+	require.Equal(t, map[string]string{"Username": "string", "Age": "int", "password": "string"},
+		kinds, "Kind of the unexported field not listed")
+
+	tags, err := Tags(&user, "json", WithUnexported())
+	require.Nil(t, err)
+	require.Equal(t, map[string]string{"Username": "username", "Age": "age", "password": ""},
+		tags, "Tag of the unexported field not listed")
+
+	// Values can't be read from the unexported fields.
+	values, err := Values(&user, WithUnexported())
+	require.Nil(t, err)
+	require.Equal(t, map[string]interface{}{"Username": "srathi", "Age": 30}, values,
+		"Value of an unexported field is returned")
+
+	names, err = Names(&Manager{}, WithUnexported(), WithSquash())
+	require.Nil(t, err)
+	require.Equal(t, []string{"Username", "Age", "password", "Reports"}, names,
+		"Unexported field of an embedded struct not listed")
+}
+
+func ExampleWithUnexported() {
+	testUser := User{Username: "srathi", password: "secret", Age: 30}
+
+	fields, err := Names(&testUser, WithUnexported())
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Printf("Field names: %v", fields)
+	// Output: Field names: [Username Age password]
+}
+
+type Server struct {
+	Addr    string `url:"addr"`
+	Handler func(string) string
+	Done    chan struct{}
+	Hook    interface{}
+}
+
+func TestFuncPolicy(t *testing.T) {
+	server := Server{Addr: ":80", Handler: strings.ToUpper, Done: make(chan struct{}), Hook: "none"}
+
+	values, err := Values(server)
+	require.Nil(t, err)
+	require.Equal(t, 4, len(values), "Func and chan fields not included by default")
+
+	values, err = Values(server, WithFuncPolicy(FuncSkip))
+	require.Nil(t, err)
+	require.Equal(t, map[string]interface{}{"Addr": ":80", "Hook": "none"}, values,
+		"Func and chan fields not skipped")
+
+	_, err = Values(&server, WithFuncPolicy(FuncError))
+	require.Equal(t, &FieldError{"Handler", ErrFuncField}, err, "Func field not reported")
+
+	server.Hook = strings.ToLower
+	query, err := ToValues(server, "url", WithFuncPolicy(FuncSkip), WithInterfaces())
+	require.Nil(t, err)
+	require.Equal(t, url.Values{"addr": {":80"}}, query, "Func fields not skipped by ToValues")
+
+	_, err = ToEnv(Server{Hook: strings.ToLower}, "APP", WithFuncPolicy(FuncError), WithInterfaces())
+	require.Equal(t, &FieldError{"APP_HANDLER", ErrFuncField}, err, "Func field not reported by ToEnv")
+}
+
+func ExampleWithFuncPolicy() {
+	type Job struct {
+		Name string
+		Run  func() error
+	}
+	job := Job{Name: "backup", Run: func() error { return nil }}
+
+	values, err := Values(job, WithFuncPolicy(FuncSkip))
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Println(values)
+	// Output: map[Name:backup]
+}
+
+func TestFieldPattern(t *testing.T) {
+	type Token struct {
+		ID           int
+		SecretKey    string
+		SecretValue  string
+		CreatedAt    int64
+		UpdatedAt    int64
+		secretCached string
+	}
+	token := Token{ID: 1, SecretKey: "k", SecretValue: "v", CreatedAt: 10}
+
+	names, err := NamesMatching(token, "^Secret")
+	require.Nil(t, err)
+	require.Equal(t, []string{"SecretKey", "SecretValue"}, names, "Matching names are not correct")
+
+	names, err = NamesMatching(&token, "(?i)^secret", WithUnexported())
+	require.Nil(t, err)
+	require.Equal(t, []string{"SecretKey", "SecretValue", "secretCached"}, names,
+		"Matching names with the other options are not correct")
+
+	_, err = NamesMatching(token, "[")
+	require.Equal(t, ErrInvalidExpr, err, "Invalid pattern not reported")
+
+	pattern := WithFieldPattern(regexp.MustCompile("At$"))
+	values, err := Values(token, pattern)
+	require.Nil(t, err)
+	require.Equal(t, map[string]interface{}{"CreatedAt": int64(10), "UpdatedAt": int64(0)}, values,
+		"Matching values are not correct")
+
+	kinds, err := Kinds(token, pattern)
+	require.Nil(t, err)
+	require.Equal(t, map[string]string{"CreatedAt": "int64", "UpdatedAt": "int64"}, kinds,
+		"Matching kinds are not correct")
+
+	tags, err := Tags(Manager{}, "json", WithSquash(), WithFieldPattern(regexp.MustCompile("^(Age|Reports)$")))
+	require.Nil(t, err)
+	require.Equal(t, map[string]string{"Age": "age", "Reports": "reports"}, tags,
+		"Matching tags of the promoted fields are not correct")
+}
+
+func ExampleNamesMatching() {
+	type Session struct {
+		ID        string
+		StartedAt int64
+		EndedAt   int64
+	}
+
+	names, err := NamesMatching(Session{}, "At$")
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Println(names)
+	// Output: [StartedAt EndedAt]
+}
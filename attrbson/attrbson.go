@@ -0,0 +1,209 @@
+// Package attrbson provides the helpers of the attr package for the structs
+// stored in MongoDB, following the rules of the "bson" struct tags of the
+// MongoDB Go driver, without depending on the driver itself. The maps returned
+// can be converted to bson.M, which has the same underlying type.
+//
+// A field without a name in its tag uses its lower case Go name, fields tagged
+// with "-" are skipped, the empty fields tagged with ",omitempty" are left out
+// of the values, and the fields of the struct fields tagged with ",inline" are
+// promoted to the parent struct. Unlike encoding/json, the embedded structs
+// are not inlined unless they are tagged so.
+//
+// The errors returned are the error values of the attr package.
+package attrbson
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	attr "github.com/ssrathi/go-attr"
+)
+
+// pathSeparator separates the names in the path of a nested field.
+const pathSeparator = "."
+
+// bsonField is an exported struct field as seen by the bson encoder.
+type bsonField struct {
+	name      string
+	goName    string
+	goPath    string
+	typ       reflect.Type
+	omitEmpty bool
+}
+
+// Names returns the bson keys of all the fields of a struct, in the order they
+// are encoded.
+func Names(obj interface{}) ([]string, error) {
+	objType, err := structType(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for _, field := range bsonFields(objType) {
+		names = append(names, field.name)
+	}
+	return names, nil
+}
+
+// Values returns a map of the bson keys of all the fields of a struct with
+// their values, such as for an update document. The empty fields tagged with
+// ",omitempty" are left out, and so are the fields inlined from a nil pointer.
+func Values(obj interface{}) (map[string]interface{}, error) {
+	objType, err := structType(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]interface{}{}
+	for _, field := range bsonFields(objType) {
+		_, value, err := attr.RawField(obj, field.goPath)
+		if errors.Is(err, attr.ErrNilPointer) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if field.omitEmpty && isEmptyValue(value) {
+			continue
+		}
+		values[field.name] = value.Interface()
+	}
+	return values, nil
+}
+
+// ProjectionByFields returns a MongoDB projection document which includes the
+// given fields of a struct, such as {"name": 1, "address.city": 1}. The fields
+// are given by their Go names, or by dot separated paths of Go names for the
+// fields of the nested structs, and are translated to their bson keys.
+func ProjectionByFields(obj interface{}, fields ...string) (map[string]interface{}, error) {
+	objType, err := structType(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	projection := map[string]interface{}{}
+	for _, field := range fields {
+		key, err := resolve(objType, field)
+		if err != nil {
+			return nil, err
+		}
+		projection[key] = 1
+	}
+	return projection, nil
+}
+
+// structType returns the struct type of a struct or a pointer to a struct.
+func structType(obj interface{}) (reflect.Type, error) {
+	objType := reflect.TypeOf(obj)
+	if objType != nil && objType.Kind() == reflect.Ptr {
+		objType = objType.Elem()
+	}
+
+	if objType == nil || objType.Kind() != reflect.Struct {
+		return nil, attr.ErrNotStruct
+	}
+	return objType, nil
+}
+
+// resolve translates a dot separated path of Go field names to the dot
+// separated path of bson keys.
+func resolve(objType reflect.Type, path string) (string, error) {
+	keys := []string{}
+	for _, part := range strings.Split(path, pathSeparator) {
+		if objType.Kind() == reflect.Ptr {
+			objType = objType.Elem()
+		}
+		if objType.Kind() != reflect.Struct {
+			return "", attr.ErrNotStruct
+		}
+
+		found := false
+		for _, field := range bsonFields(objType) {
+			if field.goName == part {
+				keys = append(keys, field.name)
+				objType, found = field.typ, true
+				break
+			}
+		}
+		if !found {
+			return "", attr.ErrNoField
+		}
+	}
+
+	return strings.Join(keys, pathSeparator), nil
+}
+
+// bsonFields returns the fields of a struct type which are encoded by the
+// bson encoder, promoting the fields of the inlined structs.
+func bsonFields(objType reflect.Type) []bsonField {
+	fields := []bsonField{}
+	collectFields(objType, "", map[reflect.Type]bool{}, &fields)
+	return fields
+}
+
+// collectFields appends the bson fields of a struct type to 'fields'.
+// 'inProgress' stops the recursion on recursive inlining.
+func collectFields(objType reflect.Type, prefix string, inProgress map[reflect.Type]bool,
+	fields *[]bsonField) {
+	inProgress[objType] = true
+	defer delete(inProgress, objType)
+
+	for i := 0; i < objType.NumField(); i++ {
+		fieldType := objType.Field(i)
+		if fieldType.PkgPath != "" {
+			continue
+		}
+
+		tag := fieldType.Tag.Get("bson")
+		if tag == "-" {
+			continue
+		}
+
+		opts := strings.Split(tag, ",")
+		field := bsonField{
+			name:   opts[0],
+			goName: fieldType.Name,
+			goPath: prefix + fieldType.Name,
+			typ:    fieldType.Type,
+		}
+		if field.name == "" {
+			field.name = strings.ToLower(fieldType.Name)
+		}
+
+		inline := false
+		for _, opt := range opts[1:] {
+			switch opt {
+			case "omitempty":
+				field.omitEmpty = true
+			case "inline":
+				inline = true
+			}
+		}
+
+		inlined := fieldType.Type
+		if inlined.Kind() == reflect.Ptr {
+			inlined = inlined.Elem()
+		}
+		if inline && inlined.Kind() == reflect.Struct {
+			if !inProgress[inlined] {
+				collectFields(inlined, field.goPath+pathSeparator, inProgress, fields)
+			}
+			continue
+		}
+		*fields = append(*fields, field)
+	}
+}
+
+// isEmptyValue reports whether a value is empty as defined by the
+// ",omitempty" option of the bson encoder: a zero value, or an empty slice,
+// map or string.
+func isEmptyValue(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Map, reflect.Slice, reflect.String:
+		return value.Len() == 0
+	}
+	return value.IsZero()
+}
@@ -0,0 +1,79 @@
+package attrbson
+
+import (
+	"fmt"
+	"testing"
+
+	attr "github.com/ssrathi/go-attr"
+	"github.com/stretchr/testify/require"
+)
+
+type Audit struct {
+	CreatedBy string `bson:"created_by"`
+	Version   int    `bson:"version,omitempty"`
+}
+
+type Address struct {
+	City string `bson:"city"`
+	Zip  string `bson:"zip,omitempty"`
+}
+
+type Customer struct {
+	ID      string `bson:"_id"`
+	Audit   `bson:",inline"`
+	Name    string
+	Email   string   `bson:"email,omitempty"`
+	Address *Address `bson:"address"`
+	Tags    []string `bson:"tags,omitempty"`
+	Cache   string   `bson:"-"`
+	secret  string
+}
+
+func TestNames(t *testing.T) {
+	got, err := Names(&Customer{})
+	require.Nil(t, err)
+	require.Equal(t, []string{"_id", "created_by", "version", "name", "email", "address", "tags"}, got,
+		"BSON names are not correct")
+
+	_, err = Names(10)
+	require.Equal(t, attr.ErrNotStruct, err, "Able to get names of a non-struct")
+}
+
+func TestValues(t *testing.T) {
+	customer := Customer{ID: "c1", Audit: Audit{CreatedBy: "admin"}, Name: "srathi", Cache: "x", secret: "y"}
+	got, err := Values(customer)
+	require.Nil(t, err)
+	require.Equal(t, map[string]interface{}{
+		"_id":        "c1",
+		"created_by": "admin",
+		"name":       "srathi",
+		"address":    (*Address)(nil),
+	}, got, "BSON values are not correct")
+}
+
+func TestProjectionByFields(t *testing.T) {
+	got, err := ProjectionByFields(&Customer{}, "Name", "CreatedBy", "Address.City")
+	require.Nil(t, err)
+	require.Equal(t, map[string]interface{}{"name": 1, "created_by": 1, "address.city": 1}, got,
+		"Projection is not correct")
+
+	for _, field := range []string{"Cache", "secret", "Address.Street", "Name.First"} {
+		_, err = ProjectionByFields(Customer{}, field)
+		require.NotNil(t, err, "Able to project %q", field)
+	}
+}
+
+func ExampleProjectionByFields() {
+	type User struct {
+		ID    string `bson:"_id"`
+		Name  string
+		Email string `bson:"email_address"`
+	}
+
+	projection, err := ProjectionByFields(User{}, "Name", "Email")
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Println(projection)
+	// Output: map[email_address:1 name:1]
+}
@@ -0,0 +1,211 @@
+// Package attrjson mirrors the core APIs of the attr package, but addresses
+// the struct fields by their "json" tag names instead of their Go names, so
+// that HTTP facing code can work entirely with the wire level names.
+//
+// Names follow the rules of encoding/json: a field without a name in its tag
+// uses its Go name, fields tagged with "-" are skipped, and the fields of
+// embedded structs without a tag are promoted to the parent struct. A nested
+// field is given by a dot separated path of names, such as "address.city".
+//
+// The errors returned are the error values of the attr package.
+package attrjson
+
+import (
+	"reflect"
+	"strings"
+
+	attr "github.com/ssrathi/go-attr"
+)
+
+// pathSeparator separates the names in the path of a nested field.
+const pathSeparator = "."
+
+// GetValue returns the value of the field with the given json name (or dot
+// separated path of names) in a struct, which can be passed by value or by
+// pointer.
+func GetValue(obj interface{}, name string) (interface{}, error) {
+	value, err := field(obj, name)
+	if err != nil {
+		return nil, err
+	}
+	return value.Interface(), nil
+}
+
+// SetValue sets the field with the given json name (or dot separated path of
+// names) in a struct to a new value of the same type as the field.
+//
+// NOTE: 'obj' struct must be passed by pointer for this API to work. Passing by
+// value results in attr.ErrNotPtr.
+func SetValue(obj interface{}, name string, newValue interface{}) error {
+	if reflect.ValueOf(obj).Kind() != reflect.Ptr {
+		return attr.ErrNotPtr
+	}
+
+	value, err := field(obj, name)
+	if err != nil {
+		return err
+	}
+
+	if value.Type() != reflect.TypeOf(newValue) {
+		return attr.ErrMismatchValue
+	}
+
+	value.Set(reflect.ValueOf(newValue))
+	return nil
+}
+
+// Names returns the json names of all the fields of a struct, in the order
+// they are marshaled by encoding/json.
+func Names(obj interface{}) ([]string, error) {
+	objType, err := structType(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for _, field := range jsonFields(objType) {
+		names = append(names, field.Name)
+	}
+	return names, nil
+}
+
+// Values returns a map of the json names of all the fields of a struct with
+// their values. Like encoding/json, the empty fields tagged with ",omitempty"
+// are left out, and so are the fields promoted from a nil embedded pointer.
+func Values(obj interface{}) (map[string]interface{}, error) {
+	objValue, err := structValue(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]interface{}{}
+	for _, field := range jsonFields(objValue.Type()) {
+		value, ok := fieldByIndex(objValue, field.Field.Index)
+		if !ok {
+			continue
+		}
+
+		if field.OmitEmpty && isEmptyValue(value) {
+			continue
+		}
+		values[field.Name] = value.Interface()
+	}
+	return values, nil
+}
+
+// structType returns the struct type of a struct or a pointer to a struct.
+func structType(obj interface{}) (reflect.Type, error) {
+	objType := reflect.TypeOf(obj)
+	if objType != nil && objType.Kind() == reflect.Ptr {
+		objType = objType.Elem()
+	}
+
+	if objType == nil || objType.Kind() != reflect.Struct {
+		return nil, attr.ErrNotStruct
+	}
+	return objType, nil
+}
+
+// structValue returns the struct value of a struct or a pointer to a struct.
+func structValue(obj interface{}) (reflect.Value, error) {
+	if _, err := structType(obj); err != nil {
+		return reflect.Value{}, err
+	}
+
+	value := reflect.ValueOf(obj)
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return reflect.Value{}, attr.ErrNilPointer
+		}
+		value = value.Elem()
+	}
+	return value, nil
+}
+
+// field returns the field with the given json name (or dot separated path of
+// names) in a struct. A *attr.NilPathError with the json path of the nil field
+// is returned if a pointer in the path (including an embedded one) is nil.
+func field(obj interface{}, name string) (reflect.Value, error) {
+	value, err := structValue(obj)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	parts := strings.Split(name, pathSeparator)
+	for i, part := range parts {
+		if value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				return reflect.Value{}, &attr.NilPathError{
+					Path: strings.Join(parts[:i], pathSeparator), Segment: i}
+			}
+			value = value.Elem()
+		}
+		if value.Kind() != reflect.Struct {
+			return reflect.Value{}, attr.ErrNotStruct
+		}
+
+		found := false
+		for _, field := range jsonFields(value.Type()) {
+			if field.Name != part {
+				continue
+			}
+
+			fieldValue, ok := fieldByIndex(value, field.Field.Index)
+			if !ok {
+				return reflect.Value{}, &attr.NilPathError{
+					Path: strings.Join(parts[:i+1], pathSeparator), Segment: i + 1}
+			}
+			value, found = fieldValue, true
+			break
+		}
+		if !found {
+			return reflect.Value{}, attr.ErrNoField
+		}
+	}
+
+	return value, nil
+}
+
+// jsonFields returns the fields of a struct type which are marshaled by
+// encoding/json, in the same order, as given by attr.JSONFields.
+func jsonFields(objType reflect.Type) []attr.JSONField {
+	fields, _ := attr.JSONFields(reflect.Zero(objType))
+	return fields
+}
+
+// fieldByIndex returns the nested field of a struct given by an index
+// sequence, like reflect.Value.FieldByIndex, but returns false instead of
+// panicking if an embedded pointer in the sequence is nil.
+func fieldByIndex(objValue reflect.Value, index []int) (reflect.Value, bool) {
+	fieldValue := objValue
+	for i, fieldIndex := range index {
+		if i > 0 && fieldValue.Kind() == reflect.Ptr {
+			if fieldValue.IsNil() {
+				return reflect.Value{}, false
+			}
+			fieldValue = fieldValue.Elem()
+		}
+		fieldValue = fieldValue.Field(fieldIndex)
+	}
+	return fieldValue, true
+}
+
+// isEmptyValue reports whether a value is empty as defined by the
+// ",omitempty" option of encoding/json.
+func isEmptyValue(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return value.Len() == 0
+	case reflect.Bool:
+		return !value.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return value.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return value.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return value.IsNil()
+	}
+	return false
+}
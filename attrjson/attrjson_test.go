@@ -0,0 +1,163 @@
+package attrjson
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	attr "github.com/ssrathi/go-attr"
+	"github.com/stretchr/testify/require"
+)
+
+type Meta struct {
+	ID      int    `json:"id"`
+	Version int    `json:"version,omitempty"`
+	Name    string `json:"meta_name"`
+}
+
+type Address struct {
+	City string `json:"city"`
+	Zip  string `json:"zip,omitempty"`
+}
+
+type Profile struct {
+	*Meta
+	Name     string   `json:"name"`
+	Email    string   `json:"email,omitempty"`
+	Address  *Address `json:"address"`
+	Tags     []string `json:"tags,omitempty"`
+	Internal string   `json:"-"`
+	Dash     string   `json:"-,"`
+	Plain    bool
+	secret   string
+}
+
+func TestNames(t *testing.T) {
+	got, err := Names(&Profile{})
+	require.Nil(t, err)
+	require.Equal(t, []string{"id", "version", "meta_name", "name", "email", "address", "tags", "-", "Plain"},
+		got, "JSON names are not correct")
+
+	_, err = Names(10)
+	require.Equal(t, attr.ErrNotStruct, err, "Able to get names of a non-struct")
+}
+
+func TestValues(t *testing.T) {
+	profile := Profile{Name: "srathi", Address: &Address{City: "Pune"}, secret: "s"}
+	got, err := Values(profile)
+	require.Nil(t, err)
+	require.Equal(t, map[string]interface{}{
+		"name": "srathi", "address": profile.Address, "-": "", "Plain": false}, got,
+		"JSON values without the embedded struct are not correct")
+
+	profile.Meta = &Meta{ID: 7}
+	profile.Tags = []string{"admin"}
+	got, err = Values(&profile)
+	require.Nil(t, err)
+	require.Equal(t, map[string]interface{}{
+		"id": 7, "meta_name": "", "name": "srathi", "address": profile.Address,
+		"tags": []string{"admin"}, "-": "", "Plain": false}, got, "JSON values are not correct")
+}
+
+func TestGetValue(t *testing.T) {
+	profile := Profile{Meta: &Meta{ID: 7}, Name: "srathi", Address: &Address{City: "Pune"}}
+	for _, test := range []struct {
+		name string
+		want interface{}
+	}{
+		{"name", "srathi"},
+		{"id", 7},
+		{"address.city", "Pune"},
+		{"-", ""},
+	} {
+		got, err := GetValue(&profile, test.name)
+		require.Nil(t, err)
+		require.Equal(t, test.want, got, "Value of %q is not correct", test.name)
+	}
+
+	for _, test := range []struct {
+		name string
+		err  error
+	}{
+		{"Name", attr.ErrNoField},
+		{"Internal", attr.ErrNoField},
+		{"secret", attr.ErrNoField},
+		{"name.first", attr.ErrNotStruct},
+		{"address.street", attr.ErrNoField},
+	} {
+		_, err := GetValue(profile, test.name)
+		require.Equal(t, test.err, err, "Wrong error for %q", test.name)
+	}
+
+	profile.Address = nil
+	_, err := GetValue(profile, "address.city")
+	require.True(t, errors.Is(err, attr.ErrNilPointer), "Able to get a field through a nil pointer")
+}
+
+func TestSetValue(t *testing.T) {
+	profile := Profile{Meta: &Meta{}, Address: &Address{}}
+	require.Nil(t, SetValue(&profile, "meta_name", "v1"))
+	require.Nil(t, SetValue(&profile, "address.zip", "411001"))
+	require.Equal(t, "v1", profile.Meta.Name, "Promoted field not set")
+	require.Equal(t, "411001", profile.Address.Zip, "Nested field not set")
+
+	err := SetValue(&profile, "name", 10)
+	require.Equal(t, attr.ErrMismatchValue, err, "Able to set a value of a different type")
+
+	err = SetValue(profile, "name", "srathi")
+	require.Equal(t, attr.ErrNotPtr, err, "Able to set a field of a struct passed by value")
+}
+
+func TestUnexportedEmbedded(t *testing.T) {
+	type audit struct {
+		Name      string `json:"audit_name"`
+		CreatedBy string `json:"created_by"`
+	}
+	type Note struct {
+		audit
+		Text      string `json:"text"`
+		CreatedBy string `json:"created_by"`
+	}
+
+	note := Note{audit: audit{Name: "a", CreatedBy: "hidden"}, Text: "hi", CreatedBy: "bob"}
+	names, err := Names(note)
+	require.Nil(t, err)
+	require.Equal(t, []string{"audit_name", "text", "created_by"}, names, "JSON names are not correct")
+
+	// The order of the names must match the one of encoding/json.
+	data, err := json.Marshal(note)
+	require.Nil(t, err)
+	require.Equal(t, `{"audit_name":"a","text":"hi","created_by":"bob"}`, string(data))
+
+	values, err := Values(note)
+	require.Nil(t, err)
+	require.Equal(t, map[string]interface{}{"audit_name": "a", "text": "hi", "created_by": "bob"}, values,
+		"JSON values are not correct")
+
+	require.Nil(t, SetValue(&note, "audit_name", "b"))
+	got, err := GetValue(note, "audit_name")
+	require.Nil(t, err)
+	require.Equal(t, "b", got, "Field of an unexported embedded struct not set")
+
+	_, err = GetValue(Profile{}, "id")
+	require.Equal(t, &attr.NilPathError{Path: "id", Segment: 1}, err,
+		"Able to get a field promoted through a nil pointer")
+}
+
+func Example() {
+	type User struct {
+		Username string `json:"user_name"`
+		Age      int    `json:"age,omitempty"`
+		Password string `json:"-"`
+	}
+	user := User{Username: "srathi", Password: "secret"}
+
+	if err := SetValue(&user, "user_name", "srathi-alt"); err != nil {
+		// Handle error.
+	}
+	names, _ := Names(&user)
+	values, _ := Values(&user)
+	fmt.Println(names, values)
+	// Output: [user_name age] map[user_name:srathi-alt]
+}
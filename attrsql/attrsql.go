@@ -0,0 +1,132 @@
+// Package attrsql bundles the "db" tag based helpers of the attr package
+// behind a Model handle, as a thin layer to map structs to database rows
+// without a full ORM.
+//
+// Column names are taken from the "db" tags of the exported (public) fields,
+// or are the lower case field names if there is no tag. Fields tagged with
+// `db:"-"` are skipped, and the fields of the embedded structs without the tag
+// are promoted to the parent struct, as with attr.Columns, which computes the
+// columns of a struct type once and caches them.
+//
+// The errors returned are the error values of the attr package.
+package attrsql
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	attr "github.com/ssrathi/go-attr"
+)
+
+// Scanner is implemented by *sql.Row and *sql.Rows.
+type Scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// Handle gives access to the database helpers for a struct, as returned by
+// Model.
+type Handle struct {
+	obj     interface{}
+	value   reflect.Value
+	columns []attr.Column
+}
+
+// Model returns a handle to the database helpers for the given struct, which
+// can be passed by value or by pointer. It must be passed by pointer to scan
+// rows into it.
+func Model(obj interface{}) (*Handle, error) {
+	value := reflect.ValueOf(obj)
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil, attr.ErrNilPointer
+		}
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return nil, attr.ErrNotStruct
+	}
+
+	columns, err := attr.Columns(obj, "db")
+	if err != nil {
+		return nil, err
+	}
+	return &Handle{obj: obj, value: value, columns: columns}, nil
+}
+
+// Columns returns the column names of the struct, in the order of its fields.
+func (h *Handle) Columns() []string {
+	names := make([]string, len(h.columns))
+	for i, col := range h.columns {
+		names[i] = col.Name
+	}
+	return names
+}
+
+// Select returns a SELECT statement for all the columns of the struct from
+// the given table, whose result can be read with ScanRow.
+func (h *Handle) Select(table string) string {
+	return fmt.Sprintf("SELECT %s FROM %s", strings.Join(h.Columns(), ", "), table)
+}
+
+// ScanRow scans a row into the struct. The row must hold the given columns
+// in order, or all the columns of the struct in the order of Columns if none
+// is given. The fields whose types implement sql.Scanner (such as
+// sql.NullString or a decimal type) are scanned with their Scan method by the
+// row.
+//
+// ErrNotPtr is returned if the struct is not passed by pointer to Model, and
+// ErrNoField if a column doesn't match any field.
+func (h *Handle) ScanRow(row Scanner, columns ...string) error {
+	if !h.value.CanAddr() {
+		return attr.ErrNotPtr
+	}
+
+	if len(columns) == 0 {
+		columns = h.Columns()
+	}
+
+	dest := make([]interface{}, len(columns))
+	for i, name := range columns {
+		col, found := h.find(name)
+		if !found {
+			return attr.ErrNoField
+		}
+		dest[i] = h.value.FieldByIndex(col.Field.Index).Addr().Interface()
+	}
+
+	return row.Scan(dest...)
+}
+
+// Insert returns a parameterized INSERT statement for the struct and the
+// values of its parameters, like attr.InsertSQL.
+func (h *Handle) Insert(table string, opts ...attr.Option) (string, []interface{}, error) {
+	return attr.InsertSQL(h.obj, table, opts...)
+}
+
+// Update returns a parameterized UPDATE statement for the struct, with the
+// given key fields in the WHERE clause, like attr.UpdateSQL.
+func (h *Handle) Update(table string, keyFields []string, opts ...attr.Option) (string, []interface{}, error) {
+	return attr.UpdateSQL(h.obj, table, keyFields, opts...)
+}
+
+// NamedArgs returns a map of the column names to the field values of the
+// struct, for the queries with named parameters.
+func (h *Handle) NamedArgs() map[string]interface{} {
+	args := make(map[string]interface{}, len(h.columns))
+	for _, col := range h.columns {
+		args[col.Name] = h.value.FieldByIndex(col.Field.Index).Interface()
+	}
+	return args
+}
+
+// find returns the column of the given name.
+func (h *Handle) find(name string) (attr.Column, bool) {
+	for _, col := range h.columns {
+		if col.Name == name {
+			return col, true
+		}
+	}
+	return attr.Column{}, false
+}
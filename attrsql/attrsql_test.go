@@ -0,0 +1,149 @@
+package attrsql
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	attr "github.com/ssrathi/go-attr"
+	"github.com/stretchr/testify/require"
+)
+
+type Base struct {
+	ID int64 `db:"id"`
+}
+
+type Account struct {
+	Base
+	Name     string  `db:"name"`
+	Email    string  `db:"email,omitempty"`
+	Balance  float64 `db:"balance"`
+	Internal string  `db:"-"`
+	Active   bool
+	token    string
+}
+
+// fakeRow is a Scanner which sets the destinations to fixed values.
+type fakeRow struct {
+	values []interface{}
+	err    error
+}
+
+func (r *fakeRow) Scan(dest ...interface{}) error {
+	if r.err != nil {
+		return r.err
+	}
+	if len(dest) != len(r.values) {
+		return errors.New("column count mismatch")
+	}
+	for i, value := range r.values {
+		reflect.ValueOf(dest[i]).Elem().Set(reflect.ValueOf(value))
+	}
+	return nil
+}
+
+func TestModel(t *testing.T) {
+	account := Account{Base: Base{ID: 7}, Name: "srathi", Balance: 10.5}
+	model, err := Model(&account)
+	require.Nil(t, err)
+	require.Equal(t, []string{"id", "name", "email", "balance", "active"}, model.Columns(),
+		"Columns are not correct")
+	require.Equal(t, "SELECT id, name, email, balance, active FROM accounts",
+		model.Select("accounts"), "Select statement is not correct")
+	require.Equal(t, map[string]interface{}{
+		"id": int64(7), "name": "srathi", "email": "", "balance": 10.5, "active": false},
+		model.NamedArgs(), "Named args are not correct")
+
+	query, args, err := model.Insert("accounts")
+	require.Nil(t, err)
+	require.Equal(t, "INSERT INTO accounts (id, name, balance, active) VALUES (?, ?, ?, ?)", query)
+	require.Equal(t, []interface{}{int64(7), "srathi", 10.5, false}, args)
+
+	query, args, err = model.Update("accounts", []string{"ID"}, attr.WithDollarParams())
+	require.Nil(t, err)
+	require.Equal(t, "UPDATE accounts SET name = $1, balance = $2 WHERE id = $3", query)
+	require.Equal(t, []interface{}{"srathi", 10.5, int64(7)}, args)
+
+	_, err = Model([]int{})
+	require.Equal(t, attr.ErrNotStruct, err, "Able to model a non-struct")
+
+	_, err = Model((*Account)(nil))
+	require.Equal(t, attr.ErrNilPointer, err, "Able to model a nil pointer")
+}
+
+func TestScanRow(t *testing.T) {
+	account := Account{}
+	model, err := Model(&account)
+	require.Nil(t, err)
+
+	row := &fakeRow{values: []interface{}{int64(9), "bob", "bob@example.com", 1.5, true}}
+	require.Nil(t, model.ScanRow(row))
+	require.Equal(t, Account{Base: Base{ID: 9}, Name: "bob", Email: "bob@example.com",
+		Balance: 1.5, Active: true}, account, "Row not scanned correctly")
+
+	row = &fakeRow{values: []interface{}{"alice", int64(3)}}
+	require.Nil(t, model.ScanRow(row, "name", "id"))
+	require.Equal(t, "alice", account.Name, "Selected columns not scanned correctly")
+	require.Equal(t, int64(3), account.ID, "Selected columns not scanned correctly")
+
+	err = model.ScanRow(row, "name", "token")
+	require.Equal(t, attr.ErrNoField, err, "Able to scan an unknown column")
+
+	rowErr := errors.New("no rows")
+	err = model.ScanRow(&fakeRow{err: rowErr})
+	require.Equal(t, rowErr, err, "Scan error not returned")
+
+	model, err = Model(account)
+	require.Nil(t, err)
+	err = model.ScanRow(row)
+	require.Equal(t, attr.ErrNotPtr, err, "Able to scan into a struct passed by value")
+}
+
+func TestModelUnexportedEmbedded(t *testing.T) {
+	type audit struct {
+		CreatedBy string `db:"created_by"`
+	}
+	type Note struct {
+		audit
+		Text string `db:"text"`
+	}
+
+	note := Note{}
+	model, err := Model(&note)
+	require.Nil(t, err)
+	require.Equal(t, []string{"created_by", "text"}, model.Columns(),
+		"Columns of an unexported embedded struct are not correct")
+
+	require.Nil(t, model.ScanRow(&fakeRow{values: []interface{}{"bob", "hi"}}))
+	require.Equal(t, "bob", note.CreatedBy, "Row not scanned correctly")
+	require.Equal(t, map[string]interface{}{"created_by": "bob", "text": "hi"},
+		model.NamedArgs(), "Named args are not correct")
+}
+
+func Example() {
+	type User struct {
+		ID       int64  `db:"id"`
+		Username string `db:"uname"`
+		Age      int
+	}
+	user := User{ID: 1, Username: "srathi", Age: 30}
+
+	model, err := Model(&user)
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Println(model.Select("users"))
+
+	query, args, _ := model.Update("users", []string{"ID"})
+	fmt.Println(query, args)
+
+	// rows, _ := db.Query(model.Select("users"))
+	// for rows.Next() {
+	// 	err = model.ScanRow(rows)
+	// }
+
+	// Output:
+	// SELECT id, uname, age FROM users
+	// UPDATE users SET uname = ?, age = ? WHERE id = ? [srathi 30 1]
+}
@@ -0,0 +1,55 @@
+// Package attrunsafe reads the unexported (private) fields of structs with the
+// unsafe package, which the attr package never does. It is meant for debugging,
+// logging and test assertions only, and never writes to a struct.
+//
+// Reading unexported fields bypasses the encapsulation of the packages which
+// define them, so the values must not be relied upon by production logic.
+package attrunsafe
+
+import (
+	"reflect"
+	"unsafe"
+
+	attr "github.com/ssrathi/go-attr"
+)
+
+// ValuesAll returns a map of all the field names of a struct with the value of
+// each field, including the unexported (private) ones. 'obj' can be passed by
+// value or by pointer, and is never modified.
+//
+// The values are copies, but the ones holding references (such as pointers,
+// slices and maps) still share the referenced data with the struct, which
+// must be treated as read-only.
+func ValuesAll(obj interface{}) (map[string]interface{}, error) {
+	objValue := reflect.ValueOf(obj)
+	if objValue.Kind() == reflect.Ptr {
+		if objValue.IsNil() {
+			return nil, attr.ErrNilPointer
+		}
+		objValue = objValue.Elem()
+	}
+
+	if objValue.Kind() != reflect.Struct {
+		return nil, attr.ErrNotStruct
+	}
+
+	// The address of an unexported field is needed to read it, so work on an
+	// addressable copy of a struct passed by value.
+	if !objValue.CanAddr() {
+		copied := reflect.New(objValue.Type()).Elem()
+		copied.Set(objValue)
+		objValue = copied
+	}
+
+	values := map[string]interface{}{}
+	objType := objValue.Type()
+	for i := 0; i < objValue.NumField(); i++ {
+		fieldValue := objValue.Field(i)
+		if !fieldValue.CanInterface() {
+			fieldValue = reflect.NewAt(fieldValue.Type(), unsafe.Pointer(fieldValue.UnsafeAddr())).Elem()
+		}
+		values[objType.Field(i).Name] = fieldValue.Interface()
+	}
+
+	return values, nil
+}
@@ -0,0 +1,50 @@
+package attrunsafe
+
+import (
+	"fmt"
+	"testing"
+
+	attr "github.com/ssrathi/go-attr"
+	"github.com/stretchr/testify/require"
+)
+
+type User struct {
+	Username string
+	Age      int
+	password string
+	tags     []string
+	limit    *int
+}
+
+func TestValuesAll(t *testing.T) {
+	user := User{Username: "srathi", Age: 30, password: "secret", tags: []string{"admin"}}
+	for _, obj := range []interface{}{user, &user} {
+		got, err := ValuesAll(obj)
+		require.Nil(t, err)
+		require.Equal(t, "secret", got["password"], "Unexported field value not read")
+		require.Equal(t, []string{"admin"}, got["tags"], "Unexported slice value not read")
+		require.Equal(t, "srathi", got["Username"], "Exported field value not read")
+		require.Equal(t, 5, len(got), "All the fields are not read")
+	}
+
+	_, err := ValuesAll([]int{1})
+	require.Equal(t, attr.ErrNotStruct, err, "Able to read the values of a non-struct")
+
+	_, err = ValuesAll((*User)(nil))
+	require.Equal(t, attr.ErrNilPointer, err, "Able to read the values of a nil pointer")
+}
+
+func Example() {
+	type Session struct {
+		User  string
+		token string
+	}
+	session := Session{User: "srathi", token: "abc123"}
+
+	values, err := ValuesAll(session)
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Println(values)
+	// Output: map[User:srathi token:abc123]
+}
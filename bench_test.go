@@ -0,0 +1,146 @@
+package attr
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// wideType is a struct type with 100 int fields named F0 to F99, like the
+// wide models with many columns.
+var wideType = func() reflect.Type {
+	fields := make([]reflect.StructField, 100)
+	for i := range fields {
+		fields[i] = reflect.StructField{Name: fmt.Sprintf("F%d", i), Type: reflect.TypeOf(0)}
+	}
+	return reflect.StructOf(fields)
+}()
+
+// newWide returns a pointer to a new struct of wideType.
+func newWide() interface{} {
+	return reflect.New(wideType).Interface()
+}
+
+func TestFieldCache(t *testing.T) {
+	obj := newWide()
+	for i := 0; i < 2; i++ {
+		if err := SetValue(obj, "F99", 7); err != nil {
+			t.Fatal(err)
+		}
+		value, err := GetValue(obj, "F99")
+		if err != nil || value != 7 {
+			t.Fatalf("GetValue() = %v, %v; want 7", value, err)
+		}
+	}
+
+	if _, err := GetValue(obj, "F100"); err != ErrNoField {
+		t.Fatalf("GetValue() error = %v; want %v", err, ErrNoField)
+	}
+	if _, found := cachedFields(wideType).byName.Load("F100"); found {
+		t.Fatal("Missing field name is cached")
+	}
+
+	// The other lookups by name use the same cache.
+	if _, err := FieldOffset(obj, "F98"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := GetTag(obj, "F97", "json"); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"F98", "F97"} {
+		if _, found := cachedFields(wideType).byName.Load(name); !found {
+			t.Fatalf("Field name %s is not cached", name)
+		}
+	}
+}
+
+func BenchmarkGetValue(b *testing.B) {
+	obj := newWide()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		GetValue(obj, "F99")
+	}
+}
+
+func BenchmarkLensGet(b *testing.B) {
+	obj := newWide()
+	lens := Lens("F99")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		lens.Get(obj)
+	}
+}
+
+func BenchmarkTypeAccessorGet(b *testing.B) {
+	type Wide struct {
+		F0, F1, F2, F3, F4, F5, F6, F7, F8, F9 int
+	}
+	wideAttr := For[Wide]()
+	obj := &Wide{}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		wideAttr.Get(obj, "F9")
+	}
+}
+
+func BenchmarkReflectGet(b *testing.B) {
+	obj := newWide()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = reflect.ValueOf(obj).Elem().FieldByName("F99").Interface()
+	}
+}
+
+func BenchmarkSetValue(b *testing.B) {
+	obj := newWide()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		SetValue(obj, "F99", i)
+	}
+}
+
+func BenchmarkReflectSet(b *testing.B) {
+	obj := newWide()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		reflect.ValueOf(obj).Elem().FieldByName("F99").Set(reflect.ValueOf(i))
+	}
+}
+
+func BenchmarkValues(b *testing.B) {
+	obj := newWide()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Values(obj)
+	}
+}
+
+func BenchmarkEachValue(b *testing.B) {
+	obj := newWide()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		EachValue(obj, func(string, interface{}) bool { return true })
+	}
+}
+
+func BenchmarkReflectValues(b *testing.B) {
+	obj := newWide()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		objValue := reflect.ValueOf(obj).Elem()
+		values := make(map[string]interface{}, objValue.NumField())
+		for j := 0; j < objValue.NumField(); j++ {
+			values[objValue.Type().Field(j).Name] = objValue.Field(j).Interface()
+		}
+	}
+}
+
+func BenchmarkPool(b *testing.B) {
+	pool := Pool[Request]()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		req := pool.Get()
+		req.Path = "/users"
+		pool.Put(req)
+	}
+}
@@ -0,0 +1,90 @@
+package attr
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"reflect"
+	"strings"
+)
+
+// bytesTagKey is the tag key which sets the text encoding of a []byte field,
+// such as `attr:"base64"`.
+const bytesTagKey = "attr"
+
+// bytesEncodings are the text encodings of the []byte fields by their names in
+// the "attr" tag. "raw" uses the bytes of the text as they are, which is also
+// the default.
+var bytesEncodings = map[string]struct {
+	decode func(string) ([]byte, error)
+	encode func([]byte) string
+}{
+	"raw": {
+		func(text string) ([]byte, error) { return []byte(text), nil },
+		func(b []byte) string { return string(b) },
+	},
+	"base64": {
+		base64.StdEncoding.DecodeString,
+		base64.StdEncoding.EncodeToString,
+	},
+	"base64url": {
+		base64.URLEncoding.DecodeString,
+		base64.URLEncoding.EncodeToString,
+	},
+	"hex": {
+		hex.DecodeString,
+		hex.EncodeToString,
+	},
+}
+
+// bytesEncoding returns the name of the text encoding of a []byte field given
+// by its "attr" tag, such as `attr:"base64"`, or "" if the field is not a
+// []byte field or has no such tag. ErrInvalidTag is returned for an unknown
+// encoding.
+func bytesEncoding(field reflect.StructField) (string, error) {
+	if field.Type.Kind() != reflect.Slice || field.Type.Elem().Kind() != reflect.Uint8 {
+		return "", nil
+	}
+
+	for _, name := range strings.Split(field.Tag.Get(bytesTagKey), ",") {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		if _, found := bytesEncodings[name]; !found {
+			return "", ErrInvalidTag
+		}
+		return name, nil
+	}
+	return "", nil
+}
+
+// decodeBytes decodes a string set to a []byte field with the encoding given
+// by its "attr" tag. It returns false if the value is not a string or the
+// field has no encoding, and ErrInvalidValue if the string can't be decoded.
+func decodeBytes(field reflect.StructField, value interface{}) (reflect.Value, bool, error) {
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.String {
+		return reflect.Value{}, false, nil
+	}
+
+	name, err := bytesEncoding(field)
+	if name == "" {
+		return reflect.Value{}, err != nil, err
+	}
+
+	b, err := bytesEncodings[name].decode(rv.String())
+	if err != nil {
+		return reflect.Value{}, true, ErrInvalidValue
+	}
+	return reflect.ValueOf(b).Convert(field.Type), true, nil
+}
+
+// encodeBytes encodes the value of a []byte field as a string with the
+// encoding given by its "attr" tag. It returns false if the field has no
+// encoding.
+func encodeBytes(field reflect.StructField, value reflect.Value) (string, bool, error) {
+	name, err := bytesEncoding(field)
+	if name == "" {
+		return "", err != nil, err
+	}
+	return bytesEncodings[name].encode(value.Bytes()), true, nil
+}
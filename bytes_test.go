@@ -0,0 +1,88 @@
+package attr
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Blob struct {
+	Raw    []byte `url:"raw" env:"RAW"`
+	Key    []byte `url:"key" env:"KEY" attr:"base64"`
+	Token  []byte `url:"token" attr:"base64url"`
+	Digest []byte `url:"digest" env:"DIGEST" attr:"hex"`
+}
+
+func TestBytesEncoding(t *testing.T) {
+	values := url.Values{
+		"raw":    {"plain"},
+		"key":    {"c2VjcmV0+/8="},
+		"token":  {"c2VjcmV0-_8="},
+		"digest": {"deadbeef"},
+	}
+
+	var blob Blob
+	require.NoError(t, FromValues(&blob, values, "url"))
+	require.Equal(t, []byte("plain"), blob.Raw)
+	require.Equal(t, []byte("secret\xfb\xff"), blob.Key)
+	require.Equal(t, []byte("secret\xfb\xff"), blob.Token)
+	require.Equal(t, []byte{0xde, 0xad, 0xbe, 0xef}, blob.Digest)
+
+	// The fields are encoded back the same way.
+	got, err := ToValues(blob, "url")
+	require.NoError(t, err)
+	require.Equal(t, values, got)
+
+	env, err := ToEnv(blob, "APP")
+	require.NoError(t, err)
+	require.Equal(t, []string{"APP_RAW=plain", "APP_KEY=c2VjcmV0+/8=", "APP_TOKEN=c2VjcmV0-_8=", "APP_DIGEST=deadbeef"},
+		env)
+
+	os.Setenv("TEST_BLOB_DIGEST", "cafe")
+	defer os.Unsetenv("TEST_BLOB_DIGEST")
+	require.NoError(t, FromEnv(&blob, "TEST_BLOB"))
+	require.Equal(t, []byte{0xca, 0xfe}, blob.Digest)
+
+	require.NoError(t, FromMap(&blob, map[string]interface{}{"key": "AAE="}, "url"))
+	require.Equal(t, []byte{0, 1}, blob.Key)
+
+	// A []byte value is set as it is.
+	require.NoError(t, FromMap(&blob, map[string]interface{}{"key": []byte("abc")}, "url"))
+	require.Equal(t, []byte("abc"), blob.Key)
+
+	require.NoError(t, SetValueFromString(&blob, "Digest", "0102"))
+	require.Equal(t, []byte{1, 2}, blob.Digest)
+
+	require.NoError(t, SetValueFromString(&blob, "Digest[0]", "7"))
+	require.Equal(t, []byte{7, 2}, blob.Digest)
+
+	err = FromValues(&blob, url.Values{"digest": {"xyz"}}, "url")
+	require.Equal(t, &FieldError{"Digest", ErrInvalidValue}, err)
+
+	err = SetValueFromString(&blob, "Key", "not base64!")
+	require.Equal(t, ErrInvalidValue, err)
+
+	type BadBlob struct {
+		Data []byte `attr:"base32"`
+	}
+	err = FromMap(&BadBlob{}, map[string]interface{}{"Data": "x"}, "")
+	require.Equal(t, &FieldError{"Data", ErrInvalidTag}, err)
+
+	_, err = ToValues(BadBlob{Data: []byte("x")}, "")
+	require.Equal(t, ErrInvalidTag, err)
+}
+
+func ExampleSetValueFromString_base64() {
+	blob := Blob{}
+	if err := SetValueFromString(&blob, "Key", "c2VjcmV0"); err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(string(blob.Key))
+
+	// Output:
+	// secret
+}
@@ -0,0 +1,84 @@
+package attr
+
+import (
+	"reflect"
+	"sync"
+)
+
+// typeFields holds the exported (public) fields of a struct type, resolved
+// once per type by cachedFields.
+type typeFields struct {
+	// names and indexes are the names and the indexes of the exported
+	// fields, in their declaration order.
+	names   []string
+	indexes []int
+	// byName holds the struct fields found by name so far, including the
+	// promoted ones, as reflect.StructField values.
+	byName sync.Map
+	// columns holds the database columns of the struct type by tag key, as
+	// []Column values.
+	columns sync.Map
+	// jsonMembers holds the fields marshaled by encoding/json, resolved once
+	// by cachedJSONMembers.
+	jsonOnce    sync.Once
+	jsonMembers []jsonMember
+}
+
+// fieldCache holds the typeFields of the struct types seen so far, keyed by
+// their reflect.Type.
+var fieldCache sync.Map
+
+// cachedFields returns the exported fields of a struct type, resolving them on
+// the first call for the type.
+func cachedFields(objType reflect.Type) *typeFields {
+	if cached, found := fieldCache.Load(objType); found {
+		return cached.(*typeFields)
+	}
+
+	fields := &typeFields{}
+	for i := 0; i < objType.NumField(); i++ {
+		if field := objType.Field(i); field.PkgPath == "" {
+			fields.names = append(fields.names, field.Name)
+			fields.indexes = append(fields.indexes, i)
+		}
+	}
+
+	cached, _ := fieldCache.LoadOrStore(objType, fields)
+	return cached.(*typeFields)
+}
+
+// cachedField returns the struct field of a given name in a struct type, like
+// reflect.Type.FieldByName, but the fields found by name are cached, so that
+// looking up a field of a wide struct repeatedly doesn't scan its fields every
+// time. The names not found are not cached, so that they can't grow the cache.
+func cachedField(objType reflect.Type, name string) (reflect.StructField, bool) {
+	fields := cachedFields(objType)
+	if cached, found := fields.byName.Load(name); found {
+		return cached.(reflect.StructField), true
+	}
+
+	field, found := objType.FieldByName(name)
+	if found {
+		fields.byName.Store(name, field)
+	}
+	return field, found
+}
+
+// fieldByName returns the field of a given name in a struct value, like
+// reflect.Value.FieldByName, using the cache of cachedField. It returns
+// ErrNoField if the field is not found, and ErrNilPointer instead of
+// panicking if the field is promoted through a nil embedded pointer.
+func fieldByName(objValue reflect.Value, name string) (reflect.Value, error) {
+	field, found := cachedField(objValue.Type(), name)
+	if !found {
+		return reflect.Value{}, ErrNoField
+	}
+	if len(field.Index) == 1 {
+		return objValue.Field(field.Index[0]), nil
+	}
+	fieldValue, ok := fieldByIndex(objValue, field.Index)
+	if !ok {
+		return reflect.Value{}, ErrNilPointer
+	}
+	return fieldValue, nil
+}
@@ -0,0 +1,80 @@
+package attr
+
+import "reflect"
+
+// IsCallable returns true if a given field of a struct is a func field with a
+// non-nil value, such as a callback stored on a config struct, which can be
+// called with CallField. The field can be a dot separated path for a field of
+// a nested struct, and 'obj' can be passed by value or by pointer.
+func IsCallable(obj interface{}, fieldName string) (bool, error) {
+	fieldValue, err := funcField(obj, fieldName)
+	if err != nil {
+		return false, err
+	}
+	return fieldValue.Kind() == reflect.Func && !fieldValue.IsNil(), nil
+}
+
+// CallField calls the func stored in a given field of a struct with the given
+// arguments, and returns its results. The field can be a path like IsCallable.
+//
+// The arguments are checked against the func type before the call: their
+// number must match the parameters (including a variadic one), and each must
+// be assignable to its parameter type, with nil allowed for a pointer, slice,
+// map, func, chan or interface parameter. ErrMismatchValue is returned if they
+// don't match, and ErrNotCallable if the field is not a func or is nil. A panic
+// in the called func is not recovered.
+func CallField(obj interface{}, fieldName string, args ...interface{}) ([]interface{}, error) {
+	fieldValue, err := funcField(obj, fieldName)
+	if err != nil {
+		return nil, err
+	}
+
+	if fieldValue.Kind() != reflect.Func || fieldValue.IsNil() {
+		return nil, ErrNotCallable
+	}
+
+	funcType := fieldValue.Type()
+	numIn := funcType.NumIn()
+	if len(args) < numIn-1 || (len(args) != numIn && !funcType.IsVariadic()) {
+		return nil, ErrMismatchValue
+	}
+
+	argValues := make([]reflect.Value, len(args))
+	for i, arg := range args {
+		paramType := funcType.In(min(i, numIn-1))
+		if funcType.IsVariadic() && i >= numIn-1 {
+			paramType = paramType.Elem()
+		}
+
+		if arg == nil {
+			if !isNillable(paramType.Kind()) {
+				return nil, ErrMismatchValue
+			}
+			argValues[i] = reflect.Zero(paramType)
+			continue
+		}
+
+		argValue := reflect.ValueOf(arg)
+		if !argValue.Type().AssignableTo(paramType) {
+			return nil, ErrMismatchValue
+		}
+		argValues[i] = argValue
+	}
+
+	results := fieldValue.Call(argValues)
+	values := make([]interface{}, len(results))
+	for i, result := range results {
+		values[i] = result.Interface()
+	}
+	return values, nil
+}
+
+// funcField returns the reflect-value of a field given by a path, to be called
+// as a func.
+func funcField(obj interface{}, fieldName string) (reflect.Value, error) {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return getFieldByPath(objValue, fieldName, false)
+}
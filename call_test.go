@@ -0,0 +1,99 @@
+package attr
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Hooks struct {
+	Server   Server
+	OnError  func(error) bool
+	Format   func(format string, args ...interface{}) string
+	Shutdown func()
+}
+
+func TestIsCallable(t *testing.T) {
+	hooks := Hooks{Server: Server{Handler: strings.ToUpper}, Shutdown: func() {}}
+	for fieldName, want := range map[string]bool{
+		"Server.Handler": true, "Shutdown": true, "OnError": false, "Server.Addr": false,
+	} {
+		got, err := IsCallable(hooks, fieldName)
+		require.Nil(t, err)
+		require.Equal(t, want, got, "Callable state of %s is not correct", fieldName)
+	}
+
+	_, err := IsCallable(&hooks, "ABC")
+	require.Equal(t, ErrNoField, err, "Able to check a missing field")
+}
+
+func TestCallField(t *testing.T) {
+	hooks := Hooks{
+		Server:  Server{Handler: strings.ToUpper},
+		OnError: func(err error) bool { return err == nil },
+		Format:  fmt.Sprintf,
+	}
+
+	got, err := CallField(&hooks, "Server.Handler", "abc")
+	require.Nil(t, err)
+	require.Equal(t, []interface{}{"ABC"}, got, "Results of the call are not correct")
+
+	got, err = CallField(hooks, "OnError", nil)
+	require.Nil(t, err)
+	require.Equal(t, []interface{}{true}, got, "Nil argument not passed")
+
+	got, err = CallField(hooks, "Format", "%s-%d", "a", 1)
+	require.Nil(t, err)
+	require.Equal(t, []interface{}{"a-1"}, got, "Variadic arguments not passed")
+
+	got, err = CallField(hooks, "Format", "plain")
+	require.Nil(t, err)
+	require.Equal(t, []interface{}{"plain"}, got, "Call without variadic arguments failed")
+
+	for _, test := range []struct {
+		fieldName string
+		args      []interface{}
+		wantErr   error
+	}{
+		{"Server.Handler", []interface{}{}, ErrMismatchValue},
+		{"Server.Handler", []interface{}{"a", "b"}, ErrMismatchValue},
+		{"Server.Handler", []interface{}{1}, ErrMismatchValue},
+		{"Server.Handler", []interface{}{nil}, ErrMismatchValue},
+		{"Format", []interface{}{}, ErrMismatchValue},
+		{"Shutdown", nil, ErrNotCallable},
+		{"Server.Addr", nil, ErrNotCallable},
+		{"ABC", nil, ErrNoField},
+	} {
+		_, err := CallField(hooks, test.fieldName, test.args...)
+		require.Equal(t, test.wantErr, err, "Error of calling %s%v is not correct", test.fieldName, test.args)
+	}
+}
+
+func ExampleCallField() {
+	type Config struct {
+		Name     string
+		Validate func(name string) error
+	}
+	config := Config{
+		Name: "prod",
+		Validate: func(name string) error {
+			if name == "" {
+				return fmt.Errorf("empty name")
+			}
+			return nil
+		},
+	}
+
+	callable, err := IsCallable(config, "Validate")
+	if err != nil {
+		// Handle error.
+	}
+	results, err := CallField(config, "Validate", "")
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Println(callable, results)
+	// Output: true [empty name]
+}
@@ -0,0 +1,38 @@
+package attr
+
+import "time"
+
+// ChangeRecord describes a single change of a field value, as emitted by the
+// WithChangeHook option. Field is the field name, or a dot separated path of
+// names for a nested field.
+type ChangeRecord struct {
+	Field     string
+	Old       interface{}
+	New       interface{}
+	Timestamp time.Time
+}
+
+// ApplyChanges replays the given changes on a struct, such as the ones read
+// back from an audit log, by setting the New value of every record to its
+// field like SetPaths. If a field is changed more than once, its last record
+// wins. The Old values and the timestamps are not checked.
+//
+// All the fields are checked before setting any of them, so the struct is not
+// modified if an error is returned.
+//
+// NOTE: 'obj' struct must be passed by pointer for this API to work. Passing by
+// value results in ErrNotPtr.
+func ApplyChanges(obj interface{}, changes []ChangeRecord, opts ...Option) error {
+	values := make(map[string]interface{}, len(changes))
+	for _, change := range changes {
+		values[change.Field] = change.New
+	}
+	return SetPaths(obj, values, opts...)
+}
+
+// emitChange calls the change hook of the WithChangeHook option, if any.
+func (o *options) emitChange(field string, oldValue, newValue interface{}) {
+	if o.changeHook != nil {
+		o.changeHook(ChangeRecord{field, oldValue, newValue, time.Now()})
+	}
+}
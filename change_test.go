@@ -0,0 +1,56 @@
+package attr
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithChangeHook(t *testing.T) {
+	changes := []ChangeRecord{}
+	hook := WithChangeHook(func(change ChangeRecord) { changes = append(changes, change) })
+
+	employee := Employee{Name: "srathi", Address: &Address{City: "San Jose"}}
+	start := time.Now()
+	require.Nil(t, SetValue(&employee, "Name", "shyam", hook))
+	require.Nil(t, SetPaths(&employee, map[string]interface{}{
+		"Address.Zip":  "95134",
+		"Address.City": "Fremont",
+	}, hook))
+	require.Equal(t, ErrMismatchValue, SetValue(&employee, "Name", 10, hook))
+
+	require.Equal(t, 3, len(changes), "Incorrect number of change records")
+	for i, want := range []ChangeRecord{
+		{Field: "Name", Old: "srathi", New: "shyam"},
+		{Field: "Address.City", Old: "San Jose", New: "Fremont"},
+		{Field: "Address.Zip", Old: "", New: "95134"},
+	} {
+		require.False(t, changes[i].Timestamp.Before(start), "Change timestamp is not correct")
+		changes[i].Timestamp = time.Time{}
+		require.Equal(t, want, changes[i], "Change record is not correct")
+	}
+
+	replayed := Employee{Address: &Address{}}
+	require.Nil(t, ApplyChanges(&replayed, changes))
+	require.Equal(t, "shyam", replayed.Name, "Field change not replayed")
+	require.Equal(t, Address{City: "Fremont", Zip: "95134"}, *replayed.Address, "Nested field change not replayed")
+
+	err := ApplyChanges(&replayed, []ChangeRecord{{Field: "Name", New: "abc"}, {Field: "Age", New: 1}})
+	require.Equal(t, &FieldError{"Age", ErrNoField}, err, "Able to replay a change of a missing field")
+	require.Equal(t, "shyam", replayed.Name, "Struct is updated on an error")
+}
+
+func ExampleWithChangeHook() {
+	testUser := User{Username: "srathi", password: "secret", Age: 30}
+
+	audit := WithChangeHook(func(change ChangeRecord) {
+		fmt.Printf("%s: %v -> %v\n", change.Field, change.Old, change.New)
+	})
+	err := SetValue(&testUser, "Age", 31, audit)
+	if err != nil {
+		// Handle error.
+	}
+	// Output: Age: 30 -> 31
+}
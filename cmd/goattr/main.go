@@ -0,0 +1,421 @@
+// Command goattr inspects the struct types of Go packages without running
+// them, using the same logic as the runtime APIs of the attr package.
+//
+// Usage:
+//
+//	goattr describe [--type NAME] [--tag KEY] [packages]
+//	goattr layout [--type NAME] [packages]
+//	goattr lint --tag KEY [--type NAME] [packages]
+//	goattr stats [--type NAME] [packages]
+//
+// The "describe" command lists the fields of the structs with their types,
+// kinds, offsets and tags (only the given tag key with --tag). The "layout"
+// command shows the padding of the structs and a field order which minimizes
+// it, and the "lint" command reports the duplicate, malformed and missing
+// tags of the given key. The "stats" command counts the fields of the structs
+// by kind and by tag key and reports the depth of their nested structs, like
+// attr.Stats. Packages are given as patterns like "./...", and
+// default to the package in the current directory.
+//
+// The exit status is 1 if "lint" finds a problem, and 2 on an error.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"go/types"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	attr "github.com/ssrathi/go-attr"
+	"golang.org/x/tools/go/packages"
+)
+
+// errLintIssues is returned by the "lint" command if a problem is found.
+var errLintIssues = errors.New("tag problems found")
+
+// command is the parsed command line.
+type command struct {
+	name     string
+	typeName string
+	tagKey   string
+	patterns []string
+	dir      string
+}
+
+// structType is a named struct type found in a package.
+type structType struct {
+	name   string
+	typ    *types.Struct
+	sizes  types.Sizes
+	fields []*types.Var
+}
+
+func main() {
+	err := run(os.Args[1:], os.Stdout, "")
+	if errors.Is(err, errLintIssues) {
+		os.Exit(1)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "goattr:", err)
+		os.Exit(2)
+	}
+}
+
+// run executes the command given by the arguments, writing its output to
+// 'out'. 'dir' is the directory to load the packages from, the current one
+// if empty.
+func run(args []string, out io.Writer, dir string) error {
+	cmd, err := parseArgs(args)
+	if err != nil {
+		return err
+	}
+	cmd.dir = dir
+
+	structs, err := loadStructs(cmd)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	defer w.Flush()
+
+	found := false
+	for _, st := range structs {
+		switch cmd.name {
+		case "describe":
+			describe(w, st, cmd.tagKey)
+		case "layout":
+			layout(w, st)
+		case "lint":
+			found = lint(w, st, cmd.tagKey) || found
+		case "stats":
+			stats(w, st)
+		}
+	}
+
+	if found {
+		return errLintIssues
+	}
+	return nil
+}
+
+// parseArgs parses the command line. Flags can be given before or after the
+// package patterns.
+func parseArgs(args []string) (command, error) {
+	cmd := command{}
+	if len(args) == 0 {
+		return cmd, errors.New("missing command: describe, layout, lint or stats")
+	}
+
+	cmd.name = args[0]
+	switch cmd.name {
+	case "describe", "layout", "lint", "stats":
+	default:
+		return cmd, fmt.Errorf("unknown command %q", cmd.name)
+	}
+
+	fs := flag.NewFlagSet(cmd.name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.StringVar(&cmd.typeName, "type", "", "only inspect the struct type of this name")
+	fs.StringVar(&cmd.tagKey, "tag", "", "tag key to show or to lint")
+
+	args = args[1:]
+	for {
+		if err := fs.Parse(args); err != nil {
+			return cmd, err
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		cmd.patterns = append(cmd.patterns, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+
+	if cmd.name == "lint" && cmd.tagKey == "" {
+		return cmd, errors.New("lint needs a tag key with --tag")
+	}
+	if len(cmd.patterns) == 0 {
+		cmd.patterns = []string{"."}
+	}
+	return cmd, nil
+}
+
+// loadStructs loads the packages of the command and returns their named
+// struct types, sorted by their qualified names.
+func loadStructs(cmd command) ([]structType, error) {
+	// Type check everything from the source rather than reading the export
+	// data of the dependencies, whose format depends on the Go toolchain.
+	config := &packages.Config{
+		Mode: packages.NeedName | packages.NeedTypes | packages.NeedTypesSizes |
+			packages.NeedSyntax | packages.NeedImports | packages.NeedDeps,
+		Dir: cmd.dir,
+	}
+	pkgs, err := packages.Load(config, cmd.patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	structs := []structType{}
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) > 0 {
+			return nil, pkg.Errors[0]
+		}
+
+		scope := pkg.Types.Scope()
+		for _, name := range scope.Names() {
+			if cmd.typeName != "" && name != cmd.typeName {
+				continue
+			}
+
+			typeName, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok || typeName.IsAlias() {
+				continue
+			}
+
+			st, ok := typeName.Type().Underlying().(*types.Struct)
+			if !ok {
+				continue
+			}
+
+			fields := make([]*types.Var, st.NumFields())
+			for i := range fields {
+				fields[i] = st.Field(i)
+			}
+			structs = append(structs, structType{
+				name:   pkg.PkgPath + "." + name,
+				typ:    st,
+				sizes:  pkg.TypesSizes,
+				fields: fields,
+			})
+		}
+	}
+
+	if cmd.typeName != "" && len(structs) == 0 {
+		return nil, fmt.Errorf("struct type %q not found", cmd.typeName)
+	}
+
+	sort.Slice(structs, func(i, j int) bool { return structs[i].name < structs[j].name })
+	return structs, nil
+}
+
+// describe writes the fields of a struct type, like attr.Describe.
+func describe(w io.Writer, st structType, tagKey string) {
+	fmt.Fprintf(w, "%s (size %d)\n", st.name, st.sizes.Sizeof(st.typ))
+	offsets := st.sizes.Offsetsof(st.fields)
+	for i, field := range st.fields {
+		tags, _ := attr.ParseTag(reflect.StructTag(st.typ.Tag(i)))
+		tagText := formatTags(tags)
+		if tagKey != "" {
+			tagText = tags[tagKey]
+		}
+
+		flags := []string{}
+		if !field.Exported() {
+			flags = append(flags, "unexported")
+		}
+		if field.Embedded() {
+			flags = append(flags, "embedded")
+		}
+
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%d\t%s\t%s\n", field.Name(),
+			types.TypeString(field.Type(), types.RelativeTo(field.Pkg())),
+			kindOf(field.Type()), offsets[i], tagText, strings.Join(flags, ","))
+	}
+}
+
+// layout writes the memory layout of a struct type, like attr.Layout.
+func layout(w io.Writer, st structType) {
+	offsets := st.sizes.Offsetsof(st.fields)
+	fields := make([]attr.FieldLayout, len(st.fields))
+	for i, field := range st.fields {
+		fields[i] = attr.FieldLayout{
+			Name:   field.Name(),
+			Offset: uintptr(offsets[i]),
+			Size:   uintptr(st.sizes.Sizeof(field.Type())),
+			Align:  uintptr(st.sizes.Alignof(field.Type())),
+		}
+	}
+
+	result := attr.BuildLayout(uintptr(st.sizes.Sizeof(st.typ)), uintptr(st.sizes.Alignof(st.typ)), fields)
+	fmt.Fprintf(w, "%s (size %d, padding %d)\n", st.name, result.Size, result.Padding)
+	for _, field := range result.Fields {
+		fmt.Fprintf(w, "  %s\toffset %d\tsize %d\tpadding %d\n", field.Name, field.Offset, field.Size, field.Padding)
+	}
+	if result.SuggestedSize < result.Size {
+		fmt.Fprintf(w, "  suggested order (size %d): %s\n", result.SuggestedSize,
+			strings.Join(result.Suggested, ", "))
+	}
+}
+
+// lint writes the tag problems of a struct type, like attr.CheckTags, and
+// returns true if a problem is found.
+func lint(w io.Writer, st structType, tagKey string) bool {
+	fields := make([]reflect.StructField, len(st.fields))
+	for i, field := range st.fields {
+		fields[i] = reflect.StructField{Name: field.Name(), Tag: reflect.StructTag(st.typ.Tag(i))}
+		if !field.Exported() {
+			fields[i].PkgPath = field.Pkg().Path()
+		}
+	}
+
+	issues := attr.CheckFieldTags(fields, tagKey)
+	for _, issue := range issues {
+		fmt.Fprintf(w, "%s: %s\n", st.name, issue)
+	}
+	return len(issues) > 0
+}
+
+// stats writes the statistics of a struct type, like attr.Stats.
+func stats(w io.Writer, st structType) {
+	result := attr.StructStats{
+		Kinds:    map[string]int{},
+		TagKeys:  map[string]int{},
+		MaxDepth: structDepth(st.typ, map[*types.Struct]bool{}),
+	}
+	for i, field := range st.fields {
+		result.Fields++
+		if field.Exported() {
+			result.Exported++
+		} else {
+			result.Unexported++
+		}
+		result.Kinds[kindOf(field.Type()).String()]++
+
+		tags, _ := attr.ParseTag(reflect.StructTag(st.typ.Tag(i)))
+		for key := range tags {
+			result.TagKeys[key]++
+			result.Tags++
+		}
+	}
+
+	fmt.Fprintf(w, "%s (fields %d, exported %d, unexported %d, depth %d, tags %d)\n", st.name,
+		result.Fields, result.Exported, result.Unexported, result.MaxDepth, result.Tags)
+	for _, kind := range sortedKeys(result.Kinds) {
+		fmt.Fprintf(w, "  kind %s\t%d\n", kind, result.Kinds[kind])
+	}
+	for _, key := range sortedKeys(result.TagKeys) {
+		fmt.Fprintf(w, "  tag %s\t%d\n", key, result.TagKeys[key])
+	}
+}
+
+// structDepth returns the number of levels of nested structs of a struct type,
+// like attr.Stats. 'inProgress' holds the enclosing structs, to stop on
+// recursive types.
+func structDepth(st *types.Struct, inProgress map[*types.Struct]bool) int {
+	inProgress[st] = true
+	defer delete(inProgress, st)
+
+	depth := 0
+	for i := 0; i < st.NumFields(); i++ {
+		if target := structTarget(st.Field(i).Type()); target != nil && !inProgress[target] {
+			depth = max(depth, structDepth(target, inProgress))
+		}
+	}
+	return depth + 1
+}
+
+// structTarget returns the struct type referred to by a field type, after
+// following the pointers and the element types of the slices, arrays and maps.
+// It returns nil if the field doesn't refer to a struct type, or refers to
+// time.Time.
+func structTarget(typ types.Type) *types.Struct {
+	for {
+		if named, ok := typ.(*types.Named); ok && named.Obj().Pkg() != nil &&
+			named.Obj().Pkg().Path() == "time" && named.Obj().Name() == "Time" {
+			return nil
+		}
+
+		switch t := typ.Underlying().(type) {
+		case *types.Pointer:
+			typ = t.Elem()
+		case *types.Slice:
+			typ = t.Elem()
+		case *types.Array:
+			typ = t.Elem()
+		case *types.Map:
+			typ = t.Elem()
+		case *types.Struct:
+			return t
+		default:
+			return nil
+		}
+	}
+}
+
+// sortedKeys returns the keys of a map of counts in order.
+func sortedKeys(counts map[string]int) []string {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// formatTags formats the parsed tags in the order of their keys.
+func formatTags(tags map[string]string) string {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = fmt.Sprintf("%s:%q", key, tags[key])
+	}
+	return strings.Join(pairs, " ")
+}
+
+// basicKinds maps the kinds of the basic types to their reflect kinds.
+var basicKinds = map[types.BasicKind]reflect.Kind{
+	types.Bool:          reflect.Bool,
+	types.Int:           reflect.Int,
+	types.Int8:          reflect.Int8,
+	types.Int16:         reflect.Int16,
+	types.Int32:         reflect.Int32,
+	types.Int64:         reflect.Int64,
+	types.Uint:          reflect.Uint,
+	types.Uint8:         reflect.Uint8,
+	types.Uint16:        reflect.Uint16,
+	types.Uint32:        reflect.Uint32,
+	types.Uint64:        reflect.Uint64,
+	types.Uintptr:       reflect.Uintptr,
+	types.Float32:       reflect.Float32,
+	types.Float64:       reflect.Float64,
+	types.Complex64:     reflect.Complex64,
+	types.Complex128:    reflect.Complex128,
+	types.String:        reflect.String,
+	types.UnsafePointer: reflect.UnsafePointer,
+}
+
+// kindOf returns the reflect kind of a type, as reported by attr.Kinds.
+func kindOf(typ types.Type) reflect.Kind {
+	switch t := typ.Underlying().(type) {
+	case *types.Basic:
+		return basicKinds[t.Kind()]
+	case *types.Struct:
+		return reflect.Struct
+	case *types.Pointer:
+		return reflect.Ptr
+	case *types.Slice:
+		return reflect.Slice
+	case *types.Array:
+		return reflect.Array
+	case *types.Map:
+		return reflect.Map
+	case *types.Chan:
+		return reflect.Chan
+	case *types.Signature:
+		return reflect.Func
+	case *types.Interface:
+		return reflect.Interface
+	}
+	return reflect.Invalid
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescribe(t *testing.T) {
+	out := &bytes.Buffer{}
+	err := run([]string{"describe", "./testdata/models", "--type", "User", "--tag", "json"}, out, "")
+	require.Nil(t, err)
+	require.Equal(t, `github.com/ssrathi/go-attr/cmd/goattr/testdata/models.User (size 96)
+  Base      Base      struct  0             embedded
+  Active    bool      bool    8   active    
+  Username  string    string  16  username  
+  Age       int32     int32   32  age       
+  Email     string    string  40  username  
+  Tags      []string  slice   56            
+  password  string    string  80            unexported
+`, out.String(), "Description is not correct")
+
+	out.Reset()
+	err = run([]string{"describe", "--type", "Base", "./testdata/models"}, out, "")
+	require.Nil(t, err)
+	require.Equal(t, `github.com/ssrathi/go-attr/cmd/goattr/testdata/models.Base (size 8)
+  ID  int64  int64  0  db:"id" json:"id"  
+`, out.String(), "Description with all the tags is not correct")
+}
+
+func TestLayout(t *testing.T) {
+	out := &bytes.Buffer{}
+	err := run([]string{"layout", "--type", "Base", "./testdata/models"}, out, "")
+	require.Nil(t, err)
+	require.Equal(t, `github.com/ssrathi/go-attr/cmd/goattr/testdata/models.Base (size 8, padding 0)
+  ID  offset 0  size 8  padding 0
+`, out.String(), "Layout is not correct")
+
+	out.Reset()
+	err = run([]string{"layout", "--type", "User", "./testdata/models"}, out, "")
+	require.Nil(t, err)
+	require.Contains(t, out.String(), "(size 96, padding 11)", "Padding is not correct")
+	require.Contains(t, out.String(),
+		"suggested order (size 88): Tags, Username, Email, password, Base, Age, Active",
+		"Suggested order is not correct")
+}
+
+func TestLint(t *testing.T) {
+	out := &bytes.Buffer{}
+	err := run([]string{"lint", "--tag", "json", "./testdata/models"}, out, "")
+	require.True(t, errors.Is(err, errLintIssues), "Lint problems not reported")
+	require.Equal(t, `github.com/ssrathi/go-attr/cmd/goattr/testdata/models.User: Base: missing: "json" tag is not present
+github.com/ssrathi/go-attr/cmd/goattr/testdata/models.User: Email: duplicate: "json" tag value "username" is also used by field Username
+github.com/ssrathi/go-attr/cmd/goattr/testdata/models.User: Tags: missing: "json" tag is not present
+`, out.String(), "Lint output is not correct")
+
+	out.Reset()
+	err = run([]string{"lint", "--tag", "json", "--type", "Base", "./testdata/models"}, out, "")
+	require.Nil(t, err)
+	require.Empty(t, out.String(), "Lint problems found in a valid struct")
+}
+
+func TestStats(t *testing.T) {
+	out := &bytes.Buffer{}
+	err := run([]string{"stats", "--type", "User", "./testdata/models"}, out, "")
+	require.Nil(t, err)
+	require.Equal(t, `github.com/ssrathi/go-attr/cmd/goattr/testdata/models.User (fields 7, exported 6, unexported 1, depth 2, tags 5)
+  kind bool    1
+  kind int32   1
+  kind slice   1
+  kind string  3
+  kind struct  1
+  tag db       1
+  tag json     4
+`, out.String(), "Stats are not correct")
+}
+
+func TestParseArgs(t *testing.T) {
+	cmd, err := parseArgs([]string{"describe", "./a", "--tag", "db", "./b", "--type", "User"})
+	require.Nil(t, err)
+	require.Equal(t, command{name: "describe", typeName: "User", tagKey: "db",
+		patterns: []string{"./a", "./b"}}, cmd, "Arguments are not parsed correctly")
+
+	cmd, err = parseArgs([]string{"layout"})
+	require.Nil(t, err)
+	require.Equal(t, []string{"."}, cmd.patterns, "Default package pattern is not correct")
+
+	for _, args := range [][]string{
+		{},
+		{"show"},
+		{"lint", "./..."},
+		{"describe", "--unknown"},
+	} {
+		_, err := parseArgs(args)
+		require.NotNil(t, err, "Invalid arguments %v are accepted", args)
+	}
+
+	err = run([]string{"describe", "--type", "Missing", "./testdata/models"}, &bytes.Buffer{}, "")
+	require.NotNil(t, err, "Missing type is not reported")
+}
@@ -0,0 +1,17 @@
+package models
+
+type Base struct {
+	ID int64 `json:"id" db:"id"`
+}
+
+type User struct {
+	Base
+	Active   bool   `json:"active"`
+	Username string `json:"username" db:"uname"`
+	Age      int32  `json:"age"`
+	Email    string `json:"username"`
+	Tags     []string
+	password string
+}
+
+type notAStruct int
@@ -0,0 +1,66 @@
+package attr
+
+import (
+	"reflect"
+	"sync"
+)
+
+// CompareFunc reports whether two values of the same type are equal. Both the
+// values are of the type the function is registered for with RegisterComparer.
+type CompareFunc func(a, b interface{}) bool
+
+// comparers is the registry of the custom comparison functions by type.
+var comparers = struct {
+	sync.RWMutex
+	funcs map[reflect.Type]CompareFunc
+}{
+	funcs: map[reflect.Type]CompareFunc{},
+}
+
+// RegisterComparer adds a custom comparison function for the values of a given
+// type, such as time.Time truncated to seconds, floats with a tolerance or
+// case-insensitive strings. It is used by EqualExported, Diff, Watch and
+// Merge3 instead of deep equality for the fields (and the nested values) of
+// that type. A struct type with a comparer is compared as a whole rather than
+// field by field. A previously registered function for the type is replaced,
+// and a nil function removes it.
+//
+// For example:
+//
+//	attr.RegisterComparer(reflect.TypeOf(time.Time{}), func(a, b interface{}) bool {
+//		return a.(time.Time).Truncate(time.Second).Equal(b.(time.Time).Truncate(time.Second))
+//	})
+func RegisterComparer(typ reflect.Type, fn CompareFunc) {
+	comparers.Lock()
+	defer comparers.Unlock()
+	if fn == nil {
+		delete(comparers.funcs, typ)
+		return
+	}
+	comparers.funcs[typ] = fn
+}
+
+// comparerFor returns the comparison function registered for a type, or nil
+// if there is none.
+func comparerFor(typ reflect.Type) CompareFunc {
+	comparers.RLock()
+	defer comparers.RUnlock()
+	return comparers.funcs[typ]
+}
+
+// valuesEqual compares two field values with the comparer registered for
+// their type, falling back to reflect.DeepEqual.
+func valuesEqual(a, b interface{}) bool {
+	if typ := reflect.TypeOf(a); typ != nil && typ == reflect.TypeOf(b) {
+		if fn := comparerFor(typ); fn != nil {
+			return fn(a, b)
+		}
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// compareByField returns true if the fields of a struct type are compared one
+// by one, i.e. it is neither time.Time nor a type with a registered comparer.
+func compareByField(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Struct && typ != timeType && comparerFor(typ) == nil
+}
@@ -0,0 +1,78 @@
+package attr
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Celsius float64
+
+type Reading struct {
+	Sensor  string
+	Temp    Celsius
+	Address Address
+}
+
+func TestRegisterComparer(t *testing.T) {
+	RegisterComparer(reflect.TypeOf(Celsius(0)), func(a, b interface{}) bool {
+		return math.Abs(float64(a.(Celsius)-b.(Celsius))) < 0.5
+	})
+	RegisterComparer(reflect.TypeOf(Address{}), func(a, b interface{}) bool {
+		return strings.EqualFold(a.(Address).City, b.(Address).City)
+	})
+	defer RegisterComparer(reflect.TypeOf(Celsius(0)), nil)
+	defer RegisterComparer(reflect.TypeOf(Address{}), nil)
+
+	old := Reading{"s1", 20.1, Address{City: "Fremont", Zip: "94536"}}
+	new := Reading{"s1", 20.3, Address{City: "FREMONT"}}
+
+	equal, err := EqualExported(old, new)
+	require.Nil(t, err)
+	require.True(t, equal, "Custom comparers are not used by EqualExported")
+
+	changes, err := Diff(old, new)
+	require.Nil(t, err)
+	require.Empty(t, changes, "Custom comparers are not used by Diff")
+
+	new.Temp = 25
+	changes, err = Diff(old, &new)
+	require.Nil(t, err)
+	require.Equal(t, []Change{{"Temp", Celsius(20.1), Celsius(25)}}, changes, "Changes are not correct")
+
+	theirs := Reading{"s2", 20.4, Address{City: "fremont"}}
+	merged, conflicts, err := Merge3(old, new, theirs)
+	require.Nil(t, err)
+	require.Empty(t, conflicts, "Custom comparers are not used by Merge3")
+	require.Equal(t, Reading{"s2", 25, Address{City: "FREMONT"}}, merged, "Merged struct is not correct")
+
+	RegisterComparer(reflect.TypeOf(Address{}), nil)
+	changes, err = Diff(old, theirs)
+	require.Nil(t, err)
+	require.Equal(t, 3, len(changes), "Removed comparer is still used")
+}
+
+func ExampleRegisterComparer() {
+	type Event struct {
+		Name string
+		At   time.Time
+	}
+
+	RegisterComparer(reflect.TypeOf(time.Time{}), func(a, b interface{}) bool {
+		return a.(time.Time).Truncate(time.Second).Equal(b.(time.Time).Truncate(time.Second))
+	})
+	defer RegisterComparer(reflect.TypeOf(time.Time{}), nil)
+
+	at := time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC)
+	equal, err := EqualExported(Event{"start", at}, Event{"start", at.Add(time.Millisecond)})
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Println(equal)
+	// Output: true
+}
@@ -0,0 +1,422 @@
+package attr
+
+import (
+	"encoding"
+	"encoding/hex"
+	"math"
+	"net"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// textUnmarshalerType is the reflect type of encoding.TextUnmarshaler.
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// The struct types of the standard library which are parsed from a string and
+// formatted to one without a TextUnmarshaler.
+var (
+	urlType   = reflect.TypeOf(url.URL{})
+	ipNetType = reflect.TypeOf(net.IPNet{})
+)
+
+// timeLayouts are the layouts tried after the default time.RFC3339 layout to
+// parse a time.Time value.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	time.DateTime,
+	"2006-01-02T15:04:05",
+	time.DateOnly,
+	time.RFC1123Z,
+	time.RFC1123,
+}
+
+// isValueStruct returns true if a struct type is parsed from a single string
+// and formatted as one, such as time.Time and url.URL, instead of being
+// handled field by field.
+func isValueStruct(typ reflect.Type) bool {
+	return typ == timeType || typ == urlType || typ == ipNetType
+}
+
+// convertValue converts a value to the given type, for the APIs which decode
+// a struct from loosely typed data, such as Layer. Strings are parsed as the
+// target type, numbers are converted between the numeric kinds, and slices
+// and maps are converted element by element. A slice converted to an array
+// fills its first elements, and ErrFixedSize is returned if it is longer than
+// the array. The types implementing
+// sql.Scanner are set with their Scan method, and the types with a function
+// registered by RegisterConverter (such as big.Int) are converted with it.
+//
+// With the WithWeakTypes option, the conversions of weakConvert are done too.
+//
+// Returns ErrInvalidValue if a string can't be parsed, an *OverflowError if a
+// number doesn't fit in the type, and ErrMismatchValue if the value can't be
+// converted to the type.
+func convertValue(value interface{}, typ reflect.Type, o *options) (reflect.Value, error) {
+	if value == nil {
+		return reflect.Zero(typ), nil
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Type().AssignableTo(typ) {
+		return rv.Convert(typ), nil
+	}
+
+	if converted, found, err := applyConverter(value, typ); found {
+		return converted, err
+	}
+
+	if isScanner(typ) {
+		scanned, err := scanValue(value, typ)
+		if err != nil {
+			return reflect.Value{}, ErrInvalidValue
+		}
+		return scanned, nil
+	}
+
+	if text, ok := value.(string); ok {
+		if o.weakTypes && text == "" && (isNumber(typ.Kind()) || typ.Kind() == reflect.Bool) {
+			return reflect.Zero(typ), nil
+		}
+		return parseString(text, typ, o)
+	}
+
+	if typ.Kind() == reflect.Ptr {
+		elem, err := convertValue(value, typ.Elem(), o)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		ptr := reflect.New(typ.Elem())
+		ptr.Elem().Set(elem)
+		return ptr, nil
+	}
+
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return reflect.Zero(typ), nil
+		}
+		return convertValue(rv.Elem().Interface(), typ, o)
+	}
+
+	switch {
+	case isNumber(rv.Kind()) && isNumber(typ.Kind()):
+		return convertNumber(rv, typ)
+
+	case typ.Kind() == reflect.Slice && (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array):
+		slice := reflect.MakeSlice(typ, rv.Len(), rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			elem, err := convertValue(rv.Index(i).Interface(), typ.Elem(), o)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			slice.Index(i).Set(elem)
+		}
+		return slice, nil
+
+	case typ.Kind() == reflect.Array && (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array):
+		if rv.Len() > typ.Len() {
+			return reflect.Value{}, ErrFixedSize
+		}
+		array := reflect.New(typ).Elem()
+		for i := 0; i < rv.Len(); i++ {
+			elem, err := convertValue(rv.Index(i).Interface(), typ.Elem(), o)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			array.Index(i).Set(elem)
+		}
+		return array, nil
+
+	case typ.Kind() == reflect.Map && rv.Kind() == reflect.Map:
+		mapValue := reflect.MakeMapWithSize(typ, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			key, err := convertValue(iter.Key().Interface(), typ.Key(), o)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			elem, err := convertValue(iter.Value().Interface(), typ.Elem(), o)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			mapValue.SetMapIndex(key, elem)
+		}
+		return mapValue, nil
+
+	case rv.Kind() == typ.Kind() && rv.Type().ConvertibleTo(typ):
+		// Such as a string to a named string type.
+		return rv.Convert(typ), nil
+	}
+
+	if o.weakTypes {
+		return weakConvert(rv, typ, o)
+	}
+	return reflect.Value{}, ErrMismatchValue
+}
+
+// weakConvert does the conversions enabled by the WithWeakTypes option: bools
+// to numbers (true is 1), numbers to bools (non-zero is true), bools and
+// numbers to strings, byte slices to strings, and a single value to a slice of
+// one element or to the first element of an array.
+//
+// Returns ErrMismatchValue if the value can't be converted to the type.
+func weakConvert(rv reflect.Value, typ reflect.Type, o *options) (reflect.Value, error) {
+	switch {
+	case rv.Kind() == reflect.Bool && isNumber(typ.Kind()):
+		number := 0
+		if rv.Bool() {
+			number = 1
+		}
+		return reflect.ValueOf(number).Convert(typ), nil
+
+	case isNumber(rv.Kind()) && typ.Kind() == reflect.Bool:
+		return reflect.ValueOf(!rv.IsZero()).Convert(typ), nil
+
+	case typ.Kind() == reflect.String && (isNumber(rv.Kind()) || rv.Kind() == reflect.Bool):
+		text, err := formatValue(rv, o.timeLayout)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(text).Convert(typ), nil
+
+	case typ.Kind() == reflect.String && rv.Kind() == reflect.Slice &&
+		rv.Type().Elem().Kind() == reflect.Uint8:
+		return reflect.ValueOf(string(rv.Bytes())).Convert(typ), nil
+
+	case typ.Kind() == reflect.Slice && rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array:
+		elem, err := convertValue(rv.Interface(), typ.Elem(), o)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		slice := reflect.MakeSlice(typ, 1, 1)
+		slice.Index(0).Set(elem)
+		return slice, nil
+
+	case typ.Kind() == reflect.Array && typ.Len() > 0 && rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array:
+		elem, err := convertValue(rv.Interface(), typ.Elem(), o)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		array := reflect.New(typ).Elem()
+		array.Index(0).Set(elem)
+		return array, nil
+	}
+
+	return reflect.Value{}, ErrMismatchValue
+}
+
+// parseString parses a string as a value of the given type. Integers are
+// parsed in base 10, so "010" is 10. Slices and arrays are parsed from comma
+// separated items, time.Time values with the layout given by the
+// WithTimeLayout option (see parseTime), durations like "1m30s", url.URL
+// values, net.IPNet values in CIDR notation and [16]byte arrays from UUIDs.
+// The enum types registered by RegisterEnum are parsed by their names, the
+// types with a function registered by RegisterConverter with it, and the types
+// implementing encoding.TextUnmarshaler with it.
+func parseString(text string, typ reflect.Type, o *options) (reflect.Value, error) {
+	if value, found, err := enumValue(text, typ); found {
+		return value, err
+	}
+
+	if value, found, err := applyConverter(text, typ); found {
+		return value, err
+	}
+
+	switch typ {
+	case timeType:
+		return parseTime(text, o.timeLayout)
+
+	case urlType:
+		u, err := url.Parse(text)
+		if err != nil {
+			return reflect.Value{}, ErrInvalidValue
+		}
+		return reflect.ValueOf(*u), nil
+
+	case ipNetType:
+		_, ipNet, err := net.ParseCIDR(text)
+		if err != nil {
+			return reflect.Value{}, ErrInvalidValue
+		}
+		return reflect.ValueOf(*ipNet), nil
+	}
+
+	if typ == durationType {
+		d, err := time.ParseDuration(text)
+		if err != nil {
+			return reflect.Value{}, ErrInvalidValue
+		}
+		return reflect.ValueOf(d), nil
+	}
+
+	value := reflect.New(typ).Elem()
+	if reflect.PtrTo(typ).Implements(textUnmarshalerType) {
+		unmarshaler := value.Addr().Interface().(encoding.TextUnmarshaler)
+		if err := unmarshaler.UnmarshalText([]byte(text)); err != nil {
+			return reflect.Value{}, ErrInvalidValue
+		}
+		return value, nil
+	}
+
+	var err error
+	switch typ.Kind() {
+	case reflect.String:
+		value.SetString(text)
+	case reflect.Bool:
+		var b bool
+		b, err = strconv.ParseBool(text)
+		value.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		i, err = strconv.ParseInt(text, 10, typ.Bits())
+		value.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var u uint64
+		u, err = strconv.ParseUint(text, 10, typ.Bits())
+		value.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		f, err = strconv.ParseFloat(text, typ.Bits())
+		value.SetFloat(f)
+	case reflect.Ptr:
+		elem, err := parseString(text, typ.Elem(), o)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		ptr := reflect.New(typ.Elem())
+		ptr.Elem().Set(elem)
+		return ptr, nil
+	case reflect.Slice:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			value.SetBytes([]byte(text))
+			break
+		}
+		items := []string{}
+		if text != "" {
+			items = strings.Split(text, ",")
+		}
+		value = reflect.MakeSlice(typ, len(items), len(items))
+		for i, item := range items {
+			elem, err := parseString(strings.TrimSpace(item), typ.Elem(), o)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			value.Index(i).Set(elem)
+		}
+	case reflect.Array:
+		if uuid, ok := parseUUID(text); ok && typ.Len() == len(uuid) && typ.Elem().Kind() == reflect.Uint8 {
+			reflect.Copy(value, reflect.ValueOf(uuid[:]))
+			break
+		}
+		items := []string{}
+		if text != "" {
+			items = strings.Split(text, ",")
+		}
+		if len(items) > typ.Len() {
+			return reflect.Value{}, ErrFixedSize
+		}
+		for i, item := range items {
+			elem, err := parseString(strings.TrimSpace(item), typ.Elem(), o)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			value.Index(i).Set(elem)
+		}
+	default:
+		return reflect.Value{}, ErrMismatchValue
+	}
+
+	if err != nil {
+		return reflect.Value{}, ErrInvalidValue
+	}
+	return value, nil
+}
+
+// parseTime parses a time.Time value with the given layout. With the default
+// time.RFC3339 layout, the common layouts in timeLayouts are tried too, such
+// as "2006-01-02" and "2006-01-02 15:04:05".
+func parseTime(text, layout string) (reflect.Value, error) {
+	t, err := time.Parse(layout, text)
+	if err == nil {
+		return reflect.ValueOf(t), nil
+	}
+
+	if layout == time.RFC3339 {
+		for _, layout := range timeLayouts {
+			if t, err := time.Parse(layout, text); err == nil {
+				return reflect.ValueOf(t), nil
+			}
+		}
+	}
+	return reflect.Value{}, ErrInvalidValue
+}
+
+// parseUUID parses a UUID in its canonical form, such as
+// "123e4567-e89b-12d3-a456-426614174000", or as 32 hex digits without the
+// dashes. It returns false if the text is not a UUID.
+func parseUUID(text string) ([16]byte, bool) {
+	var uuid [16]byte
+	if len(text) == 36 {
+		if text[8] != '-' || text[13] != '-' || text[18] != '-' || text[23] != '-' {
+			return uuid, false
+		}
+		text = text[:8] + text[9:13] + text[14:18] + text[19:23] + text[24:]
+	}
+
+	if len(text) != 2*len(uuid) {
+		return uuid, false
+	}
+	if _, err := hex.Decode(uuid[:], []byte(text)); err != nil {
+		return uuid, false
+	}
+	return uuid, true
+}
+
+// convertNumber converts a number to another numeric type. Unlike
+// reflect.Value.Convert, it returns an *OverflowError instead of silently
+// truncating a value which doesn't fit in the type, changing its sign or
+// dropping its fractional part. A float converted to a smaller float type may
+// lose precision, but not overflow to infinity.
+func convertNumber(rv reflect.Value, typ reflect.Type) (reflect.Value, error) {
+	result := rv.Convert(typ)
+	if isFloat(rv.Kind()) && isFloat(typ.Kind()) {
+		if math.IsInf(result.Float(), 0) && !math.IsInf(rv.Float(), 0) {
+			return reflect.Value{}, &OverflowError{rv.Interface(), typ}
+		}
+		return result, nil
+	}
+
+	if result.Convert(rv.Type()).Interface() != rv.Interface() || isNegative(rv) != isNegative(result) {
+		return reflect.Value{}, &OverflowError{rv.Interface(), typ}
+	}
+	return result, nil
+}
+
+// isFloat returns true if the given kind is a float kind.
+func isFloat(kind reflect.Kind) bool {
+	return kind == reflect.Float32 || kind == reflect.Float64
+}
+
+// isNegative returns true if a number is less than zero.
+func isNegative(number reflect.Value) bool {
+	switch number.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return number.Int() < 0
+	case reflect.Float32, reflect.Float64:
+		return number.Float() < 0
+	}
+	return false
+}
+
+// isNumber returns true if the given kind is an integer or a float kind.
+func isNumber(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
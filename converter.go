@@ -0,0 +1,148 @@
+package attr
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"sync"
+)
+
+// ConvertFunc converts a loosely typed value (such as a string, a number or a
+// json.Number) to a value of the type it is registered for with
+// RegisterConverter. It returns an error if the value can't be converted,
+// which is returned as is by the decoding APIs.
+type ConvertFunc func(value interface{}) (interface{}, error)
+
+// The reflect types of the big numbers, which are converted from strings and
+// numbers without losing precision.
+var (
+	bigIntType   = reflect.TypeOf(big.Int{})
+	bigFloatType = reflect.TypeOf(big.Float{})
+)
+
+// converters is the registry of the conversion functions by target type.
+var converters = struct {
+	sync.RWMutex
+	funcs map[reflect.Type]ConvertFunc
+}{
+	funcs: map[reflect.Type]ConvertFunc{
+		bigIntType:   convertBigInt,
+		bigFloatType: convertBigFloat,
+	},
+}
+
+// RegisterConverter adds a conversion function for a target type, such as a
+// third-party decimal type for money fields, which can't use float64. It is
+// used by the APIs which decode a struct from loosely typed data (such as
+// FromMap, FromValues, FromEnv and Layer) and by SetValueFromString, for the
+// fields of that type (and the pointers to it) whenever the given value is not
+// already of the type. A previously registered function for the type is
+// replaced, and a nil function removes it.
+//
+// The math/big.Int and math/big.Float types are converted by default from
+// strings (such as "123456789012345678901234567890" or "0.1") and from the
+// native numbers, without going through float64.
+//
+// For example:
+//
+//	attr.RegisterConverter(reflect.TypeOf(decimal.Decimal{}), func(value interface{}) (interface{}, error) {
+//		return decimal.NewFromString(fmt.Sprint(value))
+//	})
+func RegisterConverter(typ reflect.Type, fn ConvertFunc) {
+	converters.Lock()
+	defer converters.Unlock()
+	if fn == nil {
+		delete(converters.funcs, typ)
+		return
+	}
+	converters.funcs[typ] = fn
+}
+
+// converterFor returns the conversion function registered for a type, or nil
+// if there is none.
+func converterFor(typ reflect.Type) ConvertFunc {
+	converters.RLock()
+	defer converters.RUnlock()
+	return converters.funcs[typ]
+}
+
+// applyConverter converts a value with the function registered for a type. It
+// returns false if there is no such function, and ErrMismatchValue if the
+// function returns a value of another type.
+func applyConverter(value interface{}, typ reflect.Type) (reflect.Value, bool, error) {
+	fn := converterFor(typ)
+	if fn == nil {
+		return reflect.Value{}, false, nil
+	}
+
+	converted, err := fn(value)
+	if err != nil {
+		return reflect.Value{}, true, err
+	}
+	if reflect.TypeOf(converted) != typ {
+		return reflect.Value{}, true, ErrMismatchValue
+	}
+	return reflect.ValueOf(converted), true, nil
+}
+
+// convertBigInt converts a string or a number to a big.Int. A float must have
+// no fractional part, else an *OverflowError is returned.
+func convertBigInt(value interface{}) (interface{}, error) {
+	rv := reflect.ValueOf(value)
+	n := new(big.Int)
+	switch kind := rv.Kind(); {
+	case kind == reflect.String:
+		if _, ok := n.SetString(rv.String(), 0); !ok {
+			return nil, ErrInvalidValue
+		}
+
+	case kind >= reflect.Int && kind <= reflect.Int64:
+		n.SetInt64(rv.Int())
+
+	case kind >= reflect.Uint && kind <= reflect.Uintptr:
+		n.SetUint64(rv.Uint())
+
+	case isFloat(kind):
+		f := rv.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) || f != math.Trunc(f) {
+			return nil, &OverflowError{value, bigIntType}
+		}
+		big.NewFloat(f).Int(n)
+
+	default:
+		return nil, ErrMismatchValue
+	}
+	return *n, nil
+}
+
+// convertBigFloat converts a string or a number to a big.Float. A string is
+// parsed with enough precision for all its digits, and at least the 64 bits
+// of a float64.
+func convertBigFloat(value interface{}) (interface{}, error) {
+	rv := reflect.ValueOf(value)
+	f := new(big.Float)
+	switch kind := rv.Kind(); {
+	case kind == reflect.String:
+		text := rv.String()
+		f.SetPrec(uint(max(64, 4*len(text))))
+		if _, ok := f.SetString(text); !ok {
+			return nil, ErrInvalidValue
+		}
+
+	case kind >= reflect.Int && kind <= reflect.Int64:
+		f.SetInt64(rv.Int())
+
+	case kind >= reflect.Uint && kind <= reflect.Uintptr:
+		f.SetUint64(rv.Uint())
+
+	case isFloat(kind):
+		if math.IsNaN(rv.Float()) {
+			return nil, ErrInvalidValue
+		}
+		f.SetFloat64(rv.Float())
+
+	default:
+		return nil, ErrMismatchValue
+	}
+	return *f, nil
+}
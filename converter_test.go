@@ -0,0 +1,121 @@
+package attr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Cents is a fixed point amount of money, like a third-party decimal type.
+type Cents struct {
+	Units int64
+}
+
+type Ledger struct {
+	Total   *big.Int
+	Count   big.Int
+	Rate    *big.Float
+	Amount  Cents
+	Refunds []*big.Int
+}
+
+func TestBigNumbers(t *testing.T) {
+	var ledger Ledger
+	err := FromMap(&ledger, map[string]interface{}{
+		"Total":   "123456789012345678901234567890",
+		"Count":   int64(42),
+		"Rate":    "0.1000000000000000000000000001",
+		"Refunds": []interface{}{1, json.Number("99999999999999999999"), 2.0},
+	}, "")
+	require.NoError(t, err)
+	require.Equal(t, "123456789012345678901234567890", ledger.Total.String())
+	require.Equal(t, int64(42), ledger.Count.Int64())
+	require.Equal(t, "0.1000000000000000000000000001", ledger.Rate.Text('f', 28))
+	require.Equal(t, "99999999999999999999", ledger.Refunds[1].String())
+	require.Equal(t, int64(2), ledger.Refunds[2].Int64())
+
+	err = FromMap(&ledger, map[string]interface{}{"Rate": 2.5, "Count": uint8(7)}, "")
+	require.NoError(t, err)
+	require.Equal(t, "2.5", ledger.Rate.String())
+	require.Equal(t, int64(7), ledger.Count.Int64())
+
+	// A big number is set as it is.
+	total := big.NewInt(5)
+	require.NoError(t, FromMap(&ledger, map[string]interface{}{"Total": total}, ""))
+	require.Equal(t, total, ledger.Total)
+
+	require.NoError(t, SetValueFromString(&ledger, "Count", "0x10"))
+	require.Equal(t, int64(16), ledger.Count.Int64())
+
+	err = FromMap(&ledger, map[string]interface{}{"Total": 1.5}, "")
+	var overflow *OverflowError
+	require.True(t, errors.As(err, &overflow), "A fractional number was set to big.Int")
+
+	err = FromMap(&ledger, map[string]interface{}{"Total": "12abc"}, "")
+	require.True(t, errors.Is(err, ErrInvalidValue))
+
+	err = FromMap(&ledger, map[string]interface{}{"Rate": true}, "")
+	require.True(t, errors.Is(err, ErrMismatchValue))
+}
+
+func TestRegisterConverter(t *testing.T) {
+	centsType := reflect.TypeOf(Cents{})
+	RegisterConverter(centsType, func(value interface{}) (interface{}, error) {
+		units, fraction, _ := strings.Cut(fmt.Sprint(value), ".")
+		fraction = (fraction + "00")[:2]
+		cents, err := strconv.ParseInt(units+fraction, 10, 64)
+		if err != nil {
+			return nil, ErrInvalidValue
+		}
+		return Cents{cents}, nil
+	})
+	defer RegisterConverter(centsType, nil)
+
+	var ledger Ledger
+	require.NoError(t, FromMap(&ledger, map[string]interface{}{"Amount": "19.9"}, ""))
+	require.Equal(t, Cents{1990}, ledger.Amount)
+
+	require.NoError(t, FromMap(&ledger, map[string]interface{}{"Amount": json.Number("5")}, ""))
+	require.Equal(t, Cents{500}, ledger.Amount)
+
+	require.NoError(t, SetValueFromString(&ledger, "Amount", "0.05"))
+	require.Equal(t, Cents{5}, ledger.Amount)
+
+	err := FromMap(&ledger, map[string]interface{}{"Amount": "ten"}, "")
+	require.Equal(t, &FieldError{"Amount", ErrInvalidValue}, err)
+
+	// The converter must return a value of its type.
+	RegisterConverter(centsType, func(value interface{}) (interface{}, error) {
+		return 1, nil
+	})
+	err = SetValueFromString(&ledger, "Amount", "1")
+	require.Equal(t, ErrMismatchValue, err)
+
+	RegisterConverter(centsType, nil)
+	err = SetValueFromString(&ledger, "Amount", "1")
+	require.Equal(t, ErrMismatchValue, err)
+}
+
+func ExampleRegisterConverter() {
+	type Account struct {
+		Balance *big.Int
+	}
+
+	var account Account
+	err := FromMap(&account, map[string]interface{}{"Balance": "123456789012345678901234567890"}, "")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(account.Balance)
+
+	// Output:
+	// 123456789012345678901234567890
+}
@@ -0,0 +1,90 @@
+package attr
+
+import (
+	"context"
+	"reflect"
+)
+
+// tracker follows the nesting of the structs visited by a recursive API, to
+// enforce the WithMaxDepth option, to detect the cycles of pointers and to
+// stop on the cancellation of the WithContext option.
+type tracker struct {
+	ctx      context.Context
+	maxDepth int
+	depth    int
+	// active holds the pointers to the structs being visited, from the top
+	// level struct down to the current one.
+	active map[visitKey]bool
+	// types counts the struct types being visited.
+	types map[reflect.Type]int
+}
+
+// newTracker returns a tracker for the given options. The top level struct
+// must be entered before visiting its fields.
+func newTracker(o *options) *tracker {
+	return &tracker{
+		ctx:      o.ctx,
+		maxDepth: o.maxDepth,
+		active:   map[visitKey]bool{},
+		types:    map[reflect.Type]int{},
+	}
+}
+
+// canDescend returns true if the fields of a struct nested in the current one
+// are within the max depth, and the context is not done.
+func (t *tracker) canDescend() bool {
+	return (t.maxDepth <= 0 || t.depth < t.maxDepth) && t.err() == nil
+}
+
+// err returns the error of the context given by the WithContext option, such
+// as context.Canceled, or nil if it is not done.
+func (t *tracker) err() error {
+	if t.ctx == nil {
+		return nil
+	}
+	return t.ctx.Err()
+}
+
+// enter records that the struct held by 'value', or pointed to by it, is
+// being visited. Returns ErrCycleDetected if the pointer is already being
+// visited by one of the enclosing structs.
+func (t *tracker) enter(value reflect.Value) error {
+	if value.Kind() == reflect.Ptr {
+		key := visitKey{value.Pointer(), value.Type()}
+		if t.active[key] {
+			return ErrCycleDetected
+		}
+		t.active[key] = true
+	}
+
+	t.types[structType(value.Type())]++
+	t.depth++
+	return nil
+}
+
+// leave undoes the matching call to enter.
+func (t *tracker) leave(value reflect.Value) {
+	if value.Kind() == reflect.Ptr {
+		delete(t.active, visitKey{value.Pointer(), value.Type()})
+	}
+
+	typ := structType(value.Type())
+	if t.types[typ]--; t.types[typ] == 0 {
+		delete(t.types, typ)
+	}
+	t.depth--
+}
+
+// hasType returns true if a struct of the given type, or the type pointed to
+// by it, is being visited.
+func (t *tracker) hasType(typ reflect.Type) bool {
+	return t.types[structType(typ)] > 0
+}
+
+// structType returns the given type, or the type pointed to by it.
+func structType(typ reflect.Type) reflect.Type {
+	if typ.Kind() == reflect.Ptr {
+		return typ.Elem()
+	}
+	return typ
+}
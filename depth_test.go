@@ -0,0 +1,162 @@
+package attr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Chain struct {
+	Name string
+	Next *Chain
+}
+
+func TestWithMaxDepth(t *testing.T) {
+	chain := Chain{"a", &Chain{"b", &Chain{"c", nil}}}
+
+	values, err := ToValues(&chain, "url")
+	require.Nil(t, err)
+	require.Equal(t, url.Values{"Name": {"a"}, "Next.Name": {"b"}, "Next.Next.Name": {"c"}},
+		values, "All the nested structs are not converted")
+
+	values, err = ToValues(&chain, "url", WithMaxDepth(2))
+	require.Nil(t, err)
+	require.Equal(t, url.Values{"Name": {"a"}, "Next.Name": {"b"}}, values,
+		"Nested structs beyond the max depth are converted")
+
+	env, err := ToEnv(chain, "", WithMaxDepth(1))
+	require.Nil(t, err)
+	require.Equal(t, []string{"NAME=a"}, env, "Nested structs beyond the max depth are converted")
+
+	paths, err := FieldsEqual(&chain, "c", WithMaxDepth(2))
+	require.Nil(t, err)
+	require.Empty(t, paths, "Nested structs beyond the max depth are searched")
+
+	err = TransformStrings(&chain, func(s string) string { return s + s }, WithMaxDepth(2))
+	require.Nil(t, err)
+	require.Equal(t, Chain{"aa", &Chain{"bb", &Chain{"c", nil}}}, chain,
+		"Nested structs beyond the max depth are transformed")
+}
+
+func TestCycleDetected(t *testing.T) {
+	chain := Chain{Name: "a"}
+	chain.Next = &Chain{"b", &chain}
+
+	_, err := ToValues(&chain, "url")
+	require.Equal(t, ErrCycleDetected, err, "Cycle not detected by ToValues")
+
+	_, err = ToEnv(&chain, "")
+	require.Equal(t, ErrCycleDetected, err, "Cycle not detected by ToEnv")
+
+	err = Layer(&chain, MapSource(map[string]interface{}{"Name": "x"}, ""))
+	require.True(t, errors.Is(err, ErrCycleDetected), "Cycle not detected by Layer")
+
+	_, err = FieldsEqual(&chain, "b")
+	require.Equal(t, ErrCycleDetected, err, "Cycle not detected by FindFields")
+
+	err = TransformStrings(&chain, strings.ToUpper)
+	require.Equal(t, ErrCycleDetected, err, "Cycle not detected by TransformStrings")
+
+	// A struct shared without a cycle is searched under each path, but is
+	// transformed only once.
+	shared := &Chain{Name: "b"}
+	pair := struct{ Left, Right *Chain }{shared, shared}
+	paths, err := FieldsEqual(&pair, "b")
+	require.Nil(t, err)
+	require.Equal(t, []string{"Left.Name", "Right.Name"}, paths, "Shared struct not searched correctly")
+	require.Nil(t, TransformStrings(&pair, func(s string) string { return s + s }))
+	require.Equal(t, "bb", shared.Name, "Shared struct not transformed once")
+
+	// A nil pointer of a recursive type is not allocated.
+	fresh := Chain{}
+	err = Layer(&fresh, MapSource(map[string]interface{}{"Name": "x"}, ""))
+	require.Nil(t, err)
+	require.Equal(t, Chain{Name: "x"}, fresh, "Recursive struct not set correctly")
+}
+
+// countdownContext is a context which is done after its Err method is called a
+// given number of times, to cancel an API in the middle of a call.
+type countdownContext struct {
+	context.Context
+	calls int
+}
+
+func (c *countdownContext) Err() error {
+	if c.calls--; c.calls < 0 {
+		return context.Canceled
+	}
+	return nil
+}
+
+func TestWithContext(t *testing.T) {
+	chain := Chain{"a", &Chain{"b", &Chain{"c", nil}}}
+
+	values, err := ToValues(&chain, "url", WithContext(context.Background()))
+	require.Nil(t, err)
+	require.Len(t, values, 3, "All the nested structs are not converted")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	visited := []string{}
+	_, err = FindFields(&chain, func(path string, _ interface{}) bool {
+		visited = append(visited, path)
+		if path == "Next.Name" {
+			cancel()
+		}
+		return false
+	}, WithContext(ctx))
+	require.Equal(t, context.Canceled, err, "Search is not canceled")
+	require.Equal(t, []string{"Name", "Next", "Next.Name", "Next.Next"}, visited,
+		"Nested structs are searched after the cancellation")
+
+	_, err = ToValues(&chain, "url", WithContext(ctx))
+	require.Equal(t, context.Canceled, err)
+	_, err = ToEnv(&chain, "APP", WithContext(ctx))
+	require.Equal(t, context.Canceled, err)
+	_, err = CheckRequired(&chain, WithContext(ctx))
+	require.Equal(t, context.Canceled, err)
+	_, err = Diff(chain, *chain.Next, WithContext(ctx))
+	require.Equal(t, context.Canceled, err)
+
+	err = TransformStrings(&chain, func(s string) string { return s + "!" }, WithContext(ctx))
+	require.Equal(t, context.Canceled, err)
+	require.Equal(t, "a", chain.Name, "Struct is transformed after the cancellation")
+
+	err = FromMap(&chain, map[string]interface{}{"Name": "z"}, "", WithContext(ctx))
+	require.True(t, errors.Is(err, context.Canceled), "Struct is set after the cancellation")
+	require.Equal(t, "a", chain.Name, "Struct is set after the cancellation")
+
+	// A large field is not copied to the end once the context is done.
+	type Log struct {
+		Lines []string
+	}
+	log := Log{Lines: make([]string, 1000)}
+	countdown := &countdownContext{context.Background(), 10}
+	_, err = Diff(log, log, WithContext(countdown))
+	require.Equal(t, context.Canceled, err, "Copy of a large field is not canceled")
+}
+
+func ExampleWithMaxDepth() {
+	// type Chain struct {
+	// 	Name string
+	// 	Next *Chain
+	// }
+	chain := Chain{"a", &Chain{"b", &Chain{"c", nil}}}
+
+	env, err := ToEnv(&chain, "", WithMaxDepth(2))
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Println(env)
+
+	chain.Next.Next.Next = &chain
+	_, err = ToEnv(&chain, "")
+	fmt.Println(err)
+	// Output:
+	// [NAME=a NEXT_NAME=b]
+	// Specified struct references itself through a pointer
+}
@@ -0,0 +1,78 @@
+package attr
+
+import "encoding/json"
+
+// FieldInfo describes a single field of a struct, as returned by Describe. It
+// is tagged to be serialized with lower case keys, such as by DescribeJSON.
+type FieldInfo struct {
+	Name     string            `json:"name" yaml:"name"`
+	Type     string            `json:"type" yaml:"type"`
+	Kind     string            `json:"kind" yaml:"kind"`
+	Tags     map[string]string `json:"tags" yaml:"tags"`
+	Exported bool              `json:"exported" yaml:"exported"`
+	Index    int               `json:"index" yaml:"index"`
+	Offset   uintptr           `json:"offset" yaml:"offset"`
+	Embedded bool              `json:"embedded" yaml:"embedded"`
+}
+
+// StructInfo describes a struct and all its fields, as returned by Describe.
+// Name is empty for an anonymous struct type (such as struct{ A int }), whose
+// Type is its literal, such as "struct { A int }".
+type StructInfo struct {
+	Name   string      `json:"name" yaml:"name"`
+	Type   string      `json:"type" yaml:"type"`
+	Size   uintptr     `json:"size" yaml:"size"`
+	Fields []FieldInfo `json:"fields" yaml:"fields"`
+}
+
+// Describe returns the complete description of a struct, including both the
+// exported (public) and unexported fields, in their declaration order. Each
+// field is described with its type, kind, parsed tags, export status, index,
+// byte offset and whether it is an embedded field.
+// 'obj' can be passed by value or by pointer.
+func Describe(obj interface{}) (StructInfo, error) {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return StructInfo{}, err
+	}
+
+	objType := objValue.Type()
+	info := StructInfo{
+		Name:   objType.Name(),
+		Type:   objType.String(),
+		Size:   objType.Size(),
+		Fields: []FieldInfo{},
+	}
+
+	for i := 0; i < objType.NumField(); i++ {
+		fieldType := objType.Field(i)
+
+		// A malformed tag is described with the pairs parsed before the
+		// error. Use CheckTags to find such tags.
+		tags, _ := ParseTag(fieldType.Tag)
+
+		info.Fields = append(info.Fields, FieldInfo{
+			Name:     fieldType.Name,
+			Type:     fieldType.Type.String(),
+			Kind:     fieldType.Type.Kind().String(),
+			Tags:     tags,
+			Exported: fieldType.PkgPath == "",
+			Index:    i,
+			Offset:   fieldType.Offset,
+			Embedded: fieldType.Anonymous,
+		})
+	}
+
+	return info, nil
+}
+
+// DescribeJSON returns the description of a struct given by Describe as an
+// indented JSON document, such as for the documentation generators and the
+// other tools which consume the struct metadata without linking Go code.
+func DescribeJSON(obj interface{}) ([]byte, error) {
+	info, err := Describe(obj)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(info, "", "  ")
+}
@@ -0,0 +1,121 @@
+package attr
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Manager struct {
+	User
+	Reports []*User `json:"reports"`
+}
+
+func TestDescribe(t *testing.T) {
+	want := StructInfo{
+		Name: "User",
+		Type: "attr.User",
+		Size: unsafe.Sizeof(user),
+		Fields: []FieldInfo{
+			{"Username", "string", "string", map[string]string{"json": "username", "db": "uname"},
+				true, 0, unsafe.Offsetof(user.Username), false},
+			{"Age", "int", "int", map[string]string{"json": "age", "meta": "important"},
+				true, 1, unsafe.Offsetof(user.Age), false},
+			{"password", "string", "string", map[string]string{},
+				false, 2, unsafe.Offsetof(user.password), false},
+		},
+	}
+	got, err := Describe(&user)
+	require.Nil(t, err)
+	require.Equal(t, want, got, "Struct description is not correct")
+
+	got, err = Describe(Manager{})
+	require.Nil(t, err)
+	require.True(t, got.Fields[0].Embedded, "Embedded field not reported")
+	require.Equal(t, "[]*attr.User", got.Fields[1].Type, "Field type is not correct")
+	require.Equal(t, "slice", got.Fields[1].Kind, "Field kind is not correct")
+
+	_, err = Describe(nil)
+	require.Equal(t, ErrNotStruct, err, "Able to describe a non-struct")
+}
+
+func ExampleDescribe() {
+	testUser := User{Username: "srathi", password: "secret", Age: 30}
+
+	info, err := Describe(&testUser)
+	if err != nil {
+		// Handle error.
+	}
+	for _, field := range info.Fields {
+		fmt.Printf("%s %s exported=%v tags=%v\n", field.Name, field.Type, field.Exported, field.Tags)
+	}
+	// Output:
+	// Username string exported=true tags=map[db:uname json:username]
+	// Age int exported=true tags=map[json:age meta:important]
+	// password string exported=false tags=map[]
+}
+
+func TestDescribeJSON(t *testing.T) {
+	got, err := DescribeJSON(Address{})
+	require.Nil(t, err)
+
+	var info StructInfo
+	require.Nil(t, json.Unmarshal(got, &info))
+	want, _ := Describe(Address{})
+	require.Equal(t, want, info, "Description is not preserved in JSON")
+
+	var raw map[string]interface{}
+	require.Nil(t, json.Unmarshal(got, &raw))
+	require.Equal(t, "attr.Address", raw["type"], "JSON keys are not correct")
+
+	_, err = DescribeJSON("abc")
+	require.Equal(t, ErrNotStruct, err, "Able to describe a non-struct")
+}
+
+func ExampleDescribeJSON() {
+	type Point struct {
+		X int32 `json:"x"`
+		Y int32 `json:"y"`
+	}
+
+	doc, err := DescribeJSON(Point{})
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Println(string(doc))
+	// Output:
+	// {
+	//   "name": "Point",
+	//   "type": "attr.Point",
+	//   "size": 8,
+	//   "fields": [
+	//     {
+	//       "name": "X",
+	//       "type": "int32",
+	//       "kind": "int32",
+	//       "tags": {
+	//         "json": "x"
+	//       },
+	//       "exported": true,
+	//       "index": 0,
+	//       "offset": 0,
+	//       "embedded": false
+	//     },
+	//     {
+	//       "name": "Y",
+	//       "type": "int32",
+	//       "kind": "int32",
+	//       "tags": {
+	//         "json": "y"
+	//       },
+	//       "exported": true,
+	//       "index": 1,
+	//       "offset": 4,
+	//       "embedded": false
+	//     }
+	//   ]
+	// }
+}
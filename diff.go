@@ -0,0 +1,83 @@
+package attr
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Diff compares the exported (public) fields of two structs of the same type
+// and returns a Change for every field whose value differs, like Watch does
+// between two polls. The fields of the nested structs are compared
+// individually, and the changes are listed in the declaration order of the
+// fields. Use WithFields to compare only some of the fields, and
+// RegisterComparer to customize how the values of a type are compared, and
+// WithContext to stop comparing once a context is done, which is checked while
+// copying the field values too.
+//
+// Both 'a' and 'b' can be passed by value or by pointer. ErrMismatchValue is
+// returned if they are of different types.
+func Diff(a, b interface{}, opts ...Option) ([]Change, error) {
+	aValue, err := getReflectValue(a)
+	if err != nil {
+		return nil, err
+	}
+
+	bValue, err := getReflectValue(b)
+	if err != nil {
+		return nil, err
+	}
+
+	if aValue.Type() != bValue.Type() {
+		return nil, ErrMismatchValue
+	}
+
+	o := newOptions(opts)
+	t := newTracker(o)
+	old, new := map[string]interface{}{}, map[string]interface{}{}
+	snapshotFields(aValue, "", old, t)
+	if err := t.err(); err != nil {
+		return nil, err
+	}
+	snapshotFields(bValue, "", new, t)
+	if err := t.err(); err != nil {
+		return nil, err
+	}
+
+	changes := []Change{}
+	for _, change := range diffSnapshots(aValue.Type(), "", old, new) {
+		if o.allowsField(change.Field) {
+			changes = append(changes, change)
+		}
+	}
+	return changes, nil
+}
+
+// DiffString returns the changes between two structs found by Diff as a human
+// readable report, with one line per changed field, such as:
+//
+//	Age: 30 → 40
+//	Address.City: "San Jose" → "Fremont"
+//
+// Strings are quoted, and other values are formatted with "%v". An empty
+// string is returned if there is no change.
+func DiffString(a, b interface{}, opts ...Option) (string, error) {
+	changes, err := Diff(a, b, opts...)
+	if err != nil {
+		return "", err
+	}
+
+	var report strings.Builder
+	for _, change := range changes {
+		fmt.Fprintf(&report, "%s: %s → %s\n", change.Field, diffValue(change.Old), diffValue(change.New))
+	}
+	return report.String(), nil
+}
+
+// diffValue formats a field value for DiffString.
+func diffValue(value interface{}) string {
+	if reflect.ValueOf(value).Kind() == reflect.String {
+		return fmt.Sprintf("%q", value)
+	}
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,61 @@
+package attr
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	type Settings struct {
+		Theme   string
+		Size    int
+		Home    Address
+		Aliases []string
+	}
+	a := Settings{Theme: "dark", Size: 10, Home: Address{City: "San Jose"}}
+	b := Settings{Theme: "dark", Size: 12, Home: Address{City: "Fremont"}, Aliases: []string{"x"}}
+
+	got, err := Diff(a, &b)
+	require.Nil(t, err)
+	require.Equal(t, []Change{
+		{"Size", 10, 12},
+		{"Home.City", "San Jose", "Fremont"},
+		{"Aliases", []string(nil), []string{"x"}},
+	}, got, "Changes are not correct")
+
+	got, err = Diff(a, b, WithFields("Home", "Theme"))
+	require.Nil(t, err)
+	require.Equal(t, []Change{{"Home.City", "San Jose", "Fremont"}}, got, "Allowed changes are not correct")
+
+	_, err = Diff(a, user)
+	require.Equal(t, ErrMismatchValue, err, "Able to compare different struct types")
+}
+
+func TestDiffString(t *testing.T) {
+	a := Employee{Name: "srathi", Address: &Address{City: "San Jose"}}
+	b := Employee{Name: "shyam", Address: a.Address, Tags: []string{"go"}}
+	got, err := DiffString(a, b)
+	require.Nil(t, err)
+	require.Equal(t, "Name: \"srathi\" → \"shyam\"\nTags: [] → [go]\n", got, "Diff report is not correct")
+
+	got, err = DiffString(a, a)
+	require.Nil(t, err)
+	require.Equal(t, "", got, "Diff report of equal structs is not empty")
+
+	_, err = DiffString(a, 10)
+	require.Equal(t, ErrNotStruct, err, "Able to compare a non-struct")
+}
+
+func ExampleDiffString() {
+	old := User{Username: "srathi", Age: 30}
+	new := User{Username: "srathi", Age: 40}
+
+	report, err := DiffString(old, new)
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Print(report)
+	// Output: Age: 30 → 40
+}
@@ -0,0 +1,38 @@
+package attr
+
+import (
+	"reflect"
+	"sort"
+)
+
+// Dir returns the sorted names of all the exported (public) fields and methods
+// of a struct, like dir() of Python. The fields promoted from the embedded
+// structs are listed along with the embedded structs themselves, and the
+// methods with both value and pointer receivers are listed, including the
+// promoted ones. 'obj' can be passed by value or by pointer.
+func Dir(obj interface{}) ([]string, error) {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	for _, field := range structFields(objValue, newOptions(nil)) {
+		seen[field.Name] = true
+	}
+	for _, field := range structFields(objValue, newOptions([]Option{WithSquash()})) {
+		seen[field.Name] = true
+	}
+
+	ptrType := reflect.PtrTo(objValue.Type())
+	for i := 0; i < ptrType.NumMethod(); i++ {
+		seen[ptrType.Method(i).Name] = true
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
@@ -0,0 +1,52 @@
+package attr
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Greeter struct {
+	User
+	Greeting string
+	count    int
+}
+
+func (g Greeter) Greet() string {
+	return g.Greeting + ", " + g.Username
+}
+
+func (g *Greeter) SetGreeting(greeting string) {
+	g.Greeting = greeting
+}
+
+func TestDir(t *testing.T) {
+	want := []string{"Age", "Greet", "Greeting", "SetGreeting", "User", "Username"}
+	got, err := Dir(Greeter{})
+	require.Nil(t, err)
+	require.Equal(t, want, got, "Attributes are not correct")
+
+	got, err = Dir(&Greeter{})
+	require.Nil(t, err)
+	require.Equal(t, want, got, "Attributes of a pointer are not correct")
+
+	_, err = Dir(10)
+	require.Equal(t, ErrNotStruct, err, "Able to list the attributes of a non-struct")
+}
+
+func ExampleDir() {
+	// type Greeter struct {
+	// 	User
+	// 	Greeting string
+	// 	count    int
+	// }
+	// func (g Greeter) Greet() string
+	// func (g *Greeter) SetGreeting(greeting string)
+	names, err := Dir(Greeter{})
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Println(names)
+	// Output: [Age Greet Greeting SetGreeting User Username]
+}
@@ -0,0 +1,25 @@
+package attr
+
+// EachValue calls 'fn' with the name and the value of every exported (public)
+// field of a struct, in their declaration order, until 'fn' returns false. It
+// is the streaming form of Values for scanning large structs in tight loops:
+// no map or slice is built, and the exported fields of a struct type are
+// resolved once and cached, so the only allocations are the ones needed to
+// pass the values which don't fit in an interface as is (such as strings).
+// An embedded struct is given as a single field named after its type.
+//
+// 'obj' can be passed by value or by pointer.
+func EachValue(obj interface{}, fn func(name string, value interface{}) bool) error {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return err
+	}
+
+	fields := cachedFields(objValue.Type())
+	for i, index := range fields.indexes {
+		if !fn(fields.names[i], objValue.Field(index).Interface()) {
+			break
+		}
+	}
+	return nil
+}
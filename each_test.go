@@ -0,0 +1,63 @@
+package attr
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEachValue(t *testing.T) {
+	names := []string{}
+	values := []interface{}{}
+	err := EachValue(&user, func(name string, value interface{}) bool {
+		names = append(names, name)
+		values = append(values, value)
+		return true
+	})
+	require.Nil(t, err)
+	require.Equal(t, []string{"Username", "Age"}, names, "Field names are not correct")
+	require.Equal(t, []interface{}{"srathi", 30}, values, "Field values are not correct")
+
+	names = names[:0]
+	err = EachValue(Manager{User: user}, func(name string, _ interface{}) bool {
+		names = append(names, name)
+		return false
+	})
+	require.Nil(t, err)
+	require.Equal(t, []string{"User"}, names, "Iteration is not stopped")
+
+	err = EachValue(42, func(string, interface{}) bool { return true })
+	require.Equal(t, ErrNotStruct, err)
+}
+
+func TestEachValueAllocs(t *testing.T) {
+	age := 30
+	type Refs struct {
+		Age    *int
+		Labels map[string]int
+	}
+	refs := &Refs{Age: &age, Labels: map[string]int{}}
+
+	count := 0
+	allocs := testing.AllocsPerRun(100, func() {
+		EachValue(refs, func(string, interface{}) bool {
+			count++
+			return true
+		})
+	})
+	require.Zero(t, allocs, "Iteration over pointer fields allocates")
+}
+
+func ExampleEachValue() {
+	err := EachValue(user, func(name string, value interface{}) bool {
+		fmt.Printf("%s=%v\n", name, value)
+		return true
+	})
+	if err != nil {
+		// Handle error.
+	}
+	// Output:
+	// Username=srathi
+	// Age=30
+}
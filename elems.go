@@ -0,0 +1,79 @@
+package attr
+
+import "reflect"
+
+// FieldLen returns the length of a slice, array, map, string or channel field
+// of a struct, which can be passed by value or by pointer. The field can be a
+// dot separated path for a field of a nested struct, with optional indexes
+// such as "Owners[0].Tags". The length of a nil slice or map is 0.
+//
+// ErrNotSlice is returned if the field has no length, such as an int field.
+func FieldLen(obj interface{}, path string) (int, error) {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return 0, err
+	}
+
+	fieldValue, err := getFieldByPath(objValue, path, false)
+	if err != nil {
+		return 0, err
+	}
+
+	switch fieldValue.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.String, reflect.Chan:
+		return fieldValue.Len(), nil
+	case reflect.Ptr:
+		if fieldValue.Type().Elem().Kind() == reflect.Array {
+			return fieldValue.Type().Elem().Len(), nil
+		}
+	}
+	return 0, ErrNotSlice
+}
+
+// AppendField appends the given values to a slice field of a struct, like the
+// built-in append. The field can be a path like FieldLen. The values must be
+// of the element type of the slice (or nil for a pointer, slice, map or
+// interface element type), else ErrMismatchValue is returned and the field is
+// left unchanged. An array field can't grow, so ErrFixedSize is
+// returned for it, and ErrNotSlice for a field which isn't a slice.
+//
+// NOTE: 'obj' struct must be passed by pointer for this API to work. Passing by
+// value results in ErrNotPtr.
+func AppendField(obj interface{}, path string, values ...interface{}) error {
+	objValue, err := getStructByPtr(obj)
+	if err != nil {
+		return err
+	}
+
+	fieldValue, err := getFieldByPath(objValue, path, false)
+	if err != nil {
+		return err
+	}
+
+	switch fieldValue.Kind() {
+	case reflect.Slice:
+	case reflect.Array:
+		return ErrFixedSize
+	default:
+		return ErrNotSlice
+	}
+
+	elemType := fieldValue.Type().Elem()
+	elems := make([]reflect.Value, len(values))
+	for i, value := range values {
+		if value == nil && isNillable(elemType.Kind()) {
+			elems[i] = reflect.Zero(elemType)
+			continue
+		}
+
+		elemValue := reflect.ValueOf(value)
+		if !elemValue.IsValid() || (elemValue.Type() != elemType && elemType.Kind() != reflect.Interface) ||
+			!elemValue.Type().AssignableTo(elemType) {
+			return ErrMismatchValue
+		}
+		elems[i] = elemValue.Convert(elemType)
+	}
+
+	fieldValue.Set(reflect.Append(fieldValue, elems...))
+	return nil
+}
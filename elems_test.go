@@ -0,0 +1,132 @@
+package attr
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Matrix struct {
+	Name  string
+	Cells [2][3]int
+	Tags  []string
+	Slots [2]*Address
+	Index map[string]int
+	Ref   *[4]byte
+}
+
+func TestPathIndexes(t *testing.T) {
+	matrix := Matrix{
+		Cells: [2][3]int{{1, 2, 3}, {4, 5, 6}},
+		Tags:  []string{"a", "b"},
+		Slots: [2]*Address{{City: "San Jose"}},
+	}
+
+	got, found := GetOk(matrix, "Cells[1][2]")
+	require.True(t, found)
+	require.Equal(t, 6, got, "Element of a nested array is not correct")
+
+	city, found := TryGet[string](&matrix, "Slots[0].City")
+	require.True(t, found)
+	require.Equal(t, "San Jose", city, "Field of an array element is not correct")
+
+	for _, test := range []struct {
+		path    string
+		wantErr error
+	}{
+		{"Tags[2]", ErrOutOfRange},
+		{"Name[0]", ErrNotSlice},
+		{"Tags[-1]", ErrInvalidExpr},
+		{"Tags[x]", ErrInvalidExpr},
+		{"Tags[0", ErrInvalidExpr},
+		{"Tags[99999999999999999999]", ErrInvalidExpr},
+		{"Slots[1].City", &NilPathError{"Slots[1]", 1}},
+	} {
+		_, _, err := RawField(&matrix, test.path)
+		require.Equal(t, test.wantErr, err, "Error of path %q is not correct", test.path)
+	}
+
+	field, value, err := RawField(&matrix, "Tags[1]")
+	require.Nil(t, err)
+	require.Equal(t, "Tags", field.Name, "Field of an element is not correct")
+	require.Equal(t, "b", value.Interface(), "Element value is not correct")
+
+	require.Nil(t, SetPaths(&matrix, map[string]interface{}{"Cells[0][1]": 20, "Tags[1]": "z"}))
+	require.Equal(t, [3]int{1, 20, 3}, matrix.Cells[0], "Array element not set")
+	require.Equal(t, []string{"a", "z"}, matrix.Tags, "Slice element not set")
+}
+
+func TestFieldLen(t *testing.T) {
+	matrix := Matrix{Tags: []string{"a"}, Index: map[string]int{"a": 1, "b": 2}}
+	for path, want := range map[string]int{
+		"Cells": 2, "Cells[0]": 3, "Tags": 1, "Slots": 2, "Index": 2, "Name": 0, "Ref": 4,
+	} {
+		got, err := FieldLen(&matrix, path)
+		require.Nil(t, err)
+		require.Equal(t, want, got, "Length of %s is not correct", path)
+	}
+
+	_, err := FieldLen(matrix, "Cells[0][0]")
+	require.Equal(t, ErrNotSlice, err, "Able to get the length of an int")
+
+	_, err = FieldLen(matrix, "ABC")
+	require.Equal(t, ErrNoField, err, "Able to get the length of a missing field")
+}
+
+func TestAppendField(t *testing.T) {
+	var employee Employee
+	require.Nil(t, AppendField(&employee, "Tags", "a", "b"))
+	require.Equal(t, []string{"a", "b"}, employee.Tags, "Values not appended")
+
+	require.Equal(t, ErrMismatchValue, AppendField(&employee, "Tags", "c", 1), "Able to append a wrong type")
+	require.Equal(t, []string{"a", "b"}, employee.Tags, "Field changed on an error")
+
+	var matrix Matrix
+	require.Nil(t, AppendField(&matrix, "Tags", "x"))
+	require.Equal(t, ErrFixedSize, AppendField(&matrix, "Cells", [3]int{}), "Able to append to an array")
+	require.Equal(t, ErrNotSlice, AppendField(&matrix, "Name", "x"), "Able to append to a string")
+	require.Equal(t, ErrNotPtr, AppendField(matrix, "Tags", "x"), "Able to append to a struct passed by value")
+
+	var holder struct{ Items []interface{} }
+	require.Nil(t, AppendField(&holder, "Items", 1, "a", nil))
+	require.Equal(t, []interface{}{1, "a", nil}, holder.Items, "Values not appended to an interface slice")
+}
+
+func TestFromMapArrays(t *testing.T) {
+	type Target struct {
+		RGB   [3]uint8
+		Pair  [2]string
+		Ports [2]int
+	}
+
+	var target Target
+	values := map[string]interface{}{"RGB": []interface{}{255, 128.0}, "Pair": "a, b", "Ports": [2]int{80, 443}}
+	require.Nil(t, FromMap(&target, values, "json"))
+	require.Equal(t, Target{RGB: [3]uint8{255, 128, 0}, Pair: [2]string{"a", "b"}, Ports: [2]int{80, 443}},
+		target, "Array fields not set")
+
+	err := FromMap(&target, map[string]interface{}{"Pair": []string{"a", "b", "c"}}, "json")
+	require.Equal(t, &FieldError{"Pair", ErrFixedSize}, err, "Able to set too many array elements")
+
+	require.Nil(t, FromMap(&target, map[string]interface{}{"Ports": 8080}, "json", WithWeakTypes()))
+	require.Equal(t, [2]int{8080, 0}, target.Ports, "Single value not set to an array")
+}
+
+func ExampleAppendField() {
+	type Playlist struct {
+		Songs []string
+		Top   [3]string
+	}
+	playlist := Playlist{Songs: []string{"intro"}}
+
+	if err := AppendField(&playlist, "Songs", "verse", "chorus"); err != nil {
+		// Handle error.
+	}
+	size, err := FieldLen(playlist, "Songs")
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Println(size, AppendField(&playlist, "Top", "hit"))
+	// Output: 3 Specified field is a fixed size array
+}
@@ -0,0 +1,106 @@
+package attr
+
+import (
+	"encoding/base64"
+	"reflect"
+)
+
+// Cipher encrypts and decrypts the field values for EncryptFields and
+// DecryptFields. It is implemented by the caller, such as with AES-GCM and a
+// key from a key management service.
+type Cipher interface {
+	Encrypt(plaintext []byte) ([]byte, error)
+	Decrypt(ciphertext []byte) ([]byte, error)
+}
+
+// EncryptFields encrypts the values of the exported (public) string and []byte
+// fields of a struct tagged with `encrypt:"true"`, using the given Cipher. A
+// string field gets the base64 (standard encoding) of its ciphertext, and a
+// []byte field gets the raw ciphertext. Empty values are kept empty.
+//
+// The nested structs, including the ones referenced by non-nil pointers, held
+// by interfaces and the elements of slices, arrays and maps, are encrypted too.
+// All the values are encrypted before setting any of them, so the struct is
+// not modified if an error is returned. ErrInvalidTag is returned if the tag
+// is on a field of another type, and an error of the Cipher is returned as a
+// *FieldError with the path of the field. A struct with "encrypt" tags held by
+// value in a map or an interface can't be modified in place, so a *FieldError
+// with ErrNotPtr is returned for it (hold a pointer to it instead).
+//
+// NOTE: 'obj' struct must be passed by pointer for this API to work. Passing by
+// value results in ErrNotPtr.
+func EncryptFields(obj interface{}, cipher Cipher) error {
+	return cryptFields(obj, func(fieldValue reflect.Value) (reflect.Value, error) {
+		if fieldValue.Kind() != reflect.String {
+			ciphertext, err := cipher.Encrypt(fieldValue.Bytes())
+			return reflect.ValueOf(ciphertext), err
+		}
+
+		ciphertext, err := cipher.Encrypt([]byte(fieldValue.String()))
+		return reflect.ValueOf(base64.StdEncoding.EncodeToString(ciphertext)), err
+	})
+}
+
+// DecryptFields decrypts the values of the fields encrypted by EncryptFields,
+// using the given Cipher. ErrInvalidValue is returned as a *FieldError if a
+// string field is not base64 encoded. See EncryptFields for more details.
+//
+// NOTE: 'obj' struct must be passed by pointer for this API to work. Passing by
+// value results in ErrNotPtr.
+func DecryptFields(obj interface{}, cipher Cipher) error {
+	return cryptFields(obj, func(fieldValue reflect.Value) (reflect.Value, error) {
+		if fieldValue.Kind() != reflect.String {
+			plaintext, err := cipher.Decrypt(fieldValue.Bytes())
+			return reflect.ValueOf(plaintext), err
+		}
+
+		ciphertext, err := base64.StdEncoding.DecodeString(fieldValue.String())
+		if err != nil {
+			return reflect.Value{}, ErrInvalidValue
+		}
+		plaintext, err := cipher.Decrypt(ciphertext)
+		return reflect.ValueOf(string(plaintext)), err
+	})
+}
+
+// cryptFields replaces the values of the fields of a struct tagged with
+// `encrypt:"true"` with the values returned by 'crypt', once all of them are
+// returned without an error.
+func cryptFields(obj interface{}, crypt func(reflect.Value) (reflect.Value, error)) error {
+	objValue, err := getStructByPtr(obj)
+	if err != nil {
+		return err
+	}
+
+	err = checkTypeTags(objValue.Type(), "encrypt", map[reflect.Type]bool{},
+		func(tag string, fieldType reflect.Type) bool {
+			return tag != "true" || isStringOrBytes(fieldType)
+		})
+	if err != nil {
+		return err
+	}
+
+	fieldValues, newValues := []reflect.Value{}, []reflect.Value{}
+	err = taggedFields(objValue, "encrypt", "", map[visitKey]bool{},
+		func(path, tag string, fieldValue reflect.Value) error {
+			if tag != "true" || fieldValue.Len() == 0 {
+				return nil
+			}
+
+			newValue, err := crypt(fieldValue)
+			if err != nil {
+				return &FieldError{path, err}
+			}
+			fieldValues = append(fieldValues, fieldValue)
+			newValues = append(newValues, newValue.Convert(fieldValue.Type()))
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+
+	for i, fieldValue := range fieldValues {
+		fieldValue.Set(newValues[i])
+	}
+	return nil
+}
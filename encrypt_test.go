@@ -0,0 +1,122 @@
+package attr
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// xorCipher is a toy Cipher which XORs every byte with a key byte.
+type xorCipher struct {
+	key byte
+}
+
+func (c xorCipher) Encrypt(plaintext []byte) ([]byte, error) {
+	if bytes.Contains(plaintext, []byte("fail")) {
+		return nil, errors.New("cipher failure")
+	}
+	return c.xor(plaintext), nil
+}
+
+func (c xorCipher) Decrypt(ciphertext []byte) ([]byte, error) {
+	return c.xor(ciphertext), nil
+}
+
+func (c xorCipher) xor(data []byte) []byte {
+	out := make([]byte, len(data))
+	for i, b := range data {
+		out[i] = b ^ c.key
+	}
+	return out
+}
+
+type Credentials struct {
+	User     string
+	Password string `encrypt:"true"`
+	Key      []byte `encrypt:"true"`
+	Note     string `encrypt:"false"`
+	Backup   *Credentials
+	Others   []Credentials
+}
+
+func TestEncryptFields(t *testing.T) {
+	cipher := xorCipher{0x5a}
+	creds := Credentials{
+		User:     "srathi",
+		Password: "secret",
+		Key:      []byte{1, 2},
+		Note:     "note",
+		Backup:   &Credentials{Password: "backup"},
+		Others:   []Credentials{{Password: "other"}},
+	}
+	require.Nil(t, EncryptFields(&creds, cipher))
+	require.NotEqual(t, "secret", creds.Password, "Field is not encrypted")
+	require.Equal(t, []byte{1 ^ 0x5a, 2 ^ 0x5a}, creds.Key, "Bytes field is not encrypted")
+	require.Equal(t, "note", creds.Note, "Field tagged false is encrypted")
+	require.NotEqual(t, "backup", creds.Backup.Password, "Field of a nested pointer is not encrypted")
+	require.NotEqual(t, "other", creds.Others[0].Password, "Field of a slice element is not encrypted")
+
+	require.Nil(t, DecryptFields(&creds, cipher))
+	require.Equal(t, "secret", creds.Password, "Field is not decrypted")
+	require.Equal(t, []byte{1, 2}, creds.Key, "Bytes field is not decrypted")
+	require.Equal(t, "backup", creds.Backup.Password, "Field of a nested pointer is not decrypted")
+	require.Equal(t, "other", creds.Others[0].Password, "Field of a slice element is not decrypted")
+
+	creds.Others[0].Password = "fail"
+	err := EncryptFields(&creds, cipher)
+	require.Equal(t, "Others[0].Password: cipher failure", err.Error(), "Cipher error is not correct")
+	require.Equal(t, "secret", creds.Password, "Struct is modified on an error")
+
+	creds.Password = "not base64!"
+	err = DecryptFields(&creds, cipher)
+	require.Equal(t, &FieldError{"Password", ErrInvalidValue}, err, "Invalid ciphertext not reported")
+
+	// A struct held by pointer in a map is encrypted, and one held by value is
+	// rejected without modifying anything.
+	type Vault struct {
+		ByName map[string]*Credentials
+		Extra  interface{}
+	}
+	vault := Vault{ByName: map[string]*Credentials{"db": {Password: "secret"}}}
+	require.Nil(t, EncryptFields(&vault, cipher))
+	require.NotEqual(t, "secret", vault.ByName["db"].Password, "Field of a map value is not encrypted")
+	require.Nil(t, DecryptFields(&vault, cipher))
+	require.Equal(t, "secret", vault.ByName["db"].Password, "Field of a map value is not decrypted")
+
+	vault.Extra = map[string]Credentials{"api": {Password: "key"}}
+	err = EncryptFields(&vault, cipher)
+	require.Equal(t, &FieldError{"Extra[api]", ErrNotPtr}, err, "Able to encrypt a struct held by value in a map")
+	require.Equal(t, "secret", vault.ByName["db"].Password, "Struct is modified on an error")
+
+	type Bad struct {
+		PIN int `encrypt:"true"`
+	}
+	require.Equal(t, ErrInvalidTag, EncryptFields(&Bad{}, cipher), "Tag on an int not reported")
+	require.Equal(t, ErrNotPtr, EncryptFields(creds, cipher), "Able to encrypt a struct passed by value")
+}
+
+func ExampleEncryptFields() {
+	type Account struct {
+		User   string
+		APIKey string `encrypt:"true"`
+	}
+	account := Account{User: "srathi", APIKey: "abc"}
+
+	// Any Cipher, such as one using AES-GCM.
+	cipher := xorCipher{0x01}
+	if err := EncryptFields(&account, cipher); err != nil {
+		// Handle error.
+	}
+	fmt.Println(account.APIKey)
+
+	if err := DecryptFields(&account, cipher); err != nil {
+		// Handle error.
+	}
+	fmt.Println(account.APIKey)
+	// Output:
+	// YGNi
+	// abc
+}
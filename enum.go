@@ -0,0 +1,222 @@
+package attr
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// enumTable holds the names of the values of an enum type.
+type enumTable struct {
+	values map[string]reflect.Value
+	names  map[interface{}]string
+}
+
+// enums is the registry of the enum types added by RegisterEnum.
+var enums = struct {
+	sync.RWMutex
+	tables map[reflect.Type]enumTable
+}{tables: map[reflect.Type]enumTable{}}
+
+// RegisterEnum registers the names of the values of an enum type, such as a
+// custom integer type with constants, so that the strings are resolved to the
+// values by the names wherever a string is parsed as a field value (such as in
+// SetValueFromString, FromMap, FromValues and FromEnv), and the values are
+// formatted as their names (such as in ToEnv and ToValues). A value with many
+// names is formatted as the first of them in the sorted order. Registering a
+// type again replaces its names, and a nil map removes them.
+//
+//	type Color int
+//
+//	attr.RegisterEnum(map[string]Color{"red": Red, "green": Green})
+func RegisterEnum[T comparable](names map[string]T) {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+
+	enums.Lock()
+	defer enums.Unlock()
+	if names == nil {
+		delete(enums.tables, typ)
+		return
+	}
+
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	table := enumTable{map[string]reflect.Value{}, map[interface{}]string{}}
+	for _, name := range sorted {
+		table.values[name] = reflect.ValueOf(names[name])
+		if _, found := table.names[names[name]]; !found {
+			table.names[names[name]] = name
+		}
+	}
+	enums.tables[typ] = table
+}
+
+// enumValue returns the value of an enum type with the given name. It returns
+// false if the type is not registered, and ErrInvalidValue if the name is
+// unknown.
+func enumValue(text string, typ reflect.Type) (reflect.Value, bool, error) {
+	enums.RLock()
+	defer enums.RUnlock()
+	table, found := enums.tables[typ]
+	if !found {
+		return reflect.Value{}, false, nil
+	}
+
+	value, found := table.values[text]
+	if !found {
+		return reflect.Value{}, true, ErrInvalidValue
+	}
+	return value, true, nil
+}
+
+// enumName returns the name of a value of a registered enum type.
+func enumName(value reflect.Value) (string, bool) {
+	enums.RLock()
+	defer enums.RUnlock()
+	table, found := enums.tables[value.Type()]
+	if !found || !value.CanInterface() {
+		return "", false
+	}
+
+	name, found := table.names[value.Interface()]
+	return name, found
+}
+
+// CheckEnums returns the paths of all the exported (public) fields of a struct
+// whose value is not one of the names listed in their "enum" tag, such as
+// `enum:"red,green,blue"`. The value of a registered enum type is checked by
+// its name (see RegisterEnum), and the other values by their text, such as
+// "2" for 2. The elements of a slice or an array field are checked one by one,
+// while the zero values and the nil pointers are skipped, so use a "required"
+// tag (see CheckRequired) to reject them.
+//
+// The nested structs (including the ones referenced by non-nil pointers) are
+// checked as well, and the path of a nested field is a dot separated list of
+// field names, such as "Address.Country". A *FieldError with ErrInvalidTag is
+// returned for an "enum" tag on a field whose value has no text, such as a
+// struct or a map.
+//
+// An empty slice is returned if all the enum fields are valid.
+func CheckEnums(obj interface{}, opts ...Option) ([]string, error) {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	o := newOptions(opts)
+	t := newTracker(o)
+	t.enter(objValue)
+
+	paths := []string{}
+	if err := checkEnums(objValue, "", o, t, &paths); err != nil {
+		return nil, err
+	}
+	if err := t.err(); err != nil {
+		return nil, err
+	}
+	return paths, nil
+}
+
+// checkEnums adds the paths of the enum fields of a struct with a value which
+// is not listed in their tags to 'paths'.
+func checkEnums(objValue reflect.Value, prefix string, o *options, t *tracker, paths *[]string) error {
+	objType := objValue.Type()
+	for i := 0; i < objValue.NumField(); i++ {
+		fieldType := objType.Field(i)
+		fieldValue := objValue.Field(i)
+		if fieldType.PkgPath != "" {
+			continue
+		}
+
+		path := prefix + fieldType.Name
+		if tag, found := fieldType.Tag.Lookup("enum"); found {
+			valid, err := isEnumValue(fieldValue, strings.Split(tag, ","))
+			if err != nil {
+				return &FieldError{path, err}
+			}
+			if !valid {
+				*paths = append(*paths, path)
+			}
+			continue
+		}
+
+		if !t.canDescend() || fieldValue.Type() == timeType {
+			continue
+		}
+
+		fieldValue = unwrapInterface(fieldValue, o)
+		if fieldValue.Kind() == reflect.Ptr && !fieldValue.IsNil() &&
+			fieldValue.Elem().Kind() == reflect.Struct {
+			// A struct referenced by one of the enclosing structs is already
+			// being checked.
+			if err := t.enter(fieldValue); err != nil {
+				continue
+			}
+			err := checkEnums(fieldValue.Elem(), path+pathSeparator, o, t, paths)
+			t.leave(fieldValue)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		if fieldValue.Kind() == reflect.Struct {
+			t.enter(fieldValue)
+			err := checkEnums(fieldValue, path+pathSeparator, o, t, paths)
+			t.leave(fieldValue)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// isEnumValue returns true if a value is zero or is one of the given names.
+// The elements of a slice or an array are checked one by one.
+func isEnumValue(value reflect.Value, names []string) (bool, error) {
+	if value.IsZero() {
+		return true, nil
+	}
+
+	if value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+
+	if (value.Kind() == reflect.Slice && value.Type().Elem().Kind() != reflect.Uint8) ||
+		value.Kind() == reflect.Array {
+		for i := 0; i < value.Len(); i++ {
+			valid, err := isEnumValue(value.Index(i), names)
+			if !valid || err != nil {
+				return valid, err
+			}
+		}
+		return true, nil
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		if value.Type() != timeType && !isTextMarshaler(value) {
+			return false, ErrInvalidTag
+		}
+	case reflect.Map, reflect.Chan, reflect.Func, reflect.Interface:
+		return false, ErrInvalidTag
+	}
+
+	text, err := formatValue(value, time.RFC3339)
+	if err != nil {
+		return false, ErrInvalidTag
+	}
+	for _, name := range names {
+		if strings.TrimSpace(name) == text {
+			return true, nil
+		}
+	}
+	return false, nil
+}
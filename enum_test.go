@@ -0,0 +1,141 @@
+package attr
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Color int
+
+const (
+	Red Color = iota + 1
+	Green
+	Blue
+)
+
+type Paint struct {
+	Color   Color   `enum:"red,green" url:"color"`
+	Finish  string  `enum:"matte, gloss"`
+	Coats   int     `enum:"1,2"`
+	Accents []Color `enum:"red,blue"`
+	Base    *string `enum:"oil,water"`
+	Primer  *Paint
+	Tint    Color
+}
+
+func TestSetValueFromString(t *testing.T) {
+	RegisterEnum(map[string]Color{"red": Red, "green": Green, "blue": Blue})
+	defer RegisterEnum[Color](nil)
+
+	paint := Paint{Primer: &Paint{}}
+	err := SetValueFromString(&paint, "Color", "green")
+	require.NoError(t, err)
+	require.Equal(t, Green, paint.Color)
+
+	err = SetValueFromString(&paint, "Primer.Accents", "red, blue")
+	require.NoError(t, err)
+	require.Equal(t, []Color{Red, Blue}, paint.Primer.Accents)
+
+	err = SetValueFromString(&paint, "Coats", "2")
+	require.NoError(t, err)
+	require.Equal(t, 2, paint.Coats)
+
+	err = SetValueFromString(&paint, "Base", "oil")
+	require.NoError(t, err)
+	require.Equal(t, "oil", *paint.Base)
+
+	err = SetValueFromString(&paint, "Color", "purple")
+	require.Equal(t, ErrInvalidValue, err)
+	require.Equal(t, Green, paint.Color)
+
+	err = SetValueFromString(&paint, "Color", "2")
+	require.Equal(t, ErrInvalidValue, err, "A registered enum was parsed as a number")
+
+	err = SetValueFromString(&paint, "Coats", "two")
+	require.Equal(t, ErrInvalidValue, err)
+
+	err = SetValueFromString(&paint, "Primer", "x")
+	require.Equal(t, ErrMismatchValue, err)
+
+	err = SetValueFromString(paint, "Coats", "1")
+	require.Equal(t, ErrNotPtr, err)
+
+	var records []ChangeRecord
+	err = SetValueFromString(&paint, "Tint", "blue", WithChangeHook(func(r ChangeRecord) {
+		records = append(records, r)
+	}))
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.Equal(t, Color(0), records[0].Old)
+	require.Equal(t, Blue, records[0].New)
+
+	// The other string decoders resolve the names too.
+	err = FromValues(&paint, url.Values{"color": {"red"}}, "url")
+	require.NoError(t, err)
+	require.Equal(t, Red, paint.Color)
+
+	values, err := ToValues(paint, "url")
+	require.NoError(t, err)
+	require.Equal(t, "red", values.Get("color"))
+
+	// Without the names, the enum type is parsed as its kind.
+	RegisterEnum[Color](nil)
+	err = SetValueFromString(&paint, "Color", "2")
+	require.NoError(t, err)
+	require.Equal(t, Green, paint.Color)
+}
+
+func TestCheckEnums(t *testing.T) {
+	RegisterEnum(map[string]Color{"red": Red, "green": Green, "blue": Blue})
+	defer RegisterEnum[Color](nil)
+
+	paths, err := CheckEnums(Paint{})
+	require.NoError(t, err)
+	require.Equal(t, []string{}, paths, "The zero values were reported")
+
+	water, acrylic := "water", "acrylic"
+	paint := &Paint{
+		Color:   Blue,
+		Finish:  "gloss",
+		Coats:   3,
+		Accents: []Color{Red, Green},
+		Base:    &water,
+		Primer:  &Paint{Finish: "satin", Base: &acrylic},
+		Tint:    Blue,
+	}
+	paths, err = CheckEnums(paint)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Color", "Coats", "Accents", "Primer.Finish", "Primer.Base"}, paths)
+
+	type Bad struct {
+		Options map[string]int `enum:"a"`
+	}
+	_, err = CheckEnums(Bad{Options: map[string]int{"a": 1}})
+	require.Equal(t, &FieldError{"Options", ErrInvalidTag}, err)
+
+	_, err = CheckEnums([]int{})
+	require.Equal(t, ErrNotStruct, err)
+}
+
+func ExampleRegisterEnum() {
+	RegisterEnum(map[string]Color{"red": Red, "green": Green, "blue": Blue})
+	defer RegisterEnum[Color](nil)
+
+	paint := Paint{}
+	if err := SetValueFromString(&paint, "Color", "green"); err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(paint.Color == Green)
+
+	paint.Color = Blue
+	paths, _ := CheckEnums(paint)
+	fmt.Println(paths)
+
+	// Output:
+	// true
+	// [Color]
+}
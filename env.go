@@ -0,0 +1,141 @@
+package attr
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// envSeparator joins the prefix and the name of an environment variable.
+const envSeparator = "_"
+
+// ToEnv converts the exported (public) fields of a struct to environment
+// variable assignments, such as "DB_HOST=localhost". Variable names are taken
+// from the "env" tags, or are the upper snake case field names if there is no
+// tag (such as "MAX_CONNS" for "MaxConns"), and are joined to the given
+// prefix with "_".
+//
+// Fields tagged with `env:"-"` and nil pointers are skipped. The fields of a
+// nested struct are added with the name of the nested struct as their prefix,
+// except for embedded structs without a tag, whose fields are promoted.
+// Slices and arrays are joined with ",", durations are formatted like "1m30s",
+// time.Time values are formatted with the layout given by the WithTimeLayout
+// option (time.RFC3339 by default), and []byte fields are encoded as given by
+// their "attr" tag (such as `attr:"base64"` or `attr:"hex"`).
+//
+// The nested structs deeper than the WithMaxDepth option are skipped, and
+// ErrCycleDetected is returned if a struct references itself through pointers.
+// With the WithInterfaces option, the values held by the interface fields are
+// added like the values of the fields of their types. Use WithFuncPolicy to
+// skip or reject the func and chan fields. The assignments are listed in the
+// declaration order of the fields, or sorted with the WithSortedKeys option.
+func ToEnv(obj interface{}, prefix string, opts ...Option) ([]string, error) {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	o := newOptions(opts)
+	t := newTracker(o)
+	t.enter(valueOf(obj))
+
+	env := []string{}
+	err = addEnv(&env, objValue, prefix, o, t)
+	if o.sortedKeys {
+		sort.Strings(env)
+	}
+	return env, err
+}
+
+// addEnv adds the assignments of all the exported fields of a struct to 'env'.
+func addEnv(env *[]string, objValue reflect.Value, prefix string, o *options, t *tracker) error {
+	if err := t.err(); err != nil {
+		return err
+	}
+
+	objType := objValue.Type()
+	for i := 0; i < objValue.NumField(); i++ {
+		fieldType := objType.Field(i)
+		fieldValue := objValue.Field(i)
+		tag, hasTag := fieldType.Tag.Lookup("env")
+		if tag == "-" {
+			continue
+		}
+
+		if fieldType.Anonymous && !hasTag && fieldValue.Kind() == reflect.Struct {
+			if err := addEnv(env, fieldValue, prefix, o, t); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !fieldValue.CanInterface() {
+			continue
+		}
+
+		name := tagName(tag)
+		if name == "" {
+			name = keyName(fieldType.Name, o.keyNaming, UpperSnake)
+		}
+		if prefix != "" {
+			name = prefix + envSeparator + name
+		}
+
+		fieldValue = unwrapInterface(fieldValue, o)
+		if skip, err := o.skipFunc(fieldValue, name); skip {
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		ptrValue := fieldValue
+		if fieldValue.Kind() == reflect.Ptr {
+			if fieldValue.IsNil() {
+				continue
+			}
+			fieldValue = fieldValue.Elem()
+		}
+
+		var value string
+		switch {
+		case fieldValue.Kind() == reflect.Struct && !isValueStruct(fieldValue.Type()) &&
+			!isTextMarshaler(fieldValue):
+			if !t.canDescend() {
+				continue
+			}
+			if err := t.enter(ptrValue); err != nil {
+				return err
+			}
+			err := addEnv(env, fieldValue, name, o, t)
+			t.leave(ptrValue)
+			if err != nil {
+				return err
+			}
+			continue
+
+		case (fieldValue.Kind() == reflect.Slice && fieldValue.Type().Elem().Kind() != reflect.Uint8) ||
+			fieldValue.Kind() == reflect.Array:
+			items := make([]string, fieldValue.Len())
+			for j := range items {
+				text, err := formatValue(fieldValue.Index(j), o.timeLayout)
+				if err != nil {
+					return err
+				}
+				items[j] = text
+			}
+			value = strings.Join(items, ",")
+
+		default:
+			text, err := formatField(fieldType, fieldValue, o.timeLayout)
+			if err != nil {
+				return err
+			}
+			value = text
+		}
+
+		*env = append(*env, name+"="+value)
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package attr
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type DBConfig struct {
+	Host     string `env:"HOST"`
+	Port     int    `env:"PORT"`
+	MaxConns int
+}
+
+type AppConfig struct {
+	Page
+	Name     string        `env:"APP_NAME"`
+	DB       DBConfig      `env:"DB"`
+	Replica  *DBConfig     `env:"REPLICA"`
+	Timeout  time.Duration `env:"TIMEOUT"`
+	Hosts    []string
+	Password string `env:"-"`
+	debug    bool
+}
+
+func TestToEnv(t *testing.T) {
+	config := AppConfig{
+		Page:     Page{Number: 1},
+		Name:     "attr",
+		DB:       DBConfig{"localhost", 5432, 10},
+		Timeout:  5 * time.Second,
+		Hosts:    []string{"a", "b"},
+		Password: "secret",
+	}
+	want := []string{
+		"MY_NUMBER=1",
+		"MY_SIZE=0",
+		"MY_APP_NAME=attr",
+		"MY_DB_HOST=localhost",
+		"MY_DB_PORT=5432",
+		"MY_DB_MAX_CONNS=10",
+		"MY_TIMEOUT=5s",
+		"MY_HOSTS=a,b",
+	}
+	got, err := ToEnv(&config, "MY")
+	require.Nil(t, err)
+	require.Equal(t, want, got, "Environment variables are not correct")
+
+	_, err = ToEnv("abc", "")
+	require.Equal(t, ErrNotStruct, err, "Able to convert a non-struct")
+
+	// Nil pointer elements are formatted as empty items.
+	type Retry struct {
+		Delays []*time.Duration
+	}
+	delay := time.Second
+	got, err = ToEnv(Retry{Delays: []*time.Duration{&delay, nil, &delay}}, "")
+	require.Nil(t, err)
+	require.Equal(t, []string{"DELAYS=1s,,1s"}, got, "Environment variables of nil pointers are not correct")
+}
+
+func ExampleToEnv() {
+	type Config struct {
+		Host     string `env:"HOST"`
+		MaxConns int
+	}
+	config := Config{Host: "localhost", MaxConns: 10}
+
+	env, err := ToEnv(&config, "DB")
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Println(env)
+	// Output: [DB_HOST=localhost DB_MAX_CONNS=10]
+}
@@ -0,0 +1,144 @@
+package attr
+
+import (
+	"reflect"
+	"time"
+)
+
+// equalVisit identifies a pair of pointers already being compared by
+// EqualExported, to stop infinite recursion on cyclic values.
+type equalVisit struct {
+	a, b uintptr
+	typ  reflect.Type
+}
+
+// EqualExported reports whether two structs are deeply equal, comparing only
+// their exported (public) fields. Unlike reflect.DeepEqual, unexported fields
+// (such as mutexes and cached values) are ignored, at every level of nesting.
+// Pointers and interfaces are followed, and slices, arrays and maps are
+// compared element by element. A nil slice or map is not equal to an empty
+// one, as with reflect.DeepEqual.
+//
+// A struct type without any exported field, such as time.Time, is compared
+// with reflect.DeepEqual as a whole, except time.Time values which are
+// compared with their Equal method. The values of a type with a function
+// registered by RegisterComparer are compared with that function.
+//
+// Both 'a' and 'b' can be passed by value or by pointer. ErrMismatchValue is
+// returned if they are of different types.
+func EqualExported(a, b interface{}) (bool, error) {
+	aValue, err := getReflectValue(a)
+	if err != nil {
+		return false, err
+	}
+
+	bValue, err := getReflectValue(b)
+	if err != nil {
+		return false, err
+	}
+
+	if aValue.Type() != bValue.Type() {
+		return false, ErrMismatchValue
+	}
+
+	return equalExported(aValue, bValue, map[equalVisit]bool{}), nil
+}
+
+// equalExported compares two values of the same type, ignoring the unexported
+// struct fields.
+func equalExported(a, b reflect.Value, visited map[equalVisit]bool) bool {
+	if a.CanInterface() {
+		if fn := comparerFor(a.Type()); fn != nil {
+			return fn(a.Interface(), b.Interface())
+		}
+	}
+
+	switch a.Kind() {
+	case reflect.Ptr:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		if a.Pointer() == b.Pointer() {
+			return true
+		}
+		visit := equalVisit{a.Pointer(), b.Pointer(), a.Type()}
+		if visited[visit] {
+			return true
+		}
+		visited[visit] = true
+		return equalExported(a.Elem(), b.Elem(), visited)
+
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		if a.Elem().Type() != b.Elem().Type() {
+			return false
+		}
+		return equalExported(a.Elem(), b.Elem(), visited)
+
+	case reflect.Struct:
+		if a.Type() == timeType {
+			return a.Interface().(time.Time).Equal(b.Interface().(time.Time))
+		}
+		objType := a.Type()
+		exported := false
+		for i := 0; i < a.NumField(); i++ {
+			if objType.Field(i).PkgPath != "" {
+				continue
+			}
+			exported = true
+			if !equalExported(a.Field(i), b.Field(i), visited) {
+				return false
+			}
+		}
+		if !exported && a.CanInterface() {
+			return reflect.DeepEqual(a.Interface(), b.Interface())
+		}
+		return true
+
+	case reflect.Slice:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		if a.Len() != b.Len() {
+			return false
+		}
+		if a.Pointer() == b.Pointer() {
+			return true
+		}
+		fallthrough
+
+	case reflect.Array:
+		for i := 0; i < a.Len(); i++ {
+			if !equalExported(a.Index(i), b.Index(i), visited) {
+				return false
+			}
+		}
+		return true
+
+	case reflect.Map:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		if a.Len() != b.Len() {
+			return false
+		}
+		if a.Pointer() == b.Pointer() {
+			return true
+		}
+		iter := a.MapRange()
+		for iter.Next() {
+			bElem := b.MapIndex(iter.Key())
+			if !bElem.IsValid() || !equalExported(iter.Value(), bElem, visited) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if !a.CanInterface() {
+		return true
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
@@ -0,0 +1,90 @@
+package attr
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Inventory struct {
+	Items   map[string]*Address
+	Owners  []Employee
+	Updated time.Time
+	Note    interface{}
+	mu      sync.Mutex
+	cache   []string
+}
+
+func TestEqualExported(t *testing.T) {
+	now := time.Now()
+	a := &Inventory{
+		Items:   map[string]*Address{"home": {City: "San Jose"}},
+		Owners:  []Employee{{Name: "srathi", internal: "x"}},
+		Updated: now,
+		Note:    Address{City: "Fremont"},
+		cache:   []string{"stale"},
+	}
+	b := &Inventory{
+		Items:   map[string]*Address{"home": {City: "San Jose"}},
+		Owners:  []Employee{{Name: "srathi", internal: "y"}},
+		Updated: now.In(time.UTC),
+		Note:    Address{City: "Fremont"},
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	got, err := EqualExported(a, b)
+	require.Nil(t, err)
+	require.True(t, got, "Unexported fields are compared")
+
+	b.Items["home"].City = "Fremont"
+	got, err = EqualExported(a, b)
+	require.Nil(t, err)
+	require.False(t, got, "Different pointed-to values are reported as equal")
+	b.Items["home"].City = "San Jose"
+
+	b.Owners = append(b.Owners, Employee{})
+	got, err = EqualExported(a, b)
+	require.Nil(t, err)
+	require.False(t, got, "Slices of different lengths are reported as equal")
+	b.Owners = b.Owners[:1]
+
+	b.Note = 10
+	got, err = EqualExported(a, b)
+	require.Nil(t, err)
+	require.False(t, got, "Different interface values are reported as equal")
+
+	first := &Chain{Name: "a"}
+	first.Next = first
+	second := &Chain{Name: "a"}
+	second.Next = second
+	got, err = EqualExported(first, second)
+	require.Nil(t, err)
+	require.True(t, got, "Equal cyclic values are reported as different")
+
+	_, err = EqualExported(a, Address{})
+	require.Equal(t, ErrMismatchValue, err, "Able to compare different types")
+
+	_, err = EqualExported(a, 10)
+	require.Equal(t, ErrNotStruct, err, "Able to compare a non-struct")
+}
+
+func ExampleEqualExported() {
+	// type User struct {
+	// 	Username string `json:"username" db:"uname"`
+	// 	password string `json:"password" db:"pw"`
+	// 	Age      int    `json:"age" meta:"important"`
+	// }
+	first := User{Username: "srathi", password: "secret", Age: 30}
+	second := User{Username: "srathi", password: "other", Age: 30}
+
+	equal, err := EqualExported(first, &second)
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Println(equal)
+	// Output: true
+}
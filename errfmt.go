@@ -0,0 +1,45 @@
+package attr
+
+import "sync"
+
+// errorFormatter is the function set by SetErrorFormatter, or nil for the
+// default messages.
+var errorFormatter = struct {
+	sync.RWMutex
+	fn func(err *FieldError) string
+}{}
+
+// SetErrorFormatter overrides the message of every *FieldError returned by the
+// APIs, such as for localized or user facing messages. 'fn' is given the
+// error and returns its full message, typically derived from err.Field and
+// the error value in err.Err, such as:
+//
+//	attr.SetErrorFormatter(func(err *attr.FieldError) string {
+//		if errors.Is(err.Err, attr.ErrInvalidValue) {
+//			return fmt.Sprintf("Le champ %s est invalide", err.Field)
+//		}
+//		return err.Field + ": " + err.Err.Error()
+//	})
+//
+// Only the messages change: the errors keep wrapping the same error values, so
+// errors.Is and errors.As work as before. 'fn' must not call err.Error(),
+// which would call 'fn' again. A nil 'fn' restores the default messages. The
+// errors returned without a field name (such as ErrNoField by GetValue) are
+// not affected.
+func SetErrorFormatter(fn func(err *FieldError) string) {
+	errorFormatter.Lock()
+	defer errorFormatter.Unlock()
+	errorFormatter.fn = fn
+}
+
+// formatFieldError returns the message of a *FieldError from the formatter set
+// by SetErrorFormatter, and false if there is none.
+func formatFieldError(err *FieldError) (string, bool) {
+	errorFormatter.RLock()
+	fn := errorFormatter.fn
+	errorFormatter.RUnlock()
+	if fn == nil {
+		return "", false
+	}
+	return fn(err), true
+}
@@ -0,0 +1,49 @@
+package attr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetErrorFormatter(t *testing.T) {
+	values := map[string]interface{}{"Age": "thirty"}
+
+	var testUser User
+	err := FromMap(&testUser, values, "")
+	require.EqualError(t, err, "Age: "+ErrInvalidValue.Error())
+
+	SetErrorFormatter(func(err *FieldError) string {
+		if errors.Is(err.Err, ErrInvalidValue) {
+			return fmt.Sprintf("Le champ %s est invalide", err.Field)
+		}
+		return err.Field + " ?"
+	})
+	defer SetErrorFormatter(nil)
+
+	err = FromMap(&testUser, values, "")
+	require.EqualError(t, err, "Le champ Age est invalide")
+	require.True(t, errors.Is(err, ErrInvalidValue), "Error value is not kept")
+	var fieldErr *FieldError
+	require.True(t, errors.As(err, &fieldErr), "Error type is not kept")
+
+	err = &FieldError{"Name", ErrNoField}
+	require.EqualError(t, err, "Name ?")
+
+	SetErrorFormatter(nil)
+	require.EqualError(t, err, "Name: "+ErrNoField.Error())
+}
+
+func ExampleSetErrorFormatter() {
+	SetErrorFormatter(func(err *FieldError) string {
+		return "invalid " + err.Field
+	})
+	defer SetErrorFormatter(nil)
+
+	var testUser User
+	err := FromMap(&testUser, map[string]interface{}{"Age": "thirty"}, "")
+	fmt.Println(err)
+	// Output: invalid Age
+}
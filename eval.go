@@ -0,0 +1,268 @@
+package attr
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+)
+
+// Eval evaluates a boolean expression over the exported (public) fields of a
+// struct, such as `Age >= 18 && Country == "US"`, and returns its result.
+// 'obj' can be passed by value or by pointer.
+//
+// The expression uses the Go syntax, with the fields given by their names or
+// by dot separated paths for nested fields (such as Address.City). Supported
+// operators are ==, !=, <, <=, >, >=, &&, || and !, with parentheses for
+// grouping. Operands can be fields, numbers, strings (in double quotes or
+// backticks), true, false and nil (for pointer, slice, map and interface
+// fields). Numbers of any kind are compared by their values, strings are
+// compared lexically and bools can only be compared for (in)equality.
+//
+// ErrInvalidExpr is returned for a syntax error or an unsupported construct,
+// ErrMismatchValue for an operation on incompatible values, and the field
+// lookup errors (such as ErrNoField) for an invalid field.
+func Eval(obj interface{}, expr string) (bool, error) {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return false, err
+	}
+
+	node, err := parser.ParseExpr(expr)
+	if err != nil {
+		return false, ErrInvalidExpr
+	}
+
+	result, err := evalNode(objValue, node)
+	if err != nil {
+		return false, err
+	}
+
+	b, ok := result.(bool)
+	if !ok {
+		return false, ErrMismatchValue
+	}
+	return b, nil
+}
+
+// nilValue represents the "nil" identifier in an expression.
+type nilValue struct{}
+
+// evalNode evaluates an expression node. The result is a bool, a string, a
+// *big.Float (for all numbers), nilValue or a reflect.Value of a field which
+// is not of these types.
+func evalNode(objValue reflect.Value, node ast.Expr) (interface{}, error) {
+	switch n := node.(type) {
+	case *ast.ParenExpr:
+		return evalNode(objValue, n.X)
+
+	case *ast.BasicLit:
+		switch n.Kind {
+		case token.INT, token.FLOAT:
+			f, _, err := big.ParseFloat(n.Value, 0, 128, big.ToNearestEven)
+			if err != nil {
+				return nil, ErrInvalidExpr
+			}
+			return f, nil
+		case token.STRING:
+			s, err := strconv.Unquote(n.Value)
+			if err != nil {
+				return nil, ErrInvalidExpr
+			}
+			return s, nil
+		}
+		return nil, ErrInvalidExpr
+
+	case *ast.Ident:
+		switch n.Name {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "nil":
+			return nilValue{}, nil
+		}
+		return evalField(objValue, n.Name)
+
+	case *ast.SelectorExpr:
+		path, ok := selectorPath(n)
+		if !ok {
+			return nil, ErrInvalidExpr
+		}
+		return evalField(objValue, path)
+
+	case *ast.UnaryExpr:
+		operand, err := evalNode(objValue, n.X)
+		if err != nil {
+			return nil, err
+		}
+		switch n.Op {
+		case token.NOT:
+			b, ok := operand.(bool)
+			if !ok {
+				return nil, ErrMismatchValue
+			}
+			return !b, nil
+		case token.SUB:
+			f, ok := operand.(*big.Float)
+			if !ok {
+				return nil, ErrMismatchValue
+			}
+			return new(big.Float).Neg(f), nil
+		}
+		return nil, ErrInvalidExpr
+
+	case *ast.BinaryExpr:
+		return evalBinary(objValue, n)
+	}
+
+	return nil, ErrInvalidExpr
+}
+
+// evalBinary evaluates a binary expression node.
+func evalBinary(objValue reflect.Value, n *ast.BinaryExpr) (interface{}, error) {
+	left, err := evalNode(objValue, n.X)
+	if err != nil {
+		return nil, err
+	}
+
+	if n.Op == token.LAND || n.Op == token.LOR {
+		l, ok := left.(bool)
+		if !ok {
+			return nil, ErrMismatchValue
+		}
+		// Short circuit like Go.
+		if (n.Op == token.LAND && !l) || (n.Op == token.LOR && l) {
+			return l, nil
+		}
+		right, err := evalNode(objValue, n.Y)
+		if err != nil {
+			return nil, err
+		}
+		r, ok := right.(bool)
+		if !ok {
+			return nil, ErrMismatchValue
+		}
+		return r, nil
+	}
+
+	right, err := evalNode(objValue, n.Y)
+	if err != nil {
+		return nil, err
+	}
+
+	cmp, err := compareOperands(left, right, n.Op == token.EQL || n.Op == token.NEQ)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.Op {
+	case token.EQL:
+		return cmp == 0, nil
+	case token.NEQ:
+		return cmp != 0, nil
+	case token.LSS:
+		return cmp < 0, nil
+	case token.LEQ:
+		return cmp <= 0, nil
+	case token.GTR:
+		return cmp > 0, nil
+	case token.GEQ:
+		return cmp >= 0, nil
+	}
+	return nil, ErrInvalidExpr
+}
+
+// compareOperands compares two operands and returns -1, 0 or +1. If
+// 'equality' is true, only an equality check is needed, which is also
+// supported for bools, nil and any comparable field values. ErrMismatchValue
+// is returned for the values which can't be compared, such as two slices held
+// by interface fields.
+func compareOperands(left, right interface{}, equality bool) (int, error) {
+	switch l := left.(type) {
+	case *big.Float:
+		if r, ok := right.(*big.Float); ok {
+			return l.Cmp(r), nil
+		}
+	case string:
+		if r, ok := right.(string); ok {
+			switch {
+			case l < r:
+				return -1, nil
+			case l > r:
+				return 1, nil
+			}
+			return 0, nil
+		}
+	case bool:
+		if r, ok := right.(bool); ok && equality {
+			if l == r {
+				return 0, nil
+			}
+			return 1, nil
+		}
+	case nilValue:
+		if r, ok := right.(reflect.Value); ok && equality && isNillable(r.Kind()) {
+			if r.IsNil() {
+				return 0, nil
+			}
+			return 1, nil
+		}
+	case reflect.Value:
+		if _, ok := right.(nilValue); ok {
+			return compareOperands(right, left, equality)
+		}
+		// An interface type is comparable, but the value it holds may not
+		// be (such as a slice), so the values themselves are checked.
+		if r, ok := right.(reflect.Value); ok && equality &&
+			l.Type() == r.Type() && l.Comparable() && r.Comparable() {
+			if l.Interface() == r.Interface() {
+				return 0, nil
+			}
+			return 1, nil
+		}
+	}
+
+	return 0, ErrMismatchValue
+}
+
+// evalField returns the operand for the value of a field given by its path.
+func evalField(objValue reflect.Value, path string) (interface{}, error) {
+	fieldValue, err := getFieldByPath(objValue, path, false)
+	if err != nil {
+		return nil, err
+	}
+
+	switch fieldValue.Kind() {
+	case reflect.Bool:
+		return fieldValue.Bool(), nil
+	case reflect.String:
+		return fieldValue.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Float).SetInt64(fieldValue.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Float).SetUint64(fieldValue.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		// NaN can't be compared with any number.
+		if f := fieldValue.Float(); !math.IsNaN(f) {
+			return new(big.Float).SetFloat64(f), nil
+		}
+	}
+	return fieldValue, nil
+}
+
+// selectorPath converts a selector expression, such as "Address.City", to a
+// field path. Returns false if it is not a simple path of names.
+func selectorPath(n *ast.SelectorExpr) (string, bool) {
+	switch x := n.X.(type) {
+	case *ast.Ident:
+		return x.Name + pathSeparator + n.Sel.Name, true
+	case *ast.SelectorExpr:
+		prefix, ok := selectorPath(x)
+		return prefix + pathSeparator + n.Sel.Name, ok
+	}
+	return "", false
+}
@@ -0,0 +1,78 @@
+package attr
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEval(t *testing.T) {
+	employee := Employee{
+		Name:    "srathi",
+		Tags:    []string{"admin"},
+		Address: &Address{City: "San Jose", Zip: "95134"},
+		Extra:   []int{1},
+	}
+	for _, test := range []struct {
+		expr    string
+		want    bool
+		wantErr error
+	}{
+		{`Name == "srathi"`, true, nil},
+		{`Name != "srathi" || Address.City == "San Jose"`, true, nil},
+		{`Address.Zip >= "95000" && Address.Zip < "96000"`, true, nil},
+		{`!(Name == "abc") && Manager == nil && Address != nil`, true, nil},
+		{`Tags == nil`, false, nil},
+		{`Manager.Name == "abc" || true`, false, &NilPathError{"Manager", 1}},
+		{`Name == "abc" && Manager.Name == "abc"`, false, nil},
+		{`Name > 10`, false, ErrMismatchValue},
+		{`Name`, false, ErrMismatchValue},
+		{`Extra == Extra`, false, ErrMismatchValue},
+		{`Extra != nil`, true, nil},
+		{`Address == Address && Address <= Address`, false, ErrMismatchValue},
+		{`ABC == 1`, false, ErrNoField},
+		{`internal == ""`, false, ErrUnexportedField},
+		{`Name == `, false, ErrInvalidExpr},
+		{`len(Name) > 1`, false, ErrInvalidExpr},
+	} {
+		got, err := Eval(&employee, test.expr)
+		require.Equal(t, test.wantErr, err, "Error is not correct for %q", test.expr)
+		require.Equal(t, test.want, got, "Result is not correct for %q", test.expr)
+	}
+}
+
+func TestEvalNumbers(t *testing.T) {
+	type Stats struct {
+		Count  uint64
+		Delta  int8
+		Ratio  float32
+		Active bool
+	}
+	stats := Stats{Count: 1<<63 + 1, Delta: -5, Ratio: 0.5, Active: true}
+	for _, test := range []struct {
+		expr string
+		want bool
+	}{
+		{`Count > 9223372036854775808`, true},
+		{`Delta == -5 && Delta < 0.5`, true},
+		{`Ratio == 0.5 && Ratio > Delta`, true},
+		{`Active && Active != false`, true},
+		{`Ratio >= 1e3`, false},
+	} {
+		got, err := Eval(stats, test.expr)
+		require.Nil(t, err, "Error for %q", test.expr)
+		require.Equal(t, test.want, got, "Result is not correct for %q", test.expr)
+	}
+}
+
+func ExampleEval() {
+	testUser := User{Username: "srathi", password: "secret", Age: 30}
+
+	adult, err := Eval(&testUser, `Age >= 18 && Username != ""`)
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Printf("Adult: %v\n", adult)
+	// Output: Adult: true
+}
@@ -0,0 +1,222 @@
+package attr
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// fakeWords is the vocabulary used to generate fake text.
+var fakeWords = []string{
+	"alpha", "bravo", "charlie", "delta", "echo", "foxtrot", "golf", "hotel",
+	"india", "juliet", "kilo", "lima", "mike", "november", "oscar", "papa",
+}
+
+// fakeNames is the list of names used to generate fake names and emails.
+var fakeNames = []string{
+	"alice", "bob", "carol", "dave", "erin", "frank", "grace", "heidi",
+	"ivan", "judy", "mallory", "oscar", "peggy", "trent", "victor", "walter",
+}
+
+// fakers generate the fake string values for the values of a "fake" tag.
+var fakers = map[string]func(r *rand.Rand) string{
+	"name": func(r *rand.Rand) string {
+		return capitalize(fakeNames[r.Intn(len(fakeNames))])
+	},
+	"email": func(r *rand.Rand) string {
+		return fmt.Sprintf("%s%d@example.com", fakeNames[r.Intn(len(fakeNames))], r.Intn(1000))
+	},
+	"word": func(r *rand.Rand) string {
+		return fakeWords[r.Intn(len(fakeWords))]
+	},
+	"sentence": func(r *rand.Rand) string {
+		words := make([]string, 3+r.Intn(5))
+		for i := range words {
+			words[i] = fakeWords[r.Intn(len(fakeWords))]
+		}
+		return capitalize(strings.Join(words, " ")) + "."
+	},
+	"url": func(r *rand.Rand) string {
+		return fmt.Sprintf("https://%s.example.com/%s",
+			fakeWords[r.Intn(len(fakeWords))], fakeWords[r.Intn(len(fakeWords))])
+	},
+	"phone": func(r *rand.Rand) string {
+		return fmt.Sprintf("+1-555-%03d-%04d", r.Intn(1000), r.Intn(10000))
+	},
+	"uuid": func(r *rand.Rand) string {
+		b := make([]byte, 16)
+		r.Read(b)
+		b[6] = (b[6] & 0x0f) | 0x40
+		b[8] = (b[8] & 0x3f) | 0x80
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+	},
+}
+
+// capitalize returns the string with its first letter in upper case.
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// filler holds the state of a Fill call.
+type filler struct {
+	rand *rand.Rand
+	// inProgress holds the struct types being filled, to avoid an infinite
+	// recursion on recursive types.
+	inProgress map[reflect.Type]bool
+}
+
+// Fill populates all the exported (public) fields of a struct with
+// pseudo-random values of the field's type, recursing into nested structs,
+// pointers, slices, arrays and maps. Interface, func and channel fields are
+// left untouched, as well as the recursive references to a struct type being
+// filled.
+//
+// A string field can be filled with a realistic value by using a "fake" tag,
+// such as `fake:"email"`. Supported values are "name", "email", "word",
+// "sentence", "url", "phone" and "uuid", and `fake:"-"` skips the field.
+// An unsupported value results in ErrInvalidTag.
+//
+// Use the WithSeed option to get the same values on every call.
+//
+// NOTE: 'obj' struct must be passed by pointer for this API to work. Passing by
+// value results in ErrNotPtr.
+func Fill(obj interface{}, opts ...Option) error {
+	objValue, err := getStructByPtr(obj)
+	if err != nil {
+		return err
+	}
+
+	o := newOptions(opts)
+	f := &filler{
+		rand:       rand.New(rand.NewSource(o.seed)),
+		inProgress: map[reflect.Type]bool{},
+	}
+	return f.fillStruct(objValue)
+}
+
+// fillStruct fills all the exported fields of a struct.
+func (f *filler) fillStruct(objValue reflect.Value) error {
+	objType := objValue.Type()
+	f.inProgress[objType] = true
+	defer delete(f.inProgress, objType)
+
+	for i := 0; i < objValue.NumField(); i++ {
+		fieldType := objType.Field(i)
+		fieldValue := objValue.Field(i)
+		if !fieldValue.CanSet() {
+			continue
+		}
+
+		fake, found := fieldType.Tag.Lookup("fake")
+		if !found {
+			if err := f.fill(fieldValue); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if fake == "-" {
+			continue
+		}
+
+		faker, ok := fakers[fake]
+		if !ok || fieldValue.Kind() != reflect.String {
+			return ErrInvalidTag
+		}
+		fieldValue.SetString(faker(f.rand))
+	}
+
+	return nil
+}
+
+// fill sets a random value to the given settable value.
+func (f *filler) fill(value reflect.Value) error {
+	if value.Type() == timeType {
+		// Any second in the years 2000 to 2030.
+		secs := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC).Unix() + f.rand.Int63n(30*365*24*3600)
+		value.Set(reflect.ValueOf(time.Unix(secs, 0).UTC()))
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.Bool:
+		value.SetBool(f.rand.Intn(2) == 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value.SetInt(f.rand.Int63n(100))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		value.SetUint(uint64(f.rand.Int63n(100)))
+	case reflect.Float32, reflect.Float64:
+		value.SetFloat(float64(f.rand.Int63n(10000)) / 100)
+	case reflect.String:
+		value.SetString(fakeWords[f.rand.Intn(len(fakeWords))])
+	case reflect.Struct:
+		if f.inProgress[value.Type()] {
+			return nil
+		}
+		return f.fillStruct(value)
+	case reflect.Ptr:
+		if f.isRecursive(value.Type().Elem()) {
+			return nil
+		}
+		elem := reflect.New(value.Type().Elem())
+		if err := f.fill(elem.Elem()); err != nil {
+			return err
+		}
+		value.Set(elem)
+	case reflect.Slice:
+		if f.isRecursive(value.Type().Elem()) {
+			return nil
+		}
+		size := 1 + f.rand.Intn(3)
+		slice := reflect.MakeSlice(value.Type(), size, size)
+		for i := 0; i < slice.Len(); i++ {
+			if err := f.fill(slice.Index(i)); err != nil {
+				return err
+			}
+		}
+		value.Set(slice)
+	case reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if err := f.fill(value.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if f.isRecursive(value.Type().Elem()) {
+			return nil
+		}
+		mapValue := reflect.MakeMap(value.Type())
+		for i := 1 + f.rand.Intn(3); i > 0; i-- {
+			key := reflect.New(value.Type().Key()).Elem()
+			elem := reflect.New(value.Type().Elem()).Elem()
+			if err := f.fill(key); err != nil {
+				return err
+			}
+			if err := f.fill(elem); err != nil {
+				return err
+			}
+			mapValue.SetMapIndex(key, elem)
+		}
+		value.Set(mapValue)
+	}
+
+	return nil
+}
+
+// isRecursive returns true if the given type refers to a struct type which is
+// being filled, either directly or through pointers, slices or maps.
+func (f *filler) isRecursive(typ reflect.Type) bool {
+	for {
+		switch typ.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+			typ = typ.Elem()
+		default:
+			return f.inProgress[typ]
+		}
+	}
+}
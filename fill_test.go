@@ -0,0 +1,56 @@
+package attr
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Contact struct {
+	Name    string            `fake:"name"`
+	Email   string            `fake:"email"`
+	ID      string            `fake:"uuid"`
+	Skipped string            `fake:"-"`
+	Score   float64           `json:"score"`
+	Emails  []string          `json:"emails"`
+	Home    *Address          `json:"home"`
+	Friends []*Contact        `json:"friends"`
+	Meta    map[string]uint16 `json:"meta"`
+	Codes   [2]int8           `json:"codes"`
+	note    string
+}
+
+func TestFill(t *testing.T) {
+	var got Contact
+	require.Nil(t, Fill(&got, WithSeed(10)))
+	require.Regexp(t, regexp.MustCompile(`^[a-z]+\d+@example\.com$`), got.Email,
+		"Fake email is not correct")
+	require.Regexp(t, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`),
+		got.ID, "Fake uuid is not correct")
+	require.NotEmpty(t, got.Name, "Fake name not set")
+	require.NotEmpty(t, got.Emails, "Slice not filled")
+	require.NotNil(t, got.Home, "Pointer to a struct not filled")
+	require.NotEmpty(t, got.Home.City, "Nested struct not filled")
+	require.NotEmpty(t, got.Meta, "Map not filled")
+	require.Empty(t, got.Skipped, "Skipped field is filled")
+	require.Empty(t, got.note, "Private field is filled")
+	require.Nil(t, got.Friends, "Recursive field is filled")
+
+	var again Contact
+	require.Nil(t, Fill(&again, WithSeed(10)))
+	require.True(t, reflect.DeepEqual(got, again), "Same seed gave different values")
+
+	type BadFake struct {
+		Count int `fake:"email"`
+	}
+	require.Equal(t, ErrInvalidTag, Fill(&BadFake{}), "Able to fill an email in an int")
+
+	type UnknownFake struct {
+		Name string `fake:"abc"`
+	}
+	require.Equal(t, ErrInvalidTag, Fill(&UnknownFake{}), "Able to fill an unknown fake value")
+
+	require.Equal(t, ErrNotPtr, Fill(got), "Able to fill a struct passed by value")
+}
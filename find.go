@@ -0,0 +1,86 @@
+package attr
+
+import "reflect"
+
+// FindFields returns the paths of all the exported (public) fields of a struct
+// for which 'match' returns true. 'match' is called with the path and the
+// value of every field, and the nested structs (including the ones referenced
+// by non-nil pointers) are searched too. The path of a nested field is a dot
+// separated list of field names, such as "Address.City".
+//
+// The nested structs deeper than the WithMaxDepth option are not searched, and
+// ErrCycleDetected is returned if a struct references itself through pointers,
+// like ToValues. A struct referenced through pointers more than once (without
+// a cycle) is searched under each of its paths. Use WithInterfaces to search
+// the structs held by the interface fields too.
+//
+// An empty slice is returned if no field matches.
+func FindFields(obj interface{}, match func(path string, value interface{}) bool,
+	opts ...Option) ([]string, error) {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	o := newOptions(opts)
+	t := newTracker(o)
+	t.enter(valueOf(obj))
+
+	paths := []string{}
+	if err := findFields(objValue, "", o, t, match, &paths); err != nil {
+		return nil, err
+	}
+	if err := t.err(); err != nil {
+		return nil, err
+	}
+	return paths, nil
+}
+
+// FieldsEqual returns the paths of all the exported (public) fields of a struct
+// which are deeply equal to the given value, searching the nested structs too.
+// See FindFields for more details.
+func FieldsEqual(obj interface{}, value interface{}, opts ...Option) ([]string, error) {
+	return FindFields(obj, func(_ string, fieldValue interface{}) bool {
+		return reflect.DeepEqual(fieldValue, value)
+	}, opts...)
+}
+
+// findFields adds the paths of the matching fields of a struct to 'paths'.
+func findFields(objValue reflect.Value, prefix string, o *options, t *tracker,
+	match func(string, interface{}) bool, paths *[]string) error {
+	objType := objValue.Type()
+	for i := 0; i < objValue.NumField(); i++ {
+		fieldValue := objValue.Field(i)
+		if !fieldValue.CanInterface() {
+			continue
+		}
+
+		path := prefix + objType.Field(i).Name
+		if match(path, fieldValue.Interface()) {
+			*paths = append(*paths, path)
+		}
+
+		if !t.canDescend() {
+			continue
+		}
+
+		fieldValue = unwrapInterface(fieldValue, o)
+		ptrValue := fieldValue
+		if fieldValue.Kind() == reflect.Ptr && !fieldValue.IsNil() {
+			fieldValue = fieldValue.Elem()
+		}
+
+		if fieldValue.Kind() == reflect.Struct {
+			if err := t.enter(ptrValue); err != nil {
+				return err
+			}
+			err := findFields(fieldValue, path+pathSeparator, o, t, match, paths)
+			t.leave(ptrValue)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package attr
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindFields(t *testing.T) {
+	employee := Employee{
+		Name:    "srathi",
+		Address: &Address{City: "San Jose", Zip: "95134"},
+	}
+	employee.Manager = &Employee{Name: "boss"}
+
+	got, err := FindFields(&employee, func(path string, value interface{}) bool {
+		s, ok := value.(string)
+		return ok && strings.HasPrefix(s, "San")
+	})
+	require.Nil(t, err)
+	require.Equal(t, []string{"Address.City"}, got, "Matching fields are not correct")
+
+	got, err = FindFields(employee, func(path string, value interface{}) bool {
+		return strings.HasSuffix(path, "Name")
+	})
+	require.Nil(t, err)
+	require.Equal(t, []string{"Name", "Manager.Name"}, got, "Matching fields are not correct")
+
+	_, err = FindFields(10, func(string, interface{}) bool { return true })
+	require.Equal(t, ErrNotStruct, err, "Able to find fields in a non-struct")
+}
+
+func TestFieldsEqual(t *testing.T) {
+	employee := Employee{
+		Name:    "95134",
+		Address: &Address{City: "San Jose", Zip: "95134"},
+	}
+	got, err := FieldsEqual(&employee, "95134")
+	require.Nil(t, err)
+	require.Equal(t, []string{"Name", "Address.Zip"}, got, "Equal fields are not correct")
+
+	got, err = FieldsEqual(&employee, 95134)
+	require.Nil(t, err)
+	require.Empty(t, got, "Fields of a different type reported as equal")
+}
+
+func ExampleFieldsEqual() {
+	testUser := User{Username: "srathi", password: "secret", Age: 30}
+
+	paths, err := FieldsEqual(&testUser, 30)
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Printf("Fields with 30: %v\n", paths)
+	// Output: Fields with 30: [Age]
+}
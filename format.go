@@ -0,0 +1,95 @@
+package attr
+
+import (
+	"encoding"
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// durationType is the reflect type of time.Duration, which is formatted like
+// "1m30s" rather than as a number of nanoseconds.
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// formatValue returns the string form of a scalar value, for the APIs which
+// export a struct as text, such as ToValues. Time values are formatted with
+// the given layout and durations like "1m30s". The url.URL and net.IPNet
+// values are formatted with their String methods, and the types implementing
+// encoding.TextMarshaler with their MarshalText methods. Pointers and
+// interfaces are followed, and a nil one is formatted as an empty string.
+func formatValue(value reflect.Value, timeLayout string) (string, error) {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return "", nil
+		}
+		value = value.Elem()
+	}
+
+	if value.Type() == timeType {
+		return value.Interface().(time.Time).Format(timeLayout), nil
+	}
+
+	if value.Type() == durationType {
+		return value.Interface().(time.Duration).String(), nil
+	}
+
+	if value.Type() == urlType {
+		u := value.Interface().(url.URL)
+		return u.String(), nil
+	}
+
+	if value.Type() == ipNetType {
+		ipNet := value.Interface().(net.IPNet)
+		return ipNet.String(), nil
+	}
+
+	if name, ok := enumName(value); ok {
+		return name, nil
+	}
+
+	if marshaler, ok := value.Interface().(encoding.TextMarshaler); ok {
+		text, err := marshaler.MarshalText()
+		return string(text), err
+	}
+
+	switch value.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(value.Uint(), 10), nil
+	case reflect.Float32:
+		return strconv.FormatFloat(value.Float(), 'g', -1, 32), nil
+	case reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'g', -1, 64), nil
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Slice:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			return string(value.Bytes()), nil
+		}
+	}
+
+	return fmt.Sprint(value.Interface()), nil
+}
+
+// formatField returns the string form of the value of a struct field, like
+// formatValue, encoding a []byte field as set by its "attr" tag, such as
+// `attr:"base64"`.
+func formatField(field reflect.StructField, value reflect.Value, timeLayout string) (string, error) {
+	if text, found, err := encodeBytes(field, value); found {
+		return text, err
+	}
+	return formatValue(value, timeLayout)
+}
+
+// isTextMarshaler returns true if the value implements encoding.TextMarshaler,
+// so that it can be formatted as a single value.
+func isTextMarshaler(value reflect.Value) bool {
+	_, ok := value.Interface().(encoding.TextMarshaler)
+	return ok
+}
@@ -0,0 +1,180 @@
+package attr
+
+import (
+	"net/url"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// UnknownKeysError is returned with the WithStrict option if a source has
+// keys which don't match any struct field. Keys lists such keys in order, and
+// the error wraps ErrUnknownKey.
+type UnknownKeysError struct {
+	Keys []string
+}
+
+// Error returns the error message followed by the unknown keys.
+func (e *UnknownKeysError) Error() string {
+	return ErrUnknownKey.Error() + ": " + strings.Join(e.Keys, ", ")
+}
+
+// Unwrap returns ErrUnknownKey.
+func (e *UnknownKeysError) Unwrap() error {
+	return ErrUnknownKey
+}
+
+// keyLister is implemented by the sources which can list their keys, to check
+// them with the WithStrict option.
+type keyLister interface {
+	// unknownKeys returns the keys of the source which don't match any of
+	// the given field paths.
+	unknownKeys(paths [][]reflect.StructField) []string
+}
+
+// checkUnknownKeys returns an *UnknownKeysError if any source has keys which
+// don't match the fields looked up in it.
+func (l *layering) checkUnknownKeys() error {
+	keys := []string{}
+	for _, source := range l.sources {
+		if lister, ok := source.(keyLister); ok {
+			keys = append(keys, lister.unknownKeys(l.paths)...)
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil
+	}
+	sort.Strings(keys)
+	return &UnknownKeysError{keys}
+}
+
+// FromMap sets the exported (public) fields of a struct from a map, such as a
+// decoded JSON or YAML document. Fields are looked up like MapSource, and the
+// values are converted like Layer. Keys which don't match any field are
+// ignored, unless the WithStrict option is given.
+//
+// The fields of a struct field tagged with ",squash" (such as
+// `json:",squash"`) are looked up in the parent map, like the fields of an
+// embedded struct. Use WithSquashTag to read the option from another tag, such
+// as `mapstructure:",squash"`.
+//
+// NOTE: 'obj' struct must be passed by pointer for this API to work. Passing by
+// value results in ErrNotPtr.
+func FromMap(obj interface{}, values map[string]interface{}, tagKey string, opts ...Option) error {
+	o := newOptions(opts)
+	if o.squashTag == "" {
+		o.squashTag = tagKey
+	}
+	return layer(obj, []Source{&mapSource{values, tagKey, o.keyNaming}}, o)
+}
+
+// FromValues sets the exported (public) fields of a struct from url.Values,
+// such as the query parameters or the form of a request. Fields are looked up
+// like ValuesSource, and the values are converted like Layer. Keys which
+// don't match any field are ignored, unless the WithStrict option is given.
+// The struct fields tagged with ",squash" are flattened like FromMap.
+//
+// NOTE: 'obj' struct must be passed by pointer for this API to work. Passing by
+// value results in ErrNotPtr.
+func FromValues(obj interface{}, values url.Values, tagKey string, opts ...Option) error {
+	o := newOptions(opts)
+	if o.squashTag == "" {
+		o.squashTag = tagKey
+	}
+	return layer(obj, []Source{&valuesSource{values, tagKey, o.keyNaming}}, o)
+}
+
+// FromEnv sets the exported (public) fields of a struct from the environment
+// variables with the given prefix. Variables are looked up like EnvSource, and
+// the values are converted like Layer.
+//
+// With the WithStrict option, the variables starting with the prefix and "_"
+// which don't match any field are reported. The option is ignored if the
+// prefix is empty, as the other variables of the process can't be told apart.
+//
+// NOTE: 'obj' struct must be passed by pointer for this API to work. Passing by
+// value results in ErrNotPtr.
+func FromEnv(obj interface{}, prefix string, opts ...Option) error {
+	o := newOptions(opts)
+	return layer(obj, []Source{&envSource{prefix, o.keyNaming}}, o)
+}
+
+// unknownKeys returns the keys of the map, including the ones of the nested
+// maps as dot separated paths, which don't match any of the field paths.
+func (s *mapSource) unknownKeys(paths [][]reflect.StructField) []string {
+	known := map[string]bool{}
+	nested := map[string]bool{}
+	for _, path := range paths {
+		keys, ok := fieldKeys(path, s.tagKey, s.naming)
+		if !ok {
+			continue
+		}
+		known[strings.Join(keys, pathSeparator)] = true
+		for i := 1; i < len(keys); i++ {
+			nested[strings.Join(keys[:i], pathSeparator)] = true
+		}
+	}
+
+	unknown := []string{}
+	var walk func(values map[string]interface{}, prefix string)
+	walk = func(values map[string]interface{}, prefix string) {
+		for key, value := range values {
+			key = prefix + key
+			if known[key] {
+				continue
+			}
+			if m, ok := value.(map[string]interface{}); ok && nested[key] {
+				walk(m, key+pathSeparator)
+				continue
+			}
+			unknown = append(unknown, key)
+		}
+	}
+	walk(s.values, "")
+	return unknown
+}
+
+// unknownKeys returns the keys of url.Values which don't match any of the
+// field paths.
+func (s *valuesSource) unknownKeys(paths [][]reflect.StructField) []string {
+	known := map[string]bool{}
+	for _, path := range paths {
+		if keys, ok := fieldKeys(path, s.tagKey, s.naming); ok {
+			known[strings.Join(keys, pathSeparator)] = true
+		}
+	}
+
+	unknown := []string{}
+	for key := range s.values {
+		if !known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	return unknown
+}
+
+// unknownKeys returns the names of the environment variables with the prefix
+// which don't match any of the field paths.
+func (s *envSource) unknownKeys(paths [][]reflect.StructField) []string {
+	if s.prefix == "" {
+		return nil
+	}
+
+	known := map[string]bool{}
+	for _, path := range paths {
+		if name, ok := s.name(path); ok {
+			known[name] = true
+		}
+	}
+
+	unknown := []string{}
+	for _, env := range os.Environ() {
+		name := env[:strings.Index(env, "=")]
+		if strings.HasPrefix(name, s.prefix+envSeparator) && !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	return unknown
+}
@@ -0,0 +1,313 @@
+package attr
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromMap(t *testing.T) {
+	values := map[string]interface{}{
+		"Name":    "app",
+		"page":    "2",
+		"DB":      map[string]interface{}{"HOST": "db", "Hots": "typo"},
+		"REPLICA": map[string]interface{}{"PORT": 5433.0},
+		"Timeout": "1s",
+		"Extra":   true,
+	}
+
+	var config AppConfig
+	require.Nil(t, FromMap(&config, values, "env"), "Unknown keys reported without strict mode")
+	require.Equal(t, "db", config.DB.Host, "Nested field not set")
+	require.Equal(t, 5433, config.Replica.Port, "Field of a nested pointer not set")
+
+	config = AppConfig{}
+	err := FromMap(&config, values, "env", WithStrict())
+	require.True(t, errors.Is(err, ErrUnknownKey), "Unknown keys not reported in strict mode")
+	require.Equal(t, []string{"DB.Hots", "Extra", "Name", "Timeout", "page"}, err.(*UnknownKeysError).Keys,
+		"Unknown keys are not correct")
+
+	values = map[string]interface{}{"APP_NAME": "app", "DB": map[string]interface{}{"PORT": 1}}
+	require.Nil(t, FromMap(&config, values, "env", WithStrict()), "Known keys reported")
+
+	// A map value of a map field is not checked for unknown keys.
+	var target struct{ Labels map[string]int }
+	values = map[string]interface{}{"Labels": map[string]interface{}{"a": 1}}
+	require.Nil(t, FromMap(&target, values, "json", WithStrict()), "Keys of a map field reported")
+
+	require.Equal(t, ErrNotPtr, FromMap(config, values, "env"), "Able to set a struct passed by value")
+}
+
+type BaseModel struct {
+	ID   int    `json:"id"`
+	Kind string `json:"kind"`
+}
+
+type Listing struct {
+	Base    *BaseModel `json:"base" mapstructure:",squash"`
+	Where   Address    `json:"where" mapstructure:",squash"`
+	Title   string     `json:"title"`
+	Details Address    `json:"details"`
+}
+
+func TestFromMapSquash(t *testing.T) {
+	values := map[string]interface{}{
+		"id":      7,
+		"kind":    "flat",
+		"city":    "Pune",
+		"title":   "Sunny",
+		"details": map[string]interface{}{"zip": "411001"},
+	}
+
+	var listing Listing
+	err := FromMap(&listing, values, "json", WithSquashTag("mapstructure"), WithStrict())
+	require.Nil(t, err)
+	require.Equal(t, Listing{
+		Base:    &BaseModel{ID: 7, Kind: "flat"},
+		Where:   Address{City: "Pune"},
+		Title:   "Sunny",
+		Details: Address{Zip: "411001"},
+	}, listing, "Squashed struct is not correct")
+
+	// Without the option, the squashed fields are looked up in nested maps.
+	listing = Listing{}
+	err = FromMap(&listing, values, "json")
+	require.Nil(t, err)
+	require.Nil(t, listing.Base, "Nil pointer is allocated without a value")
+	require.Equal(t, Address{}, listing.Where, "Nested struct is not correct")
+
+	// The ",squash" option is read from the tag of the map keys by default.
+	type Config struct {
+		Page `conf:",squash"`
+		Name string `conf:"name"`
+	}
+	var config Config
+	err = FromMap(&config, map[string]interface{}{"Number": 3, "name": "c"}, "conf")
+	require.Nil(t, err)
+	require.Equal(t, Config{Page{Number: 3}, "c"}, config, "Squashed embedded struct is not correct")
+}
+
+func TestFromValues(t *testing.T) {
+	var search Search
+	values := url.Values{"q": {"go"}, "page": {"3"}, "range.size": {"10"}, "sort": {"asc"}}
+	require.Nil(t, FromValues(&search, values, "url"))
+	require.Equal(t, "go", search.Query, "Field not set")
+	require.Equal(t, 10, search.Range.Size, "Nested field not set")
+
+	err := FromValues(&search, values, "url", WithStrict())
+	require.Equal(t, &UnknownKeysError{[]string{"sort"}}, err, "Unknown keys are not correct")
+}
+
+func TestFromEnv(t *testing.T) {
+	os.Setenv("TEST_FROM_DB_HOST", "db")
+	os.Setenv("TEST_FROM_DB_HSOT", "typo")
+	defer os.Unsetenv("TEST_FROM_DB_HOST")
+	defer os.Unsetenv("TEST_FROM_DB_HSOT")
+
+	var config AppConfig
+	require.Nil(t, FromEnv(&config, "TEST_FROM"))
+	require.Equal(t, "db", config.DB.Host, "Field not set from the environment")
+
+	err := FromEnv(&config, "TEST_FROM", WithStrict())
+	require.Equal(t, &UnknownKeysError{[]string{"TEST_FROM_DB_HSOT"}}, err,
+		"Unknown variables are not correct")
+
+	// Strict mode is ignored without a prefix.
+	require.Nil(t, FromEnv(&config, "", WithStrict()))
+}
+
+type ServerConfig struct {
+	Bind     net.IP       `url:"bind"`
+	Allow    net.IPNet    `url:"allow"`
+	Upstream url.URL      `url:"upstream"`
+	Proxy    *url.URL     `url:"proxy"`
+	ID       [16]byte     `url:"id"`
+	Since    time.Time    `url:"since"`
+	Peers    []net.IP     `url:"peers"`
+	Nets     []*net.IPNet `url:"nets"`
+}
+
+func TestFromBuiltinTypes(t *testing.T) {
+	values := url.Values{
+		"bind":     {"10.0.0.1"},
+		"allow":    {"192.168.0.0/16"},
+		"upstream": {"https://example.com:8443/api?v=1"},
+		"proxy":    {"http://proxy:3128"},
+		"id":       {"123e4567-e89b-12d3-a456-426614174000"},
+		"since":    {"2024-03-01"},
+		"peers":    {"10.0.0.2", "10.0.0.3"},
+		"nets":     {"10.0.0.0/8"},
+	}
+
+	var config ServerConfig
+	require.NoError(t, FromValues(&config, values, "url"))
+	require.Equal(t, net.ParseIP("10.0.0.1"), config.Bind)
+	require.Equal(t, "192.168.0.0/16", config.Allow.String())
+	require.Equal(t, "example.com:8443", config.Upstream.Host)
+	require.Equal(t, "proxy:3128", config.Proxy.Host)
+	require.Equal(t, [16]byte{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00},
+		config.ID)
+	require.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), config.Since)
+	require.Equal(t, []net.IP{net.ParseIP("10.0.0.2"), net.ParseIP("10.0.0.3")}, config.Peers)
+	require.Equal(t, "10.0.0.0/8", config.Nets[0].String())
+
+	// The values are formatted back to the same strings.
+	got, err := ToValues(config, "url")
+	require.NoError(t, err)
+	for _, key := range []string{"bind", "allow", "upstream", "proxy"} {
+		require.Equal(t, values.Get(key), got.Get(key), key)
+	}
+
+	os.Setenv("TEST_SERVER_UPSTREAM", "https://example.org")
+	os.Setenv("TEST_SERVER_ID", "123e4567e89b12d3a456426614174000")
+	defer os.Unsetenv("TEST_SERVER_UPSTREAM")
+	defer os.Unsetenv("TEST_SERVER_ID")
+
+	config = ServerConfig{}
+	require.NoError(t, FromEnv(&config, "TEST_SERVER"))
+	require.Equal(t, "example.org", config.Upstream.Host)
+	require.Equal(t, byte(0x12), config.ID[0])
+
+	for key, value := range map[string]string{
+		"bind":  "10.0.0.300",
+		"allow": "10.0.0.1",
+		"id":    "123e4567-e89b-12d3-a456-42661417400z",
+		"since": "March 1st",
+		"proxy": "http://[::1",
+	} {
+		err := FromValues(&ServerConfig{}, url.Values{key: {value}}, "url")
+		require.True(t, errors.Is(err, ErrInvalidValue), "%s: %v", key, err)
+	}
+
+	// A set layout is used on its own.
+	err = FromValues(&config, url.Values{"since": {"2024-03-01"}}, "url", WithTimeLayout(time.RFC3339))
+	require.NoError(t, err)
+	err = FromValues(&config, url.Values{"since": {"2024-03-01"}}, "url", WithTimeLayout(time.Kitchen))
+	require.True(t, errors.Is(err, ErrInvalidValue))
+}
+
+func ExampleWithStrict() {
+	// type DBConfig struct {
+	// 	Host     string `env:"HOST"`
+	// 	Port     int    `env:"PORT"`
+	// 	MaxConns int
+	// }
+	values := map[string]interface{}{"HOST": "localhost", "PROT": 5432}
+
+	var config DBConfig
+	err := FromMap(&config, values, "env", WithStrict())
+	fmt.Println(err)
+	// Output: Specified key doesn't match any struct field: PROT
+}
+
+func TestWithWeakTypes(t *testing.T) {
+	type Target struct {
+		Count   int
+		Enabled bool
+		Active  bool
+		Version string
+		Flag    string
+		Raw     string
+		Hosts   []string
+		Ports   []int
+		Empty   uint
+		Score   float64
+	}
+	values := map[string]interface{}{
+		"Count":   true,
+		"Enabled": 1.0,
+		"Active":  0,
+		"Version": 1.5,
+		"Flag":    false,
+		"Raw":     []byte("abc"),
+		"Hosts":   "a, b",
+		"Ports":   8080.0,
+		"Empty":   "",
+		"Score":   false,
+	}
+
+	var target Target
+	err := FromMap(&target, values, "json")
+	require.True(t, errors.Is(err, ErrMismatchValue), "Weak conversion done without the option")
+
+	require.Nil(t, FromMap(&target, values, "json", WithWeakTypes()))
+	require.Equal(t, Target{
+		Count:   1,
+		Enabled: true,
+		Active:  false,
+		Version: "1.5",
+		Flag:    "false",
+		Raw:     "abc",
+		Hosts:   []string{"a", "b"},
+		Ports:   []int{8080},
+		Empty:   0,
+		Score:   0,
+	}, target, "Weakly typed values are not converted correctly")
+
+	err = FromMap(&target, map[string]interface{}{"Count": []int{1}}, "json", WithWeakTypes())
+	require.True(t, errors.Is(err, ErrMismatchValue), "Able to convert a slice to a number")
+}
+
+func TestFromMapOverflow(t *testing.T) {
+	type Target struct {
+		Small  int8
+		Count  uint
+		Size   int
+		Ratio  float32
+		Shifts []int16
+	}
+
+	var target Target
+	values := map[string]interface{}{"Small": 100, "Count": 7.0, "Size": int64(1) << 40, "Ratio": 0.5}
+	require.Nil(t, FromMap(&target, values, "json"))
+	require.Equal(t, Target{Small: 100, Count: 7, Size: 1 << 40, Ratio: 0.5}, target, "Numbers are not converted")
+
+	tests := []struct {
+		key   string
+		value interface{}
+		field string
+	}{
+		{"Small", int64(300), "Small"},
+		{"Count", -1, "Count"},
+		{"Size", 1.5, "Size"},
+		{"Ratio", 1e300, "Ratio"},
+		{"Shifts", []interface{}{1, 70000}, "Shifts"},
+	}
+	for _, test := range tests {
+		err := FromMap(&target, map[string]interface{}{test.key: test.value}, "json")
+		require.True(t, errors.Is(err, ErrOverflow), "Overflow of %v not reported", test.value)
+
+		var fieldErr *FieldError
+		require.True(t, errors.As(err, &fieldErr))
+		require.Equal(t, test.field, fieldErr.Field, "Field of the overflow is not correct")
+	}
+
+	err := FromMap(&target, map[string]interface{}{"Small": 128}, "json")
+	var overflowErr *OverflowError
+	require.True(t, errors.As(err, &overflowErr))
+	require.Equal(t, &OverflowError{128, reflect.TypeOf(int8(0))}, overflowErr, "Overflow error is not correct")
+	require.Equal(t, "Small: 128 overflows int8", err.Error(), "Overflow error message is not correct")
+}
+
+func ExampleWithWeakTypes() {
+	// type DBConfig struct {
+	// 	Host     string `env:"HOST"`
+	// 	Port     int    `env:"PORT"`
+	// 	MaxConns int
+	// }
+	values := map[string]interface{}{"HOST": 127, "PORT": "5432", "MaxConns": true}
+
+	var config DBConfig
+	if err := FromMap(&config, values, "env", WithWeakTypes()); err != nil {
+		// Handle error.
+	}
+	fmt.Printf("%+v\n", config)
+	// Output: {Host:127 Port:5432 MaxConns:1}
+}
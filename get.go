@@ -0,0 +1,241 @@
+package attr
+
+import (
+	"reflect"
+	"strings"
+)
+
+// TryGet returns the value of a field of a struct given by a dot separated
+// path of field names, such as "Address.City", as the type parameter T. It
+// never returns an error; the zero value of T and false are returned instead
+// if the field can't be read for any reason, including a nil pointer in the
+// path or a field value which is not of type T.
+//
+// This is useful where a missing value is expected, such as in templates or
+// while logging. Use WithInterfaces to follow the interface fields in the path.
+func TryGet[T any](obj interface{}, path string, opts ...Option) (T, bool) {
+	var result T
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return result, false
+	}
+
+	fieldValue, _, err := walkPath(objValue, path, followInterfaces(opts))
+	if err != nil {
+		return result, false
+	}
+
+	resultValue := reflect.ValueOf(&result).Elem()
+	if fieldValue.Type().AssignableTo(resultValue.Type()) {
+		resultValue.Set(fieldValue)
+		return result, true
+	}
+
+	// An interface field can hold a value of type T.
+	result, ok := fieldValue.Interface().(T)
+	return result, ok
+}
+
+// GetOk returns the value of a field of a struct given by a dot separated path
+// of field names, such as "Address.City", and true. If the field can't be read
+// for any reason, including a nil pointer in the path, nil and false are
+// returned without allocating an error. Use RawField to find out why a field
+// can't be read. Use WithInterfaces to follow the interface fields in the path.
+func GetOk(obj interface{}, path string, opts ...Option) (interface{}, bool) {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return nil, false
+	}
+
+	fieldValue, _, err := walkPath(objValue, path, followInterfaces(opts))
+	if err != nil {
+		return nil, false
+	}
+	return fieldValue.Interface(), true
+}
+
+// RawField returns the reflect.StructField and the reflect.Value of a field of
+// a struct given by a dot separated path of field names, after the same
+// validation as GetValue. It is an escape hatch to use the reflect package
+// directly where this package falls short.
+//
+// The returned value can be set only if 'obj' is passed by pointer (or the
+// path goes through a pointer), as reported by its CanSet method. A nil pointer
+// in the path is reported by a *NilPathError. Use WithInterfaces to follow
+// the interface fields in the path. For a path ending with an index, such as
+// "Tags[0]", the element value is returned along with the slice or array field.
+func RawField(obj interface{}, path string, opts ...Option) (reflect.StructField, reflect.Value, error) {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return reflect.StructField{}, reflect.Value{}, err
+	}
+
+	interfaces := followInterfaces(opts)
+	fieldValue, err := getFieldByPath(objValue, path, interfaces)
+	if err != nil {
+		return reflect.StructField{}, reflect.Value{}, err
+	}
+
+	parentValue, name := objValue, path
+	if idx := strings.LastIndex(path, pathSeparator); idx != -1 {
+		// The parent path is already validated along with the field value.
+		parentValue, _ = getFieldByPath(objValue, path[:idx], interfaces)
+		if parentValue.Kind() == reflect.Interface {
+			parentValue = parentValue.Elem()
+		}
+		parentValue, name = reflect.Indirect(parentValue), path[idx+1:]
+	}
+
+	if bracket := strings.IndexByte(name, '['); bracket != -1 {
+		name = name[:bracket]
+	}
+	field, _ := cachedField(parentValue.Type(), name)
+	return field, fieldValue, nil
+}
+
+// followInterfaces returns true if the WithInterfaces option is given. Unlike
+// newOptions, it doesn't allocate if no option is given.
+func followInterfaces(opts []Option) bool {
+	return len(opts) > 0 && newOptions(opts).interfaces
+}
+
+// GetPathAny returns the value at a path in a tree of structs, maps and
+// slices, such as the map[string]interface{} and []interface{} values decoded
+// by encoding/json, or structs mixed with them. The path is a dot separated
+// list of struct field names or map keys, each optionally followed by indexes
+// of slice or array elements, such as "a.b[2].c". A path starting with an
+// index, such as "[0].name", indexes 'v' itself.
+//
+// A struct field is matched by its name, or else by its json tag name, so the
+// same path works on a struct and on its decoded JSON. Maps must have string
+// keys. The pointers and interfaces in the path are followed, and a nil one
+// is reported by a *NilPathError. ErrNoKey is returned for a missing map key,
+// ErrNoField for a missing struct field, ErrMismatchValue for a name applied to
+// a slice or an array (instead of an index), ErrNotStruct for a name applied
+// to any other value which is neither a struct nor a map, and ErrNotSlice,
+// ErrOutOfRange or ErrInvalidExpr for a bad index. ErrInvalidExpr is returned
+// for an empty path segment too, such as in "a..b".
+func GetPathAny(v interface{}, path string) (interface{}, error) {
+	value := valueOf(v)
+	if !value.IsValid() {
+		return nil, ErrNilPointer
+	}
+
+	segments := strings.Split(path, pathSeparator)
+	for i, segment := range segments {
+		if segment == "" || (i > 0 && segment[0] == '[') {
+			return nil, ErrInvalidExpr
+		}
+	}
+
+	for i, segment := range segments {
+		name, indexes := segment, ""
+		if bracket := strings.IndexByte(segment, '['); bracket != -1 {
+			name, indexes = segment[:bracket], segment[bracket:]
+		}
+
+		if name != "" || i > 0 {
+			elem, ok := anyElem(value)
+			if !ok {
+				return nil, anyPathError(ErrNilPointer, segments, i)
+			}
+			var err error
+			if value, err = anyChild(elem, name); err != nil {
+				// A field promoted through a nil embedded pointer.
+				return nil, anyPathError(err, segments, i+1)
+			}
+		}
+
+		for indexes != "" {
+			index, size, ok := parseIndex(indexes)
+			if !ok {
+				return nil, ErrInvalidExpr
+			}
+			indexes = indexes[size:]
+
+			elem, ok := anyElem(value)
+			if !ok {
+				return nil, anyPathError(ErrNilPointer, segments, i+1)
+			}
+			if elem.Kind() != reflect.Slice && elem.Kind() != reflect.Array {
+				return nil, ErrNotSlice
+			}
+			if index >= elem.Len() {
+				return nil, ErrOutOfRange
+			}
+			value = elem.Index(index)
+		}
+	}
+
+	return value.Interface(), nil
+}
+
+// anyChild returns the field of a struct or the value of a map with the given
+// name, for GetPathAny. It returns ErrNilPointer if the field is promoted
+// through a nil embedded pointer.
+func anyChild(value reflect.Value, name string) (reflect.Value, error) {
+	switch value.Kind() {
+	case reflect.Map:
+		keyType := value.Type().Key()
+		if keyType.Kind() != reflect.String {
+			return reflect.Value{}, ErrNotStruct
+		}
+		elemValue := value.MapIndex(reflect.ValueOf(name).Convert(keyType))
+		if !elemValue.IsValid() {
+			return reflect.Value{}, ErrNoKey
+		}
+		return elemValue, nil
+
+	case reflect.Slice, reflect.Array:
+		// A slice or an array needs an index, not a name.
+		return reflect.Value{}, ErrMismatchValue
+
+	case reflect.Struct:
+		fieldValue, err := fieldByName(value, name)
+		if err == ErrNilPointer {
+			return reflect.Value{}, err
+		}
+		if !fieldValue.IsValid() {
+			for _, member := range cachedJSONMembers(value.Type()) {
+				if member.name == name {
+					var ok bool
+					fieldValue, ok = fieldByIndex(value, member.index)
+					if !ok {
+						return reflect.Value{}, ErrNilPointer
+					}
+					break
+				}
+			}
+		}
+		if !fieldValue.IsValid() {
+			return reflect.Value{}, ErrNoField
+		}
+		if !fieldValue.CanInterface() {
+			return reflect.Value{}, ErrUnexportedField
+		}
+		return fieldValue, nil
+	}
+
+	return reflect.Value{}, ErrNotStruct
+}
+
+// anyElem follows the pointers and interfaces to the value they hold. It
+// returns false if one of them is nil.
+func anyElem(value reflect.Value) (reflect.Value, bool) {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return value, false
+		}
+		value = value.Elem()
+	}
+	return value, value.IsValid()
+}
+
+// anyPathError returns a *NilPathError for ErrNilPointer at the given number
+// of segments of a path, and the other errors as they are.
+func anyPathError(err error, segments []string, segment int) error {
+	if err != ErrNilPointer || segment == 0 {
+		return err
+	}
+	return &NilPathError{strings.Join(segments[:segment], pathSeparator), segment}
+}
@@ -0,0 +1,289 @@
+package attr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTryGet(t *testing.T) {
+	name, ok := TryGet[string](&user, "Username")
+	require.True(t, ok)
+	require.Equal(t, "srathi", name, "Field value mismatch")
+
+	employee := Employee{Name: "bob", Address: &Address{City: "Pune"}, Extra: 42}
+	city, ok := TryGet[string](employee, "Address.City")
+	require.True(t, ok)
+	require.Equal(t, "Pune", city, "Nested field value mismatch")
+
+	extra, ok := TryGet[int](employee, "Extra")
+	require.True(t, ok)
+	require.Equal(t, 42, extra, "Interface field value mismatch")
+
+	manager, ok := TryGet[*Employee](employee, "Manager")
+	require.True(t, ok)
+	require.Nil(t, manager, "Nil pointer field value mismatch")
+
+	for _, path := range []string{"Manager.Name", "password", "Missing", "Name.Length"} {
+		value, ok := TryGet[string](employee, path)
+		require.False(t, ok, "Able to get an invalid path %q", path)
+		require.Equal(t, "", value, "Zero value not returned for %q", path)
+	}
+
+	_, ok = TryGet[int](employee, "Name")
+	require.False(t, ok, "Able to get a field of a different type")
+
+	_, ok = TryGet[int](10, "Name")
+	require.False(t, ok, "Able to get a field of a non-struct")
+}
+
+func ExampleTryGet() {
+	testUser := User{Username: "srathi", password: "secret", Age: 30}
+
+	age, ok := TryGet[int](&testUser, "Age")
+	fmt.Println(age, ok)
+
+	email, ok := TryGet[string](&testUser, "Email")
+	fmt.Printf("%q %v\n", email, ok)
+	// Output:
+	// 30 true
+	// "" false
+}
+
+func TestGetOk(t *testing.T) {
+	employee := Employee{Name: "srathi", Address: &Address{City: "San Jose"}}
+	value, ok := GetOk(employee, "Address.City")
+	require.True(t, ok, "Unable to get a nested field")
+	require.Equal(t, "San Jose", value, "Nested field value mismatch")
+
+	for _, path := range []string{"Manager.Name", "Name.ABC", "internal", "ABC", "Address."} {
+		value, ok = GetOk(&employee, path)
+		require.False(t, ok, "Able to get %q", path)
+		require.Nil(t, value, "Value returned for %q", path)
+	}
+
+	_, ok = GetOk(10, "Name")
+	require.False(t, ok, "Able to get a field of a non-struct")
+
+	allocs := testing.AllocsPerRun(10, func() { GetOk(&employee, "Manager.Name") })
+	require.Zero(t, allocs, "Missing field allocates")
+}
+
+func ExampleGetOk() {
+	employee := Employee{Name: "srathi"}
+
+	if _, ok := GetOk(employee, "Manager.Name"); !ok {
+		fmt.Println("No manager")
+	}
+	_, _, err := RawField(employee, "Manager.Name")
+	fmt.Println(err)
+	// Output:
+	// No manager
+	// Manager is nil at segment 1
+}
+
+func TestRawField(t *testing.T) {
+	testUser := user
+	field, value, err := RawField(&testUser, "Username")
+	require.Nil(t, err)
+	require.Equal(t, `json:"username" db:"uname"`, string(field.Tag), "Struct field mismatch")
+	require.True(t, value.CanSet(), "Field of a struct passed by pointer can't be set")
+	value.SetString("new-username")
+	require.Equal(t, "new-username", testUser.Username, "Field not set through reflect")
+
+	_, value, err = RawField(testUser, "Age")
+	require.Nil(t, err)
+	require.False(t, value.CanSet(), "Field of a struct passed by value can be set")
+
+	employee := Employee{Address: &Address{City: "Pune"}}
+	field, value, err = RawField(employee, "Address.City")
+	require.Nil(t, err)
+	require.Equal(t, "City", field.Name, "Nested struct field mismatch")
+	require.Equal(t, []int{0}, field.Index, "Nested struct field index mismatch")
+	require.Equal(t, reflect.String, value.Kind(), "Nested field value mismatch")
+	require.True(t, value.CanSet(), "Field reached through a pointer can't be set")
+
+	_, _, err = RawField(&testUser, "password")
+	require.Equal(t, ErrUnexportedField, err, "Able to get an unexported field")
+
+	_, _, err = RawField(employee, "Manager.Name")
+	require.Equal(t, &NilPathError{"Manager", 1}, err, "Able to get a field through a nil pointer")
+	require.True(t, errors.Is(err, ErrNilPointer), "Nil path error doesn't wrap ErrNilPointer")
+	require.Equal(t, "Manager is nil at segment 1", err.Error(), "Nil path error message is not correct")
+
+	employee.Manager = &Employee{}
+	_, _, err = RawField(employee, "Manager.Address.City")
+	require.Equal(t, &NilPathError{"Manager.Address", 2}, err, "Nil segment of a deep path is not correct")
+}
+
+func ExampleRawField() {
+	testUser := User{Username: "srathi", password: "secret", Age: 30}
+
+	field, value, err := RawField(&testUser, "Age")
+	if err != nil {
+		// Handle error.
+	}
+	value.SetInt(value.Int() + 1)
+	fmt.Printf("%s (%s): %d\n", field.Name, field.Tag.Get("json"), testUser.Age)
+	// Output: Age (age): 31
+}
+
+func TestGetPathAny(t *testing.T) {
+	var decoded interface{}
+	err := json.Unmarshal([]byte(`{"a": {"b": [1, 2, {"c": "deep"}], "n": null}, "list": [{"name": "x"}]}`), &decoded)
+	require.NoError(t, err)
+
+	value, err := GetPathAny(decoded, "a.b[2].c")
+	require.NoError(t, err)
+	require.Equal(t, "deep", value)
+
+	value, err = GetPathAny(decoded, "a.b[1]")
+	require.NoError(t, err)
+	require.Equal(t, float64(2), value)
+
+	value, err = GetPathAny(decoded.(map[string]interface{})["list"], "[0].name")
+	require.NoError(t, err)
+	require.Equal(t, "x", value)
+
+	value, err = GetPathAny(decoded, "a.n")
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	_, err = GetPathAny(decoded, "a.n.x")
+	var nilErr *NilPathError
+	require.True(t, errors.As(err, &nilErr))
+	require.Equal(t, &NilPathError{"a.n", 2}, nilErr)
+
+	_, err = GetPathAny(decoded, "a.missing")
+	require.Equal(t, ErrNoKey, err)
+
+	_, err = GetPathAny(decoded, "a.b[3]")
+	require.Equal(t, ErrOutOfRange, err)
+
+	_, err = GetPathAny(decoded, "a.b[x]")
+	require.Equal(t, ErrInvalidExpr, err)
+
+	_, err = GetPathAny(decoded, "a[0]")
+	require.Equal(t, ErrNotSlice, err)
+
+	_, err = GetPathAny(decoded, "a.b[0].c")
+	require.Equal(t, ErrNotStruct, err)
+
+	_, err = GetPathAny(decoded, "a.b.c")
+	require.Equal(t, ErrMismatchValue, err)
+
+	for _, path := range []string{"a..b", "", ".a", "a.", "a.[0]"} {
+		_, err = GetPathAny(decoded, path)
+		require.Equal(t, ErrInvalidExpr, err, "Error is not correct for %q", path)
+	}
+
+	// Structs are mixed with maps and slices, by field name or json tag name.
+	type Envelope struct {
+		Meta    map[string]interface{}
+		Payload interface{} `json:"payload"`
+		Users   []*User
+		secret  string
+	}
+	envelope := &Envelope{
+		Meta:    map[string]interface{}{"user": &user},
+		Payload: map[string]interface{}{"ids": []int{4, 5}},
+		Users:   []*User{nil},
+	}
+
+	value, err = GetPathAny(envelope, "Meta.user.Username")
+	require.NoError(t, err)
+	require.Equal(t, "srathi", value)
+
+	value, err = GetPathAny(envelope, "Meta.user.age")
+	require.NoError(t, err)
+	require.Equal(t, 30, value)
+
+	value, err = GetPathAny(envelope, "payload.ids[1]")
+	require.NoError(t, err)
+	require.Equal(t, 5, value)
+
+	_, err = GetPathAny(envelope, "Users[0].Age")
+	require.Equal(t, &NilPathError{"Users[0]", 1}, err)
+
+	_, err = GetPathAny(envelope, "secret")
+	require.Equal(t, ErrUnexportedField, err)
+
+	_, err = GetPathAny(envelope, "Missing")
+	require.Equal(t, ErrNoField, err)
+
+	_, err = GetPathAny(map[int]string{1: "a"}, "1")
+	require.Equal(t, ErrNotStruct, err)
+
+	_, err = GetPathAny(nil, "a")
+	require.Equal(t, ErrNilPointer, err)
+
+	_, err = GetPathAny((*User)(nil), "Age")
+	require.Equal(t, ErrNilPointer, err)
+}
+
+func ExampleGetPathAny() {
+	var decoded interface{}
+	_ = json.Unmarshal([]byte(`{"order": {"items": [{"sku": "A1"}, {"sku": "B2"}]}}`), &decoded)
+
+	sku, err := GetPathAny(decoded, "order.items[1].sku")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(sku)
+
+	// Output:
+	// B2
+}
+
+func TestWithInterfaces(t *testing.T) {
+	employee := Employee{Name: "srathi", Extra: &Address{City: "San Jose"}}
+	_, ok := GetOk(employee, "Extra.City")
+	require.False(t, ok, "Able to get a field of an interface without WithInterfaces")
+
+	value, ok := GetOk(employee, "Extra.City", WithInterfaces())
+	require.True(t, ok, "Unable to get a field of a struct held by an interface")
+	require.Equal(t, "San Jose", value, "Field value mismatch")
+
+	field, fieldValue, err := RawField(&employee, "Extra.City", WithInterfaces())
+	require.Nil(t, err)
+	require.Equal(t, `json:"city" validate:"required"`, string(field.Tag), "Field of the dynamic type mismatch")
+	require.True(t, fieldValue.CanSet(), "Field reached through a pointer can't be set")
+
+	city, ok := TryGet[string](employee, "Extra.City", WithInterfaces())
+	require.True(t, ok, "Unable to get a typed field of a struct held by an interface")
+	require.Equal(t, "San Jose", city, "Typed field value mismatch")
+
+	paths, err := FieldsEqual(employee, "San Jose", WithInterfaces())
+	require.Nil(t, err)
+	require.Equal(t, []string{"Extra.City"}, paths, "Field of an interface not searched")
+
+	values, err := ToValues(employee, "json", WithInterfaces())
+	require.Nil(t, err)
+	require.Equal(t, "San Jose", values.Get("extra.city"), "Field of an interface not added")
+
+	employee.Extra = Address{}
+	missing, err := CheckRequired(employee, WithInterfaces())
+	require.Nil(t, err)
+	require.Equal(t, []string{"Extra.City"}, missing, "Field of an interface not checked")
+
+	employee.Extra = nil
+	_, _, err = RawField(employee, "Extra.City", WithInterfaces())
+	require.Equal(t, &NilPathError{"Extra", 1}, err, "Able to get a field of a nil interface")
+}
+
+func ExampleWithInterfaces() {
+	type Event struct {
+		Kind    string
+		Payload interface{}
+	}
+	event := Event{Kind: "signup", Payload: User{Username: "srathi"}}
+
+	name, ok := GetOk(event, "Payload.Username", WithInterfaces())
+	fmt.Println(name, ok)
+	// Output: srathi true
+}
@@ -0,0 +1,184 @@
+package attr
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// graphEdge is a field of a struct type referring to another struct type,
+// directly, through a pointer, or as the elements of a slice, an array or a
+// map ('many').
+type graphEdge struct {
+	from, to reflect.Type
+	field    string
+	many     bool
+}
+
+// structGraph is the graph of the struct types reachable from a struct type
+// through its exported fields, in the order they are found.
+type structGraph struct {
+	types []reflect.Type
+	edges []graphEdge
+}
+
+// newStructGraph builds the graph of the struct types reachable from a struct
+// type, visiting the fields breadth first in their declaration order.
+func newStructGraph(objType reflect.Type) *structGraph {
+	g := &structGraph{types: []reflect.Type{objType}}
+	seen := map[reflect.Type]bool{objType: true}
+	for i := 0; i < len(g.types); i++ {
+		typ := g.types[i]
+		for _, field := range exportedFields(typ) {
+			target, many := graphTarget(field.Type)
+			if target == nil {
+				continue
+			}
+
+			g.edges = append(g.edges, graphEdge{typ, target, field.Name, many})
+			if !seen[target] {
+				seen[target] = true
+				g.types = append(g.types, target)
+			}
+		}
+	}
+	return g
+}
+
+// exportedFields returns the exported fields of a struct type, in their
+// declaration order.
+func exportedFields(typ reflect.Type) []reflect.StructField {
+	fields := []reflect.StructField{}
+	for i := 0; i < typ.NumField(); i++ {
+		if field := typ.Field(i); field.PkgPath == "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// graphTarget returns the struct type referred to by a field type, after
+// following the pointers and the element types of the slices, arrays and maps,
+// and whether there can be many of them. It returns nil if the field doesn't
+// refer to a struct type, or refers to time.Time.
+func graphTarget(typ reflect.Type) (reflect.Type, bool) {
+	many := false
+	for {
+		switch typ.Kind() {
+		case reflect.Ptr:
+			typ = typ.Elem()
+			continue
+		case reflect.Slice, reflect.Array, reflect.Map:
+			typ, many = typ.Elem(), true
+			continue
+		case reflect.Struct:
+			if typ == timeType {
+				return nil, false
+			}
+			return typ, many
+		}
+		return nil, false
+	}
+}
+
+// Graph returns a Graphviz DOT diagram of a struct and the struct types of its
+// nested fields, such as for the model diagrams of a design document. Each
+// struct type is a node listing its exported (public) fields with their types,
+// and each field referring to another struct type (directly, through a
+// pointer, or as the elements of a slice, an array or a map) is an edge
+// labeled with the field name. The edges of the slices, arrays and maps are
+// labeled with a "[]" suffix, such as "Reports[]".
+//
+// Use GraphMermaid for a Mermaid class diagram. 'obj' can be passed by value
+// or by pointer.
+func Graph(obj interface{}) (string, error) {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return "", err
+	}
+
+	g := newStructGraph(objValue.Type())
+	var dot strings.Builder
+	fmt.Fprintf(&dot, "digraph %q {\n", graphName(objValue.Type()))
+	dot.WriteString("  node [shape=record];\n")
+	for _, typ := range g.types {
+		lines := []string{}
+		for _, field := range exportedFields(typ) {
+			lines = append(lines, dotEscape(field.Name+" "+field.Type.String())+`\l`)
+		}
+		fmt.Fprintf(&dot, "  %q [label=\"{%s|%s}\"];\n", typ.String(),
+			dotEscape(graphName(typ)), strings.Join(lines, ""))
+	}
+	for _, edge := range g.edges {
+		label := edge.field
+		if edge.many {
+			label += "[]"
+		}
+		fmt.Fprintf(&dot, "  %q -> %q [label=%q];\n", edge.from.String(), edge.to.String(), label)
+	}
+	dot.WriteString("}\n")
+	return dot.String(), nil
+}
+
+// GraphMermaid is the same as Graph, but returns a Mermaid class diagram. The
+// edges of the slices, arrays and maps have a "*" multiplicity.
+func GraphMermaid(obj interface{}) (string, error) {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return "", err
+	}
+
+	g := newStructGraph(objValue.Type())
+	ids := map[reflect.Type]string{}
+	for i, typ := range g.types {
+		ids[typ] = mermaidID(typ, i)
+	}
+
+	var diagram strings.Builder
+	diagram.WriteString("classDiagram\n")
+	for _, typ := range g.types {
+		fmt.Fprintf(&diagram, "  class %s {\n", ids[typ])
+		for _, field := range exportedFields(typ) {
+			fmt.Fprintf(&diagram, "    %s %s\n", mermaidType(field.Type), field.Name)
+		}
+		diagram.WriteString("  }\n")
+	}
+	for _, edge := range g.edges {
+		multiplicity := ""
+		if edge.many {
+			multiplicity = `"*" `
+		}
+		fmt.Fprintf(&diagram, "  %s --> %s%s : %s\n", ids[edge.from], multiplicity, ids[edge.to], edge.field)
+	}
+	return diagram.String(), nil
+}
+
+// graphName returns the name of a struct type for a diagram, or "struct" for
+// an anonymous struct type.
+func graphName(typ reflect.Type) string {
+	if typ.Name() == "" {
+		return "struct"
+	}
+	return typ.Name()
+}
+
+// dotEscape escapes the characters with a special meaning in the record labels
+// of DOT.
+var dotEscape = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "{", `\{`, "}", `\}`,
+	"|", `\|`, "<", `\<`, ">", `\>`).Replace
+
+// mermaidID returns the identifier of a struct type in a Mermaid diagram. The
+// identifiers of the anonymous struct types are numbered by their position.
+func mermaidID(typ reflect.Type, position int) string {
+	if typ.Name() == "" || strings.ContainsAny(typ.Name(), "[],") {
+		return fmt.Sprintf("Struct%d", position)
+	}
+	return typ.Name()
+}
+
+// mermaidType returns the name of a field type for a Mermaid class diagram,
+// without the braces which Mermaid doesn't allow in a class body.
+func mermaidType(typ reflect.Type) string {
+	name := strings.ReplaceAll(typ.String(), "interface {}", "any")
+	return strings.NewReplacer("{", "(", "}", ")").Replace(name)
+}
@@ -0,0 +1,79 @@
+package attr
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraph(t *testing.T) {
+	want := `digraph "Manager" {
+  node [shape=record];
+  "attr.Manager" [label="{Manager|User attr.User\lReports []*attr.User\l}"];
+  "attr.User" [label="{User|Username string\lAge int\l}"];
+  "attr.Manager" -> "attr.User" [label="User"];
+  "attr.Manager" -> "attr.User" [label="Reports[]"];
+}
+`
+	got, err := Graph(&Manager{})
+	require.Nil(t, err)
+	require.Equal(t, want, got, "DOT diagram is not correct")
+
+	got, err = Graph(struct{ Extra interface{} }{})
+	require.Nil(t, err)
+	require.Contains(t, got, `[label="{struct|Extra interface \{\}\l}"]`, "DOT label is not escaped")
+
+	_, err = Graph(10)
+	require.Equal(t, ErrNotStruct, err, "Able to draw a non-struct")
+}
+
+func TestGraphMermaid(t *testing.T) {
+	want := `classDiagram
+  class Manager {
+    attr.User User
+    []*attr.User Reports
+  }
+  class User {
+    string Username
+    int Age
+  }
+  Manager --> User : User
+  Manager --> "*" User : Reports
+`
+	got, err := GraphMermaid(Manager{})
+	require.Nil(t, err)
+	require.Equal(t, want, got, "Mermaid diagram is not correct")
+
+	got, err = GraphMermaid(&Employee{})
+	require.Nil(t, err)
+	require.Contains(t, got, "    any Extra\n", "Interface type is not simplified")
+	require.Contains(t, got, "  Employee --> Employee : Manager\n", "Recursive edge is not correct")
+
+	_, err = GraphMermaid(10)
+	require.Equal(t, ErrNotStruct, err, "Able to draw a non-struct")
+}
+
+func ExampleGraph() {
+	// type Address struct {
+	// 	City string `json:"city" validate:"required"`
+	// 	Zip  string `json:"zip,omitempty"`
+	// }
+	type Office struct {
+		Name      string
+		Addresses []Address
+	}
+
+	dot, err := Graph(Office{})
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Print(dot)
+	// Output:
+	// digraph "Office" {
+	//   node [shape=record];
+	//   "attr.Office" [label="{Office|Name string\lAddresses []attr.Address\l}"];
+	//   "attr.Address" [label="{Address|City string\lZip string\l}"];
+	//   "attr.Office" -> "attr.Address" [label="Addresses[]"];
+	// }
+}
@@ -0,0 +1,55 @@
+package attr
+
+import "sync"
+
+// Guarded wraps a struct shared across goroutines. All the accesses to the
+// struct through its methods are protected by a read-write mutex.
+type Guarded struct {
+	mu  sync.RWMutex
+	obj interface{}
+}
+
+// Guard returns a Guarded wrapper of the given struct, so that its fields can
+// be read and updated from multiple goroutines without a data race. The struct
+// must not be accessed directly while it is being shared through the wrapper.
+//
+// NOTE: 'obj' struct must be passed by pointer for this API to work. Passing by
+// value results in ErrNotPtr.
+func Guard(obj interface{}) (*Guarded, error) {
+	if _, err := getStructByPtr(obj); err != nil {
+		return nil, err
+	}
+
+	return &Guarded{obj: obj}, nil
+}
+
+// Get returns the value of a given field of the guarded struct, like GetValue.
+func (g *Guarded) Get(fieldName string) (interface{}, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return GetValue(g.obj, fieldName)
+}
+
+// Set sets the given value to a field of the guarded struct, like SetValue.
+func (g *Guarded) Set(fieldName string, newValue interface{}) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return SetValue(g.obj, fieldName, newValue)
+}
+
+// Values returns a map of all the exported (public) field names of the guarded
+// struct with their values, like Values.
+func (g *Guarded) Values() (map[string]interface{}, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return Values(g.obj)
+}
+
+// Update calls 'fn' with the pointer to the guarded struct while holding the
+// write lock, so that multiple fields can be updated together. The pointer
+// must not be retained after 'fn' returns.
+func (g *Guarded) Update(fn func(obj interface{}) error) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return fn(g.obj)
+}
@@ -0,0 +1,68 @@
+package attr
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuard(t *testing.T) {
+	testUser := User{Username: "srathi", Age: 30}
+	guarded, err := Guard(&testUser)
+	require.Nil(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			require.Nil(t, guarded.Set("Age", i))
+		}(i)
+		go func() {
+			defer wg.Done()
+			_, err := guarded.Values()
+			require.Nil(t, err)
+		}()
+	}
+	wg.Wait()
+
+	err = guarded.Update(func(obj interface{}) error {
+		obj.(*User).Age = 50
+		return SetValue(obj, "Username", "new-srathi")
+	})
+	require.Nil(t, err)
+
+	got, err := guarded.Get("Age")
+	require.Nil(t, err)
+	require.Equal(t, 50, got, "Updated value is not correct")
+
+	got, err = guarded.Get("Username")
+	require.Nil(t, err)
+	require.Equal(t, "new-srathi", got, "Updated value is not correct")
+
+	_, err = Guard(testUser)
+	require.Equal(t, ErrNotPtr, err, "Able to guard a struct passed by value")
+}
+
+func ExampleGuard() {
+	testUser := User{Username: "srathi", password: "secret", Age: 30}
+
+	guarded, err := Guard(&testUser)
+	if err != nil {
+		// Handle error.
+	}
+
+	// Safe to use from multiple goroutines.
+	err = guarded.Set("Age", 31)
+	if err != nil {
+		// Handle error.
+	}
+	age, err := guarded.Get("Age")
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Printf("Age: %v\n", age)
+	// Output: Age: 31
+}
@@ -0,0 +1,67 @@
+package attr
+
+import "reflect"
+
+// DecodeHook is called by FromMap, FromValues and FromEnv with the type of a
+// value from the source, the type of the field it is meant for and the value
+// itself, before the value is converted to the field type. It returns the
+// value to use instead, such as a parsed version of it, or an error to stop
+// the decoding.
+//
+// This is the same signature as the DecodeHookFuncType of mapstructure, so the
+// existing mapstructure hooks can be used with WithDecodeHook.
+type DecodeHook func(from, to reflect.Type, data interface{}) (interface{}, error)
+
+// Function types of the decode hooks accepted by WithDecodeHook, which are the
+// ones supported by mapstructure.
+var (
+	decodeHookType     = reflect.TypeOf(DecodeHook(nil))
+	decodeHookKindType = reflect.TypeOf(
+		(func(from, to reflect.Kind, data interface{}) (interface{}, error))(nil))
+	decodeHookValueType = reflect.TypeOf(
+		(func(from, to reflect.Value) (interface{}, error))(nil))
+)
+
+// toDecodeHook adapts a function of any of the supported decode hook types to
+// a DecodeHook. Returns ErrInvalidHook for any other value.
+func toDecodeHook(hook interface{}) (DecodeHook, error) {
+	hookValue := reflect.ValueOf(hook)
+	if !hookValue.IsValid() || hookValue.Kind() != reflect.Func || hookValue.IsNil() {
+		return nil, ErrInvalidHook
+	}
+
+	switch typ := hookValue.Type(); {
+	case typ.ConvertibleTo(decodeHookType):
+		return hookValue.Convert(decodeHookType).Interface().(DecodeHook), nil
+
+	case typ.ConvertibleTo(decodeHookKindType):
+		fn := hookValue.Convert(decodeHookKindType).Interface().(func(reflect.Kind, reflect.Kind, interface{}) (interface{}, error))
+		return func(from, to reflect.Type, data interface{}) (interface{}, error) {
+			return fn(from.Kind(), to.Kind(), data)
+		}, nil
+
+	case typ.ConvertibleTo(decodeHookValueType):
+		fn := hookValue.Convert(decodeHookValueType).Interface().(func(reflect.Value, reflect.Value) (interface{}, error))
+		return func(_, to reflect.Type, data interface{}) (interface{}, error) {
+			return fn(reflect.ValueOf(data), reflect.New(to).Elem())
+		}, nil
+	}
+
+	return nil, ErrInvalidHook
+}
+
+// applyHooks passes a value from a source through the decode hooks in order,
+// for a field of the given type. A nil value is not passed to the hooks.
+func applyHooks(hooks []DecodeHook, value interface{}, to reflect.Type) (interface{}, error) {
+	for _, hook := range hooks {
+		if value == nil {
+			break
+		}
+
+		var err error
+		if value, err = hook(reflect.TypeOf(value), to, value); err != nil {
+			return nil, err
+		}
+	}
+	return value, nil
+}
@@ -0,0 +1,94 @@
+package attr
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// mapstructureHook has the same definition as mapstructure.DecodeHookFuncType.
+type mapstructureHook func(reflect.Type, reflect.Type, interface{}) (interface{}, error)
+
+func TestWithDecodeHook(t *testing.T) {
+	type Target struct {
+		Name    string
+		Timeout time.Duration
+		Count   int
+	}
+	values := map[string]interface{}{"Name": "srathi", "Timeout": 5, "Count": "7"}
+
+	upper := func(from, to reflect.Type, data interface{}) (interface{}, error) {
+		if from.Kind() == reflect.String && to.Kind() == reflect.String {
+			return strings.ToUpper(data.(string)), nil
+		}
+		return data, nil
+	}
+	seconds := mapstructureHook(func(from, to reflect.Type, data interface{}) (interface{}, error) {
+		if to == reflect.TypeOf(time.Duration(0)) && from.Kind() == reflect.Int {
+			return time.Duration(data.(int)) * time.Second, nil
+		}
+		return data, nil
+	})
+	double := func(from, to reflect.Kind, data interface{}) (interface{}, error) {
+		if from == reflect.String && to == reflect.Int {
+			return data.(string) + data.(string), nil
+		}
+		return data, nil
+	}
+
+	var target Target
+	err := FromMap(&target, values, "json", WithDecodeHook(upper), WithDecodeHook(seconds),
+		WithDecodeHook(double))
+	require.Nil(t, err)
+	require.Equal(t, Target{"SRATHI", 5 * time.Second, 77}, target, "Hooks are not applied correctly")
+
+	// A value hook gets the current value of the target field type.
+	zeroTo := func(from, to reflect.Value) (interface{}, error) {
+		if to.Kind() == reflect.Int {
+			return to.Interface(), nil
+		}
+		return from.Interface(), nil
+	}
+	require.Nil(t, FromMap(&target, values, "json", WithDecodeHook(zeroTo)))
+	require.Equal(t, 0, target.Count, "Value hook is not applied correctly")
+
+	hookErr := errors.New("rejected")
+	reject := DecodeHook(func(from, to reflect.Type, data interface{}) (interface{}, error) {
+		return nil, hookErr
+	})
+	err = FromMap(&target, values, "json", WithDecodeHook(reject))
+	require.True(t, errors.Is(err, hookErr), "Hook error not returned")
+
+	for _, hook := range []interface{}{nil, 10, func(string) error { return nil }} {
+		err = FromMap(&target, values, "json", WithDecodeHook(hook))
+		require.Equal(t, ErrInvalidHook, err, "Invalid hook %T accepted", hook)
+	}
+}
+
+func ExampleWithDecodeHook() {
+	// type DBConfig struct {
+	// 	Host     string `env:"HOST"`
+	// 	Port     int    `env:"PORT"`
+	// 	MaxConns int
+	// }
+	values := map[string]interface{}{"HOST": " localhost ", "PORT": 5432}
+
+	trim := func(from, to reflect.Type, data interface{}) (interface{}, error) {
+		if text, ok := data.(string); ok {
+			return strings.TrimSpace(text), nil
+		}
+		return data, nil
+	}
+
+	var config DBConfig
+	if err := FromMap(&config, values, "env", WithDecodeHook(trim)); err != nil {
+		// Handle error.
+	}
+	fmt.Printf("%q\n", config.Host)
+	// Output: "localhost"
+}
@@ -0,0 +1,103 @@
+package attr
+
+import "reflect"
+
+// SetInSlice sets a field of the struct at the given index of a slice (or an
+// array) of structs or pointers to structs. A nested field can be given by a
+// dot separated path, such as "Address.City". The elements of a slice are set
+// in place, so the slice can be passed by value, while an array must be
+// passed by pointer, else ErrNotPtr is returned.
+//
+// ErrOutOfRange is returned for an index past the length of the slice, and
+// the value is checked and set like SetValue does.
+func SetInSlice(slice interface{}, index int, path string, newValue interface{}) error {
+	sliceValue, err := getSliceValue(slice)
+	if err != nil {
+		return err
+	}
+
+	if sliceValue.Kind() == reflect.Array && !sliceValue.CanAddr() {
+		return ErrNotPtr
+	}
+
+	if index < 0 || index >= sliceValue.Len() {
+		return ErrOutOfRange
+	}
+
+	return setInElem(sliceValue.Index(index), path, newValue)
+}
+
+// SetInMap sets a field of the struct stored with the given key in a map of
+// structs or pointers to structs, which can be passed by value or by pointer.
+// A nested field can be given by a dot separated path, such as
+// "Address.City".
+//
+// A struct stored by value in a map isn't addressable, so it is copied, the
+// field is set on the copy and the copy is stored back with the same key. The
+// struct pointed to by a map value is set in place.
+//
+// ErrNotMap is returned if the object is not such a map, ErrNoKey if the key
+// is not present (such as in a nil map) and ErrMismatchValue if the key is not
+// of the key type of the map. The value is checked like SetValue does.
+func SetInMap(m interface{}, key interface{}, path string, newValue interface{}) error {
+	mapValue := valueOf(m)
+	if mapValue.Kind() == reflect.Ptr {
+		if mapValue.IsNil() {
+			if mapValue.Type().Elem().Kind() == reflect.Map {
+				return ErrNilPointer
+			}
+			return ErrNotMap
+		}
+		mapValue = mapValue.Elem()
+	}
+
+	if mapValue.Kind() != reflect.Map {
+		return ErrNotMap
+	}
+
+	elemType := mapValue.Type().Elem()
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return ErrNotMap
+	}
+
+	keyValue := reflect.ValueOf(key)
+	if !keyValue.IsValid() || keyValue.Type() != mapValue.Type().Key() {
+		return ErrMismatchValue
+	}
+
+	elemValue := mapValue.MapIndex(keyValue)
+	if !elemValue.IsValid() {
+		return ErrNoKey
+	}
+
+	if elemValue.Kind() == reflect.Ptr {
+		return setInElem(elemValue, path, newValue)
+	}
+
+	copyValue := reflect.New(elemValue.Type()).Elem()
+	copyValue.Set(elemValue)
+	if err := setInElem(copyValue, path, newValue); err != nil {
+		return err
+	}
+	mapValue.SetMapIndex(keyValue, copyValue)
+	return nil
+}
+
+// setInElem sets a field of an addressable struct (or a pointer to a struct)
+// which is an element of a slice or a map, given by a dot separated path.
+func setInElem(elemValue reflect.Value, path string, newValue interface{}) error {
+	fieldValue, err := getFieldByPath(elemValue, path, false)
+	if err != nil {
+		return err
+	}
+
+	value, err := assignableValue(fieldValue, newValue)
+	if err != nil {
+		return err
+	}
+	fieldValue.Set(value)
+	return nil
+}
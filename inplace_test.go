@@ -0,0 +1,118 @@
+package attr
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetInSlice(t *testing.T) {
+	list := append([]User{}, users...)
+	err := SetInSlice(list, 1, "Age", 26)
+	require.NoError(t, err)
+	require.Equal(t, 26, list[1].Age)
+	require.Equal(t, 25, users[1].Age, "The source slice was modified")
+
+	ptrs := []*Employee{{Name: "ann", Address: &Address{City: "Pune"}}, nil}
+	err = SetInSlice(&ptrs, 0, "Address.City", "Delhi")
+	require.NoError(t, err)
+	require.Equal(t, "Delhi", ptrs[0].Address.City)
+
+	err = SetInSlice(ptrs, 1, "Name", "bob")
+	require.Equal(t, ErrNilPointer, err)
+
+	array := [2]User{}
+	err = SetInSlice(array, 0, "Age", 1)
+	require.Equal(t, ErrNotPtr, err)
+
+	err = SetInSlice(&array, 0, "Age", 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, array[0].Age)
+
+	err = SetInSlice(list, 4, "Age", 1)
+	require.Equal(t, ErrOutOfRange, err)
+
+	err = SetInSlice(list, -1, "Age", 1)
+	require.Equal(t, ErrOutOfRange, err)
+
+	err = SetInSlice(list, 0, "Age", "1")
+	require.Equal(t, ErrMismatchValue, err)
+
+	err = SetInSlice(list, 0, "password", "x")
+	require.Equal(t, ErrUnexportedField, err)
+
+	err = SetInSlice(list, 0, "Missing", 1)
+	require.Equal(t, ErrNoField, err)
+
+	err = SetInSlice([]int{1}, 0, "Age", 1)
+	require.Equal(t, ErrNotSlice, err)
+}
+
+func TestSetInMap(t *testing.T) {
+	byName := map[string]User{"srathi": {Username: "srathi", Age: 30}}
+	err := SetInMap(byName, "srathi", "Age", 31)
+	require.NoError(t, err)
+	require.Equal(t, 31, byName["srathi"].Age)
+
+	err = SetInMap(&byName, "srathi", "Username", "srathi-alt")
+	require.NoError(t, err)
+	require.Equal(t, "srathi-alt", byName["srathi"].Username)
+
+	byID := map[int]*Employee{1: {Name: "ann", Address: &Address{}}}
+	employee := byID[1]
+	err = SetInMap(byID, 1, "Address.Zip", "411001")
+	require.NoError(t, err)
+	require.Equal(t, "411001", employee.Address.Zip, "The pointed struct was not set in place")
+
+	// A failed set leaves the stored struct unchanged.
+	err = SetInMap(byName, "srathi", "Age", "32")
+	require.Equal(t, ErrMismatchValue, err)
+	require.Equal(t, 31, byName["srathi"].Age)
+
+	err = SetInMap(byName, "alice", "Age", 1)
+	require.Equal(t, ErrNoKey, err)
+	require.Len(t, byName, 1, "A missing key was added")
+
+	var nilMap map[string]User
+	err = SetInMap(nilMap, "srathi", "Age", 1)
+	require.Equal(t, ErrNoKey, err)
+
+	err = SetInMap(byName, 1, "Age", 1)
+	require.Equal(t, ErrMismatchValue, err)
+
+	err = SetInMap(byName, nil, "Age", 1)
+	require.Equal(t, ErrMismatchValue, err)
+
+	err = SetInMap(map[string]int{"a": 1}, "a", "Age", 1)
+	require.Equal(t, ErrNotMap, err)
+
+	err = SetInMap(users, 0, "Age", 1)
+	require.Equal(t, ErrNotMap, err)
+
+	err = SetInMap((*map[string]User)(nil), "srathi", "Age", 1)
+	require.Equal(t, ErrNilPointer, err)
+
+	// The values are scanned into sql.Scanner fields like SetValue.
+	type Row struct{ Note sql.NullString }
+	rows := map[string]Row{"a": {}}
+	err = SetInMap(rows, "a", "Note", "hello")
+	require.NoError(t, err)
+	require.Equal(t, sql.NullString{String: "hello", Valid: true}, rows["a"].Note)
+}
+
+func ExampleSetInMap() {
+	byName := map[string]User{"srathi": {Username: "srathi", Age: 30}}
+
+	// byName["srathi"].Age = 31 doesn't compile, as map values aren't
+	// addressable.
+	if err := SetInMap(byName, "srathi", "Age", 31); err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(byName["srathi"].Age)
+
+	// Output:
+	// 31
+}
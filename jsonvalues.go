@@ -0,0 +1,426 @@
+package attr
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// jsonMarshalerType is the reflect type of json.Marshaler.
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// textMarshalerType is the reflect type of encoding.TextMarshaler.
+var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+// jsonMember is a field of a struct type as marshaled by encoding/json.
+type jsonMember struct {
+	name      string
+	index     []int
+	tagged    bool
+	omitEmpty bool
+	omitZero  bool
+	quoted    bool
+}
+
+// JSONField is a struct field as marshaled by encoding/json, as returned by
+// JSONFields.
+type JSONField struct {
+	// Name is the name of the field in JSON.
+	Name string
+	// Field is the struct field. Its Index is the index sequence of the field
+	// for reflect.Value.FieldByIndex, including the indexes of the embedded
+	// structs it is promoted from.
+	Field reflect.StructField
+	// OmitEmpty, OmitZero and Quoted are true if the field is tagged with the
+	// ",omitempty", ",omitzero" and ",string" options respectively.
+	OmitEmpty bool
+	OmitZero  bool
+	Quoted    bool
+}
+
+// JSONFields returns the fields of a struct which are marshaled by
+// encoding/json, in the same order. The fields tagged with "-" are dropped,
+// and the fields of the untagged embedded structs (exported or not) are
+// promoted, following the rules of encoding/json for the conflicting names.
+// The fields of a struct type are resolved once and cached.
+//
+// 'obj' can be passed by value or by pointer.
+func JSONFields(obj interface{}) ([]JSONField, error) {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	objType := objValue.Type()
+	members := cachedJSONMembers(objType)
+	fields := make([]JSONField, len(members))
+	for i, member := range members {
+		field := objType.FieldByIndex(member.index)
+		field.Index = append([]int(nil), member.index...)
+		fields[i] = JSONField{
+			Name:      member.name,
+			Field:     field,
+			OmitEmpty: member.omitEmpty,
+			OmitZero:  member.omitZero,
+			Quoted:    member.quoted,
+		}
+	}
+	return fields, nil
+}
+
+// JSONValues returns a map of the fields of a struct as encoding/json would
+// marshal them, without a marshal and unmarshal round trip. The keys are the
+// json tag names (or the field names without a name in the tag), the fields
+// tagged with "-" are dropped, and so are the empty fields tagged with
+// ",omitempty" and the zero fields tagged with ",omitzero". The fields of the
+// untagged embedded structs are promoted, following the rules of encoding/json
+// for the conflicting names, and the fields tagged with ",string" are given as
+// their quoted JSON strings.
+//
+// The nested structs are converted to maps too, the slices and arrays to
+// []interface{} and the maps to map[string]interface{}, while the []byte
+// values and the values of the types implementing json.Marshaler or
+// encoding.TextMarshaler (such as time.Time) are kept as they are, so that
+// marshaling the result gives the same JSON as marshaling 'obj'.
+//
+// ErrCycleDetected is returned if a struct references itself through
+// pointers. 'obj' can be passed by value or by pointer.
+func JSONValues(obj interface{}) (map[string]interface{}, error) {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	visited := map[uintptr]bool{}
+	if ptrValue := valueOf(obj); ptrValue.Kind() == reflect.Ptr {
+		visited[ptrValue.Pointer()] = true
+	}
+	return jsonObject(objValue, visited)
+}
+
+// jsonObject converts a struct to a map, like encoding/json marshals it to a
+// JSON object. 'visited' holds the pointers being converted, to detect cycles.
+func jsonObject(objValue reflect.Value, visited map[uintptr]bool) (map[string]interface{}, error) {
+	object := map[string]interface{}{}
+	for _, member := range cachedJSONMembers(objValue.Type()) {
+		fieldValue, ok := fieldByIndex(objValue, member.index)
+		if !ok {
+			continue
+		}
+
+		if (member.omitEmpty && isEmptyJSONValue(fieldValue)) || (member.omitZero && isZeroJSONValue(fieldValue)) {
+			continue
+		}
+
+		if member.quoted {
+			if value, ok := quotedJSONValue(fieldValue); ok {
+				object[member.name] = value
+				continue
+			}
+		}
+
+		value, err := jsonValue(fieldValue, visited)
+		if err != nil {
+			return nil, err
+		}
+		object[member.name] = value
+	}
+	return object, nil
+}
+
+// jsonValue converts a value like encoding/json marshals it.
+func jsonValue(value reflect.Value, visited map[uintptr]bool) (interface{}, error) {
+	if isJSONMarshaler(value) {
+		return value.Interface(), nil
+	}
+
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() {
+			return nil, nil
+		}
+		if visited[value.Pointer()] {
+			return nil, ErrCycleDetected
+		}
+		visited[value.Pointer()] = true
+		defer delete(visited, value.Pointer())
+		return jsonValue(value.Elem(), visited)
+
+	case reflect.Interface:
+		if value.IsNil() {
+			return nil, nil
+		}
+		return jsonValue(value.Elem(), visited)
+
+	case reflect.Struct:
+		return jsonObject(value, visited)
+
+	case reflect.Slice:
+		if value.IsNil() || value.Type().Elem().Kind() == reflect.Uint8 {
+			return value.Interface(), nil
+		}
+		fallthrough
+
+	case reflect.Array:
+		items := make([]interface{}, value.Len())
+		for i := range items {
+			item, err := jsonValue(value.Index(i), visited)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = item
+		}
+		return items, nil
+
+	case reflect.Map:
+		if value.IsNil() {
+			return value.Interface(), nil
+		}
+		object := make(map[string]interface{}, value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			key, err := jsonKey(iter.Key())
+			if err != nil {
+				return nil, err
+			}
+			elem, err := jsonValue(iter.Value(), visited)
+			if err != nil {
+				return nil, err
+			}
+			object[key] = elem
+		}
+		return object, nil
+	}
+
+	return value.Interface(), nil
+}
+
+// jsonKey returns the JSON object key of a map key, like encoding/json.
+func jsonKey(key reflect.Value) (string, error) {
+	if key.Kind() == reflect.String {
+		return key.String(), nil
+	}
+
+	if marshaler, ok := key.Interface().(encoding.TextMarshaler); ok {
+		text, err := marshaler.MarshalText()
+		return string(text), err
+	}
+
+	switch key.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(key.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(key.Uint(), 10), nil
+	}
+	return "", ErrMismatchValue
+}
+
+// quotedJSONValue returns the JSON encoding of a string, number or bool field
+// tagged with ",string" as a string, such as "10" for 10. It returns false for
+// the other kinds, for which the option is ignored by encoding/json.
+func quotedJSONValue(value reflect.Value) (interface{}, bool) {
+	if value.Kind() == reflect.Ptr && value.Type().Name() == "" {
+		if !isQuotable(value.Type().Elem().Kind()) {
+			return nil, false
+		}
+		if value.IsNil() {
+			return nil, true
+		}
+		value = value.Elem()
+	}
+
+	if !isQuotable(value.Kind()) {
+		return nil, false
+	}
+	text, err := json.Marshal(value.Interface())
+	if err != nil {
+		return nil, false
+	}
+	return string(text), true
+}
+
+// isQuotable returns true if the ",string" json option applies to a kind.
+func isQuotable(kind reflect.Kind) bool {
+	return kind == reflect.String || kind == reflect.Bool || isNumber(kind)
+}
+
+// isJSONMarshaler returns true if a value marshals itself to JSON, with
+// json.Marshaler or encoding.TextMarshaler.
+func isJSONMarshaler(value reflect.Value) bool {
+	if value.Kind() == reflect.Ptr && value.IsNil() {
+		return false
+	}
+
+	typ := value.Type()
+	if typ.Implements(jsonMarshalerType) || typ.Implements(textMarshalerType) {
+		return true
+	}
+	return value.CanAddr() &&
+		(reflect.PtrTo(typ).Implements(jsonMarshalerType) || reflect.PtrTo(typ).Implements(textMarshalerType))
+}
+
+// isEmptyJSONValue reports whether a value is empty as defined by the
+// ",omitempty" option of encoding/json.
+func isEmptyJSONValue(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return value.Len() == 0
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Interface, reflect.Ptr:
+		return value.IsZero()
+	}
+	return false
+}
+
+// isZeroJSONValue reports whether a value is zero as defined by the
+// ",omitzero" option of encoding/json, using its IsZero method if it has one.
+func isZeroJSONValue(value reflect.Value) bool {
+	if zeroer, ok := value.Interface().(interface{ IsZero() bool }); ok {
+		if value.Kind() == reflect.Ptr && value.IsNil() {
+			return true
+		}
+		return zeroer.IsZero()
+	}
+	return value.IsZero()
+}
+
+// fieldByIndex returns the nested field of a struct given by an index
+// sequence, like reflect.Value.FieldByIndex, but returns false instead of
+// panicking if an embedded pointer in the sequence is nil.
+func fieldByIndex(objValue reflect.Value, index []int) (reflect.Value, bool) {
+	fieldValue := objValue
+	for i, fieldIndex := range index {
+		if i > 0 && fieldValue.Kind() == reflect.Ptr {
+			if fieldValue.IsNil() {
+				return reflect.Value{}, false
+			}
+			fieldValue = fieldValue.Elem()
+		}
+		fieldValue = fieldValue.Field(fieldIndex)
+	}
+	return fieldValue, true
+}
+
+// cachedJSONMembers returns the json fields of a struct type, like jsonMembers,
+// resolving them on the first call for the type.
+func cachedJSONMembers(objType reflect.Type) []jsonMember {
+	fields := cachedFields(objType)
+	fields.jsonOnce.Do(func() {
+		fields.jsonMembers = jsonMembers(objType)
+	})
+	return fields.jsonMembers
+}
+
+// jsonMembers returns the fields of a struct type which are marshaled by
+// encoding/json, promoting the fields of the untagged embedded structs. Of the
+// fields with the same name, the shallowest one is kept, or the only tagged
+// one at the shallowest depth, else none of them, like encoding/json. The
+// fields are sorted by their index sequences, like encoding/json marshals them.
+func jsonMembers(objType reflect.Type) []jsonMember {
+	candidates := map[string][]jsonMember{}
+	names := []string{}
+	addJSONMembers(objType, nil, map[reflect.Type]bool{}, candidates, &names)
+
+	members := []jsonMember{}
+	for _, name := range names {
+		fields := candidates[name]
+		depth := len(fields[0].index)
+		dominant := []jsonMember{}
+		for _, field := range fields {
+			if len(field.index) < depth {
+				depth, dominant = len(field.index), nil
+			}
+			if len(field.index) == depth {
+				dominant = append(dominant, field)
+			}
+		}
+
+		if len(dominant) > 1 {
+			tagged := []jsonMember{}
+			for _, field := range dominant {
+				if field.tagged {
+					tagged = append(tagged, field)
+				}
+			}
+			dominant = tagged
+		}
+		if len(dominant) == 1 {
+			members = append(members, dominant[0])
+		}
+	}
+
+	sort.Slice(members, func(i, j int) bool {
+		return lessIndex(members[i].index, members[j].index)
+	})
+	return members
+}
+
+// lessIndex reports whether a field index sequence comes before another one
+// in the declaration order of the fields.
+func lessIndex(a, b []int) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return len(a) < len(b)
+}
+
+// addJSONMembers adds the json fields of a struct type to 'candidates' by
+// their names, recording the new names in 'names' in their order. 'index' is
+// the index sequence of an embedded struct whose fields are promoted, and
+// 'inProgress' stops the recursion on recursive embedding.
+func addJSONMembers(objType reflect.Type, index []int, inProgress map[reflect.Type]bool,
+	candidates map[string][]jsonMember, names *[]string) {
+	inProgress[objType] = true
+	defer delete(inProgress, objType)
+
+	for i := 0; i < objType.NumField(); i++ {
+		field := objType.Field(i)
+		tag, hasTag := field.Tag.Lookup("json")
+		if tag == "-" {
+			continue
+		}
+
+		fieldIndex := append(append([]int{}, index...), i)
+		embedded := field.Type
+		if embedded.Kind() == reflect.Ptr {
+			embedded = embedded.Elem()
+		}
+		name := tagName(tag)
+		if field.Anonymous && name == "" && embedded.Kind() == reflect.Struct {
+			if !inProgress[embedded] && (field.PkgPath == "" || field.Type.Kind() != reflect.Ptr) {
+				addJSONMembers(embedded, fieldIndex, inProgress, candidates, names)
+			}
+			continue
+		}
+
+		if field.PkgPath != "" {
+			continue
+		}
+
+		member := jsonMember{name: name, index: fieldIndex, tagged: hasTag && name != ""}
+		if member.name == "" {
+			member.name = field.Name
+		}
+		for _, opt := range strings.Split(tag, ",")[1:] {
+			switch opt {
+			case "omitempty":
+				member.omitEmpty = true
+			case "omitzero":
+				member.omitZero = true
+			case "string":
+				member.quoted = true
+			}
+		}
+
+		if _, found := candidates[member.name]; !found {
+			*names = append(*names, member.name)
+		}
+		candidates[member.name] = append(candidates[member.name], member)
+	}
+}
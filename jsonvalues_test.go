@@ -0,0 +1,163 @@
+package attr
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Audit struct {
+	Created time.Time `json:"created"`
+	Author  string    `json:"author,omitempty"`
+}
+
+type Article struct {
+	*Audit
+	Title    string            `json:"title"`
+	Views    int64             `json:"views,string"`
+	Draft    bool              `json:"draft,omitempty"`
+	Tags     []string          `json:"tags,omitempty"`
+	Body     []byte            `json:"body"`
+	Related  []User            `json:"related"`
+	Meta     map[int]User      `json:"meta,omitempty"`
+	Editor   *User             `json:"editor"`
+	Internal string            `json:"-"`
+	Dash     string            `json:"-,"`
+	Extra    map[string]string `json:",omitempty"`
+	secret   string
+}
+
+func TestJSONValues(t *testing.T) {
+	created := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	article := Article{
+		Audit:   &Audit{Created: created},
+		Title:   "Go",
+		Views:   42,
+		Body:    []byte("hi"),
+		Related: []User{user},
+		Meta:    map[int]User{7: user},
+		Dash:    "dash",
+		secret:  "s",
+	}
+
+	got, err := JSONValues(&article)
+	require.Nil(t, err)
+	userMap := map[string]interface{}{"username": "srathi", "age": 30}
+	require.Equal(t, map[string]interface{}{
+		"created": created,
+		"title":   "Go",
+		"views":   "42",
+		"body":    []byte("hi"),
+		"related": []interface{}{userMap},
+		"meta":    map[string]interface{}{"7": userMap},
+		"editor":  nil,
+		"-":       "dash",
+	}, got, "JSON values are not correct")
+
+	// Marshaling the values gives the same JSON as marshaling the struct.
+	want, err := json.Marshal(article)
+	require.Nil(t, err)
+	gotJSON, err := json.Marshal(got)
+	require.Nil(t, err)
+	require.JSONEq(t, string(want), string(gotJSON), "JSON of the values is not correct")
+
+	// The fields promoted from a nil embedded pointer are skipped.
+	got, err = JSONValues(Article{Title: "Go"})
+	require.Nil(t, err)
+	require.NotContains(t, got, "created")
+
+	_, err = JSONValues(article.Title)
+	require.Equal(t, ErrNotStruct, err)
+}
+
+func TestJSONValuesConflicts(t *testing.T) {
+	type Left struct{ Name, Side string }
+	type Right struct{ Name string }
+	type Pair struct {
+		Left
+		Right
+	}
+
+	got, err := JSONValues(Pair{Left{"left", "L"}, Right{"right"}})
+	require.Nil(t, err)
+	require.Equal(t, map[string]interface{}{"Side": "L"}, got,
+		"Conflicting JSON values are not correct")
+}
+
+func TestJSONValuesCycle(t *testing.T) {
+	node := &Node{Name: "root"}
+	node.Next = node
+
+	_, err := JSONValues(node)
+	require.Equal(t, ErrCycleDetected, err)
+}
+
+func ExampleJSONValues() {
+	type Profile struct {
+		Name  string `json:"name"`
+		Email string `json:"email,omitempty"`
+		Token string `json:"-"`
+	}
+
+	values, err := JSONValues(Profile{Name: "srathi", Token: "secret"})
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Println(values)
+	// Output:
+	// map[name:srathi]
+}
+
+func TestJSONFields(t *testing.T) {
+	got, err := JSONFields(&Article{})
+	require.Nil(t, err)
+	names := []string{}
+	for _, field := range got {
+		names = append(names, field.Name)
+	}
+	require.Equal(t, []string{"created", "author", "title", "views", "draft", "tags", "body",
+		"related", "meta", "editor", "-", "Extra"}, names, "JSON fields are not correct")
+	require.Equal(t, []int{0, 1}, got[1].Field.Index, "Index of a promoted field is not correct")
+	require.True(t, got[1].OmitEmpty, "Omitempty field is not correct")
+	require.True(t, got[3].Quoted, "Quoted field is not correct")
+
+	// Promoted fields are ordered like encoding/json orders them.
+	type base struct {
+		Name string `json:"base_name"`
+		ID   int    `json:"id"`
+	}
+	type Item struct {
+		base
+		ID int `json:"id"`
+	}
+	got, err = JSONFields(Item{})
+	require.Nil(t, err)
+	require.Equal(t, 2, len(got), "JSON fields are not correct")
+	require.Equal(t, "base_name", got[0].Name, "JSON fields are not correct")
+	require.Equal(t, []int{1}, got[1].Field.Index, "Dominant JSON field is not correct")
+
+	_, err = JSONFields("abc")
+	require.Equal(t, ErrNotStruct, err)
+}
+
+func ExampleJSONFields() {
+	type Profile struct {
+		Name  string `json:"name"`
+		Email string `json:"email,omitempty"`
+		Token string `json:"-"`
+	}
+
+	fields, err := JSONFields(Profile{})
+	if err != nil {
+		// Handle error.
+	}
+	for _, field := range fields {
+		fmt.Println(field.Name, field.Field.Name, field.OmitEmpty)
+	}
+	// Output:
+	// name Name false
+	// email Email true
+}
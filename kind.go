@@ -0,0 +1,50 @@
+package attr
+
+import "reflect"
+
+// IsKind returns true if a given field of a struct is of the given kind, such
+// as reflect.Slice or reflect.Int, as a quick type guard before getting or
+// setting the field. The field can be a dot separated path for a field of a
+// nested struct, and 'obj' can be passed by value or by pointer.
+//
+// Only exported (public) fields can be checked, else ErrUnexportedField is
+// returned.
+func IsKind(obj interface{}, fieldName string, kind reflect.Kind) (bool, error) {
+	fieldType, err := fieldTypeOf(obj, fieldName)
+	if err != nil {
+		return false, err
+	}
+	return fieldType.Kind() == kind, nil
+}
+
+// IsType returns true if a given field of a struct is of the same type as the
+// given sample value, such as "" for a string field or time.Time{} for a
+// time.Time field. The sample can also be a reflect.Type, such as for an
+// interface type. A nil sample doesn't match any field. The field is looked up
+// like IsKind.
+func IsType(obj interface{}, fieldName string, sample interface{}) (bool, error) {
+	fieldType, err := fieldTypeOf(obj, fieldName)
+	if err != nil {
+		return false, err
+	}
+
+	sampleType, ok := sample.(reflect.Type)
+	if !ok {
+		sampleType = reflect.TypeOf(sample)
+	}
+	return sampleType != nil && fieldType == sampleType, nil
+}
+
+// fieldTypeOf returns the type of a field of a struct given by a path.
+func fieldTypeOf(obj interface{}, fieldName string) (reflect.Type, error) {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldValue, err := getFieldByPath(objValue, fieldName, false)
+	if err != nil {
+		return nil, err
+	}
+	return fieldValue.Type(), nil
+}
@@ -0,0 +1,70 @@
+package attr
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsKind(t *testing.T) {
+	employee := Employee{Address: &Address{}}
+	for fieldName, kind := range map[string]reflect.Kind{
+		"Name": reflect.String, "Tags": reflect.Slice, "Address": reflect.Ptr,
+		"Address.City": reflect.String, "Labels": reflect.Map, "Extra": reflect.Interface,
+	} {
+		got, err := IsKind(&employee, fieldName, kind)
+		require.Nil(t, err)
+		require.True(t, got, "Kind of %s is not %s", fieldName, kind)
+	}
+
+	got, err := IsKind(employee, "Name", reflect.Int)
+	require.Nil(t, err)
+	require.False(t, got, "String field reported as an int")
+
+	_, err = IsKind(employee, "internal", reflect.String)
+	require.Equal(t, ErrUnexportedField, err, "Able to check a private field")
+
+	_, err = IsKind(employee, "ABC", reflect.String)
+	require.Equal(t, ErrNoField, err, "Able to check a missing field")
+}
+
+func TestIsType(t *testing.T) {
+	employee := Employee{}
+	for _, test := range []struct {
+		fieldName string
+		sample    interface{}
+		want      bool
+	}{
+		{"Name", "", true},
+		{"Tags", []string(nil), true},
+		{"Address", &Address{}, true},
+		{"Address", Address{}, false},
+		{"Labels", map[string]string{}, false},
+		{"Extra", reflect.TypeOf((*interface{})(nil)).Elem(), true},
+		{"Extra", nil, false},
+	} {
+		got, err := IsType(employee, test.fieldName, test.sample)
+		require.Nil(t, err)
+		require.Equal(t, test.want, got, "Type check of %s with %T is not correct", test.fieldName, test.sample)
+	}
+
+	_, err := IsType(10, "Name", "")
+	require.Equal(t, ErrNotStruct, err, "Able to check a field of a non-struct")
+}
+
+func ExampleIsType() {
+	testUser := User{Username: "srathi", password: "secret", Age: 30}
+
+	isInt, err := IsKind(testUser, "Age", reflect.Int)
+	if err != nil {
+		// Handle error.
+	}
+	isString, err := IsType(testUser, "Username", "")
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Println(isInt, isString)
+	// Output: true true
+}
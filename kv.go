@@ -0,0 +1,64 @@
+package attr
+
+import (
+	"reflect"
+	"strings"
+)
+
+// KV is a key-value store which can populate a struct with FromKV, such as
+// consul, etcd or a flat file of "key=value" lines.
+type KV interface {
+	// Get returns the value of a key, and false if the key is not present.
+	Get(key string) (string, bool)
+}
+
+// KVFunc is an adapter to use an ordinary function as a KV, such as
+// os.LookupEnv.
+type KVFunc func(key string) (string, bool)
+
+// Get returns f(key).
+func (f KVFunc) Get(key string) (string, bool) {
+	return f(key)
+}
+
+// kvSource is a Source backed by a KV.
+type kvSource struct {
+	kv     KV
+	tagKey string
+}
+
+// KVSource returns a Source which provides the field values from a KV, to use
+// it with other sources in Layer. Keys are derived like FromKV.
+func KVSource(kv KV, tagKey string) Source {
+	return &kvSource{kv, tagKey}
+}
+
+// Lookup returns the value of the field from the KV.
+func (s *kvSource) Lookup(path []reflect.StructField) (interface{}, bool) {
+	keys, ok := fieldKeys(path, s.tagKey, nil)
+	if !ok {
+		return nil, false
+	}
+
+	value, found := s.kv.Get(strings.Join(keys, pathSeparator))
+	if !found {
+		return nil, false
+	}
+	return value, true
+}
+
+// FromKV sets the exported (public) fields of a struct from a key-value store.
+// Keys are taken from the 'tagKey' tags (such as "kv"), or are the field names
+// if there is no tag, and the keys of the fields of a nested struct are
+// prefixed with the key of the nested struct and ".", such as "db.host".
+// Fields whose key is not present in the store are left untouched.
+//
+// Values are parsed as the field types, with slices parsed from comma
+// separated items. A *FieldError with ErrInvalidValue is returned if a value
+// can't be parsed. See Layer for more details.
+//
+// NOTE: 'obj' struct must be passed by pointer for this API to work. Passing by
+// value results in ErrNotPtr.
+func FromKV(obj interface{}, kv KV, tagKey string) error {
+	return Layer(obj, KVSource(kv, tagKey))
+}
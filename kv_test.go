@@ -0,0 +1,67 @@
+package attr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// mapKV is a KV backed by a map.
+type mapKV map[string]string
+
+func (m mapKV) Get(key string) (string, bool) {
+	value, found := m[key]
+	return value, found
+}
+
+type ServiceConfig struct {
+	Name    string   `kv:"name"`
+	Port    uint16   `kv:"port"`
+	Peers   []string `kv:"peers"`
+	DB      DBConfig `kv:"db"`
+	Ignored string   `kv:"-"`
+}
+
+func TestFromKV(t *testing.T) {
+	kv := mapKV{
+		"name":        "attr",
+		"port":        "8080",
+		"peers":       "a:1, b:2",
+		"db.HOST":     "ignored",
+		"db.Host":     "localhost",
+		"db.MaxConns": "10",
+		"-":           "ignored",
+	}
+	want := ServiceConfig{
+		Name:  "attr",
+		Port:  8080,
+		Peers: []string{"a:1", "b:2"},
+		DB:    DBConfig{Host: "localhost", MaxConns: 10},
+	}
+	var got ServiceConfig
+	require.Nil(t, FromKV(&got, kv, "kv"))
+	require.Equal(t, want, got, "Values from KV are not correct")
+
+	err := FromKV(&got, mapKV{"port": "70000"}, "kv")
+	require.True(t, errors.Is(err, ErrInvalidValue), "Able to set an out of range port")
+
+	require.Equal(t, ErrNotPtr, FromKV(got, kv, "kv"), "Able to set a struct passed by value")
+}
+
+func ExampleFromKV() {
+	kv := KVFunc(func(key string) (string, bool) {
+		values := map[string]string{"username": "srathi", "age": "30"}
+		value, found := values[key]
+		return value, found
+	})
+
+	var testUser User
+	err := FromKV(&testUser, kv, "json")
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Printf("Username: %s, Age: %d\n", testUser.Username, testUser.Age)
+	// Output: Username: srathi, Age: 30
+}
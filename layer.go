@@ -0,0 +1,390 @@
+package attr
+
+import (
+	"net/url"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// Source provides the values of struct fields for Layer, such as from a map,
+// the environment or another struct.
+type Source interface {
+	// Lookup returns the value of the field at the given path, and false if
+	// the source doesn't provide a value for it. The path lists the fields
+	// from the top level struct down to the field itself, leaving out the
+	// embedded structs whose fields are promoted.
+	//
+	// The value doesn't need to be of the field type, as long as it can be
+	// converted to it, such as a string which can be parsed as an int.
+	Lookup(path []reflect.StructField) (interface{}, bool)
+}
+
+// Layer sets the exported (public) fields of a struct from the given sources,
+// in order. A source overrides the earlier ones only for the fields it
+// provides, so the sources should be given from the lowest to the highest
+// precedence, such as defaults, a config file and then the environment.
+//
+// The fields of nested structs are looked up individually, and a nil pointer
+// to a struct is allocated only if a source provides one of its fields.
+// Values are converted to the field types as needed, with strings being parsed
+// as the field type (slices and arrays from comma separated items, and []byte
+// fields with the encoding of their "attr" tag, such as `attr:"base64"`). A
+// *FieldError with ErrInvalidValue or ErrMismatchValue is returned if a value
+// can't be converted, and the struct may be partially updated in that case.
+//
+// A nil pointer to a struct of a type being set by an enclosing struct (such
+// as a linked list) is not allocated, and a *FieldError with ErrCycleDetected
+// is returned if a struct references itself through pointers.
+//
+// NOTE: 'obj' struct must be passed by pointer for this API to work. Passing by
+// value results in ErrNotPtr.
+func Layer(obj interface{}, sources ...Source) error {
+	return layer(obj, sources, newOptions(nil))
+}
+
+// layering holds the state of a Layer call.
+type layering struct {
+	sources []Source
+	o       *options
+	t       *tracker
+	// paths holds the paths of all the fields looked up in the sources.
+	paths [][]reflect.StructField
+}
+
+// layer sets the exported fields of a struct from the sources, with the given
+// options. With the strict option, an *UnknownKeysError is returned if a
+// source has keys which don't match any field.
+func layer(obj interface{}, sources []Source, o *options) error {
+	if o.err != nil {
+		return o.err
+	}
+
+	objValue, err := getStructByPtr(obj)
+	if err != nil {
+		return err
+	}
+
+	l := &layering{sources: sources, o: o, t: newTracker(o)}
+	l.t.enter(valueOf(obj))
+	if _, err := l.layerStruct(objValue, nil); err != nil {
+		return err
+	}
+
+	if o.strict {
+		return l.checkUnknownKeys()
+	}
+	return nil
+}
+
+// layerStruct sets the exported fields of a struct from the sources, and
+// returns true if any field was set.
+func (l *layering) layerStruct(objValue reflect.Value, path []reflect.StructField) (bool, error) {
+	if err := l.t.err(); err != nil {
+		return false, err
+	}
+
+	updated := false
+	objType := objValue.Type()
+	for i := 0; i < objValue.NumField(); i++ {
+		fieldType := objType.Field(i)
+		fieldValue := objValue.Field(i)
+
+		squashed := isSquashed(fieldType, l.o.squashTag)
+		if (fieldType.Anonymous || squashed) && fieldValue.Kind() == reflect.Struct {
+			// Fields of embedded and squashed structs are promoted to the
+			// parent.
+			set, err := l.layerStruct(fieldValue, path)
+			if err != nil {
+				return updated, err
+			}
+			updated = updated || set
+			continue
+		}
+
+		if !fieldValue.CanSet() {
+			continue
+		}
+
+		fieldPath := append(append([]reflect.StructField{}, path...), fieldType)
+		nestedPath := fieldPath
+		if squashed {
+			nestedPath = path
+		}
+		if isNestedStruct(fieldType.Type) {
+			if !l.t.canDescend() {
+				continue
+			}
+			l.t.enter(fieldValue)
+			set, err := l.layerStruct(fieldValue, nestedPath)
+			l.t.leave(fieldValue)
+			if err != nil {
+				return updated, err
+			}
+			updated = updated || set
+			continue
+		}
+
+		if fieldType.Type.Kind() == reflect.Ptr && isNestedStruct(fieldType.Type.Elem()) {
+			if !l.t.canDescend() || (fieldValue.IsNil() && l.t.hasType(fieldType.Type)) {
+				continue
+			}
+			if err := l.t.enter(fieldValue); err != nil {
+				return updated, &FieldError{fieldPathName(fieldPath), err}
+			}
+
+			// Work on a copy to allocate the struct only if it gets a value.
+			nested := reflect.New(fieldType.Type.Elem())
+			if !fieldValue.IsNil() {
+				nested.Elem().Set(fieldValue.Elem())
+			}
+			set, err := l.layerStruct(nested.Elem(), nestedPath)
+			l.t.leave(fieldValue)
+			if err != nil {
+				return updated, err
+			}
+			if set {
+				fieldValue.Set(nested)
+				updated = true
+			}
+			continue
+		}
+
+		l.paths = append(l.paths, fieldPath)
+		for _, source := range l.sources {
+			value, found := source.Lookup(fieldPath)
+			if !found {
+				continue
+			}
+
+			value, err := applyHooks(l.o.hooks, value, fieldType.Type)
+			if err != nil {
+				return updated, &FieldError{fieldPathName(fieldPath), err}
+			}
+
+			if decoded, found, err := decodeBytes(fieldType, value); found {
+				if err != nil {
+					return updated, &FieldError{fieldPathName(fieldPath), err}
+				}
+				fieldValue.Set(decoded)
+				updated = true
+				continue
+			}
+
+			newValue, err := convertValue(value, fieldType.Type, l.o)
+			if err != nil {
+				return updated, &FieldError{fieldPathName(fieldPath), err}
+			}
+			fieldValue.Set(newValue)
+			updated = true
+		}
+	}
+
+	return updated, nil
+}
+
+// isNestedStruct returns true if the fields of a struct type are set one by one
+// by Layer, rather than the struct being set as a single value.
+func isNestedStruct(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Struct && !isValueStruct(typ) &&
+		!reflect.PtrTo(typ).Implements(textUnmarshalerType) && converterFor(typ) == nil
+}
+
+// isSquashed returns true if a struct field has the ",squash" option in the
+// given tag, so that its fields are promoted to the key space of the parent.
+func isSquashed(field reflect.StructField, tagKey string) bool {
+	return tagKey != "" && hasTagOption(field.Tag.Get(tagKey), "squash")
+}
+
+// fieldPathName returns the dot separated field names of a field path.
+func fieldPathName(path []reflect.StructField) string {
+	names := make([]string, len(path))
+	for i, field := range path {
+		names[i] = field.Name
+	}
+	return strings.Join(names, pathSeparator)
+}
+
+// fieldKey returns the key of a field in the given tag, or its field name
+// converted with the naming strategy (if not nil) if there is no such tag.
+func fieldKey(field reflect.StructField, tagKey string, naming KeyNaming) string {
+	if name := tagName(field.Tag.Get(tagKey)); name != "" {
+		return name
+	}
+	return keyName(field.Name, naming, nil)
+}
+
+// fieldKeys returns the keys of the fields of a path in the given tag, and
+// false if a field is skipped with a "-" tag.
+func fieldKeys(path []reflect.StructField, tagKey string, naming KeyNaming) ([]string, bool) {
+	keys := make([]string, len(path))
+	for i, field := range path {
+		if keys[i] = fieldKey(field, tagKey, naming); keys[i] == "-" {
+			return nil, false
+		}
+	}
+	return keys, true
+}
+
+// mapSource is a Source backed by a map.
+type mapSource struct {
+	values map[string]interface{}
+	tagKey string
+	naming KeyNaming
+}
+
+// MapSource returns a Source which provides the field values from a map, such
+// as a decoded JSON or YAML document. Fields are looked up by their 'tagKey'
+// tags (such as "json"), or by their field names if there is no tag. Fields of
+// a nested struct are looked up in a nested map[string]interface{}.
+func MapSource(values map[string]interface{}, tagKey string) Source {
+	return &mapSource{values, tagKey, nil}
+}
+
+// Lookup returns the value of the field from the map.
+func (s *mapSource) Lookup(path []reflect.StructField) (interface{}, bool) {
+	values := s.values
+	for i, field := range path {
+		key := fieldKey(field, s.tagKey, s.naming)
+		value, found := values[key]
+		if !found || key == "-" {
+			return nil, false
+		}
+
+		if i == len(path)-1 {
+			return value, true
+		}
+
+		if values, found = value.(map[string]interface{}); !found {
+			return nil, false
+		}
+	}
+	return nil, false
+}
+
+// envSource is a Source backed by the environment variables.
+type envSource struct {
+	prefix string
+	naming KeyNaming
+}
+
+// EnvSource returns a Source which provides the field values from the
+// environment variables. Variable names are derived like ToEnv, such as
+// "APP_DB_HOST" for the field "DB.Host" with the "APP" prefix.
+func EnvSource(prefix string) Source {
+	return &envSource{prefix, nil}
+}
+
+// Lookup returns the value of the field from the environment.
+func (s *envSource) Lookup(path []reflect.StructField) (interface{}, bool) {
+	name, ok := s.name(path)
+	if !ok {
+		return nil, false
+	}
+	return os.LookupEnv(name)
+}
+
+// name returns the name of the environment variable of a field path, and
+// false if a field is skipped with `env:"-"`.
+func (s *envSource) name(path []reflect.StructField) (string, bool) {
+	name := s.prefix
+	for _, field := range path {
+		tag := tagName(field.Tag.Get("env"))
+		if tag == "-" {
+			return "", false
+		}
+		if tag == "" {
+			tag = keyName(field.Name, s.naming, UpperSnake)
+		}
+		if name != "" {
+			name += envSeparator
+		}
+		name += tag
+	}
+	return name, true
+}
+
+// valuesSource is a Source backed by url.Values.
+type valuesSource struct {
+	values url.Values
+	tagKey string
+	naming KeyNaming
+}
+
+// ValuesSource returns a Source which provides the field values from
+// url.Values, such as the query parameters or the form of a request. Keys are
+// derived like ToValues, such as "range.size" for the field "Range.Size".
+// All the values of a key are used for a slice or an array field, and the
+// first one for any other field, including a byte slice or array (such as a
+// [16]byte UUID).
+func ValuesSource(values url.Values, tagKey string) Source {
+	return &valuesSource{values, tagKey, nil}
+}
+
+// Lookup returns the value of the field from url.Values.
+func (s *valuesSource) Lookup(path []reflect.StructField) (interface{}, bool) {
+	keys, ok := fieldKeys(path, s.tagKey, s.naming)
+	if !ok {
+		return nil, false
+	}
+
+	values, found := s.values[strings.Join(keys, pathSeparator)]
+	if !found || len(values) == 0 {
+		return nil, false
+	}
+
+	fieldType := path[len(path)-1].Type
+	if (fieldType.Kind() == reflect.Slice || fieldType.Kind() == reflect.Array) &&
+		fieldType.Elem().Kind() != reflect.Uint8 {
+		return values, true
+	}
+	return values[0], true
+}
+
+// structSource is a Source backed by another struct.
+type structSource struct {
+	objValue reflect.Value
+}
+
+// StructSource returns a Source which provides the field values from another
+// struct, which need not be of the same type. Fields are looked up by their
+// names, and only the exported fields with a non-zero value are provided.
+//
+// A source for an invalid 'obj' (not a struct or a pointer to a struct)
+// doesn't provide any value.
+func StructSource(obj interface{}) Source {
+	objValue, _ := getReflectValue(obj)
+	return &structSource{objValue}
+}
+
+// Lookup returns the value of the field from the struct.
+func (s *structSource) Lookup(path []reflect.StructField) (interface{}, bool) {
+	if !s.objValue.IsValid() {
+		return nil, false
+	}
+
+	fieldValue := s.objValue
+	for _, field := range path {
+		if fieldValue.Kind() == reflect.Ptr {
+			if fieldValue.IsNil() {
+				return nil, false
+			}
+			fieldValue = fieldValue.Elem()
+		}
+
+		if fieldValue.Kind() != reflect.Struct {
+			return nil, false
+		}
+
+		var err error
+		fieldValue, err = fieldByName(fieldValue, field.Name)
+		if err != nil || !fieldValue.CanInterface() {
+			return nil, false
+		}
+	}
+
+	if fieldValue.IsZero() {
+		return nil, false
+	}
+	return fieldValue.Interface(), true
+}
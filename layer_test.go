@@ -0,0 +1,117 @@
+package attr
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLayer(t *testing.T) {
+	defaults := AppConfig{Name: "default", Timeout: time.Second, DB: DBConfig{Host: "localhost", Port: 5432}}
+	file := map[string]interface{}{
+		"Name":    "from-file",
+		"Hosts":   []interface{}{"a", "b"},
+		"DB":      map[string]interface{}{"Port": 6543.0},
+		"Replica": map[string]interface{}{"Host": "replica"},
+	}
+	os.Setenv("TEST_LAYER_TIMEOUT", "1m")
+	os.Setenv("TEST_LAYER_DB_MAX_CONNS", "20")
+	defer os.Unsetenv("TEST_LAYER_TIMEOUT")
+	defer os.Unsetenv("TEST_LAYER_DB_MAX_CONNS")
+	query := url.Values{"page": {"3"}, "Hosts": {"c", "d", "e"}}
+
+	var config AppConfig
+	err := Layer(&config, StructSource(defaults), MapSource(file, "json"),
+		EnvSource("TEST_LAYER"), ValuesSource(query, "url"))
+	require.Nil(t, err)
+
+	want := AppConfig{
+		Page:    Page{Number: 3},
+		Name:    "from-file",
+		DB:      DBConfig{Host: "localhost", Port: 6543, MaxConns: 20},
+		Replica: &DBConfig{Host: "replica"},
+		Timeout: time.Minute,
+		Hosts:   []string{"c", "d", "e"},
+	}
+	require.Equal(t, want, config, "Layered config is not correct")
+
+	// A nil pointer to a struct stays nil if no source provides its fields.
+	config = AppConfig{}
+	require.Nil(t, Layer(&config, MapSource(map[string]interface{}{"Name": "x"}, "json")))
+	require.Nil(t, config.Replica, "Pointer to a struct allocated without any value")
+
+	err = Layer(&config, MapSource(map[string]interface{}{"DB": map[string]interface{}{"Port": "abc"}}, "json"))
+	require.True(t, errors.Is(err, ErrInvalidValue), "Able to set an invalid value")
+	require.Equal(t, "DB.Port: "+ErrInvalidValue.Error(), err.Error(), "Error message is not correct")
+
+	err = Layer(&config, MapSource(map[string]interface{}{"Hosts": 10}, "json"))
+	require.True(t, errors.Is(err, ErrMismatchValue), "Able to set a value of a different type")
+
+	require.Equal(t, ErrNotPtr, Layer(config), "Able to layer a struct passed by value")
+}
+
+func TestLayerConversions(t *testing.T) {
+	type Target struct {
+		Count    int8
+		Ratio    float32
+		Enabled  bool
+		When     time.Time
+		Labels   map[string]int
+		Email    Email
+		Optional *int
+		Data     []byte
+	}
+	source := map[string]interface{}{
+		"Count":    "016",
+		"Ratio":    2,
+		"Enabled":  "true",
+		"When":     "2021-01-04T10:30:00Z",
+		"Labels":   map[string]interface{}{"a": 1.0},
+		"Email":    "a@b.c",
+		"Optional": "5",
+		"Data":     "abc",
+	}
+	five := 5
+	want := Target{
+		Count:    16,
+		Ratio:    2,
+		Enabled:  true,
+		When:     time.Date(2021, 1, 4, 10, 30, 0, 0, time.UTC),
+		Labels:   map[string]int{"a": 1},
+		Email:    "a@b.c",
+		Optional: &five,
+		Data:     []byte("abc"),
+	}
+
+	var got Target
+	require.Nil(t, Layer(&got, MapSource(source, "json")))
+	require.Equal(t, want, got, "Converted values are not correct")
+
+	err := Layer(&got, MapSource(map[string]interface{}{"Count": "300"}, "json"))
+	require.True(t, errors.Is(err, ErrInvalidValue), "Able to parse an out of range value")
+
+	err = Layer(&got, MapSource(map[string]interface{}{"Count": "0x10"}, "json"))
+	require.True(t, errors.Is(err, ErrInvalidValue), "Able to parse a hex value")
+}
+
+func ExampleLayer() {
+	type Config struct {
+		Host string `json:"host"`
+		Port int    `json:"port"`
+	}
+	defaults := Config{Host: "localhost", Port: 8080}
+	file := map[string]interface{}{"port": 9090}
+
+	var config Config
+	err := Layer(&config, StructSource(defaults), MapSource(file, "json"))
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Printf("%s:%d\n", config.Host, config.Port)
+	// Output: localhost:9090
+}
@@ -0,0 +1,139 @@
+package attr
+
+import (
+	"reflect"
+	"sort"
+)
+
+// FieldLayout describes the memory layout of a single struct field.
+// Padding is the number of unused bytes after the field, before the next
+// field (or the end of the struct).
+type FieldLayout struct {
+	Name    string
+	Offset  uintptr
+	Size    uintptr
+	Align   uintptr
+	Padding uintptr
+}
+
+// StructLayout describes the memory layout of a struct, as returned by Layout.
+//
+// Suggested lists the field names in an order which minimizes the padding, and
+// SuggestedSize is the size of the struct if its fields were in that order.
+type StructLayout struct {
+	Size          uintptr
+	Align         uintptr
+	Padding       uintptr
+	Fields        []FieldLayout
+	Suggested     []string
+	SuggestedSize uintptr
+}
+
+// FieldOffset returns the byte offset of a given field from the start of the
+// struct. Both exported and unexported fields are supported, and the offset of
+// a field promoted from an embedded struct is relative to the outer struct.
+// A field promoted through an embedded pointer lives in a different memory
+// block, so ErrIndirectField is returned for it.
+func FieldOffset(obj interface{}, fieldName string) (uintptr, error) {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return 0, err
+	}
+
+	field, found := cachedField(objValue.Type(), fieldName)
+	if !found {
+		return 0, ErrNoField
+	}
+
+	// The offset of a promoted field is relative to its embedded struct, so
+	// add the offsets of all the structs in between.
+	offset := uintptr(0)
+	typ := objValue.Type()
+	for _, idx := range field.Index {
+		if typ.Kind() == reflect.Ptr {
+			return 0, ErrIndirectField
+		}
+		f := typ.Field(idx)
+		offset += f.Offset
+		typ = f.Type
+	}
+
+	return offset, nil
+}
+
+// Layout returns the memory layout of all the fields of a struct, including
+// the padding added by the compiler for alignment, and a suggested ordering of
+// the fields which minimizes such padding.
+func Layout(obj interface{}) (StructLayout, error) {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return StructLayout{}, err
+	}
+
+	objType := objValue.Type()
+	fields := make([]FieldLayout, objType.NumField())
+	for i := range fields {
+		fieldType := objType.Field(i)
+		fields[i] = FieldLayout{
+			Name:   fieldType.Name,
+			Offset: fieldType.Offset,
+			Size:   fieldType.Type.Size(),
+			Align:  uintptr(fieldType.Type.Align()),
+		}
+	}
+
+	return BuildLayout(objType.Size(), uintptr(objType.Align()), fields), nil
+}
+
+// BuildLayout computes the padding and the suggested field order of a struct
+// layout from the size and the alignment of the struct, and the Name, Offset,
+// Size and Align of its fields in their declaration order. It is used by
+// Layout, and can be used for the struct types which are not available at
+// runtime, such as the ones loaded with go/types.
+func BuildLayout(size, align uintptr, fields []FieldLayout) StructLayout {
+	layout := StructLayout{
+		Size:   size,
+		Align:  align,
+		Fields: make([]FieldLayout, len(fields)),
+	}
+
+	for i, field := range fields {
+		end := size
+		if i+1 < len(fields) {
+			end = fields[i+1].Offset
+		}
+
+		field.Padding = end - field.Offset - field.Size
+		layout.Padding += field.Padding
+		layout.Fields[i] = field
+	}
+
+	// Sorting the fields by decreasing alignment (and by decreasing size for
+	// the same alignment) packs them with the least padding.
+	sorted := make([]FieldLayout, len(layout.Fields))
+	copy(sorted, layout.Fields)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Align != sorted[j].Align {
+			return sorted[i].Align > sorted[j].Align
+		}
+		return sorted[i].Size > sorted[j].Size
+	})
+
+	offset := uintptr(0)
+	layout.Suggested = []string{}
+	for _, field := range sorted {
+		offset = alignUp(offset, field.Align) + field.Size
+		layout.Suggested = append(layout.Suggested, field.Name)
+	}
+	layout.SuggestedSize = alignUp(offset, layout.Align)
+
+	return layout
+}
+
+// alignUp rounds up the offset to the given alignment.
+func alignUp(offset, align uintptr) uintptr {
+	if align == 0 {
+		return offset
+	}
+	return (offset + align - 1) / align * align
+}
@@ -0,0 +1,110 @@
+package attr
+
+import (
+	"fmt"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Padded struct {
+	Flag  bool
+	Count int64
+	Small int8
+	Value int32
+}
+
+func TestFieldOffset(t *testing.T) {
+	got, err := FieldOffset(&user, "Age")
+	require.Nil(t, err)
+	require.Equal(t, unsafe.Offsetof(user.Age), got, "Field offset is not correct")
+
+	got, err = FieldOffset(&user, "password")
+	require.Nil(t, err)
+	require.Equal(t, unsafe.Offsetof(user.password), got, "Unexported field offset is not correct")
+
+	type Wrapper struct {
+		ID int64
+		User
+	}
+	wrapper := Wrapper{}
+	got, err = FieldOffset(wrapper, "Age")
+	require.Nil(t, err)
+	require.Equal(t, unsafe.Offsetof(wrapper.User)+unsafe.Offsetof(wrapper.User.Age), got,
+		"Promoted field offset is not correct")
+
+	type Indirect struct {
+		ID int64
+		*User
+	}
+	_, err = FieldOffset(Indirect{}, "Age")
+	require.Equal(t, ErrIndirectField, err, "Able to get the offset of a field behind a pointer")
+	got, err = FieldOffset(Indirect{}, "User")
+	require.Nil(t, err)
+	require.Equal(t, unsafe.Offsetof(Indirect{}.User), got, "Embedded pointer offset is not correct")
+
+	_, err = FieldOffset(&user, "ABC")
+	require.Equal(t, ErrNoField, err, "Able to get the offset of a non-existent field")
+}
+
+func TestLayout(t *testing.T) {
+	padded := Padded{}
+	got, err := Layout(&padded)
+	require.Nil(t, err)
+	require.Equal(t, unsafe.Sizeof(padded), got.Size, "Struct size is not correct")
+	require.Equal(t, FieldLayout{"Flag", 0, 1, 1, 7}, got.Fields[0], "Field layout is not correct")
+	require.Equal(t, FieldLayout{"Small", 16, 1, 1, 3}, got.Fields[2], "Field layout is not correct")
+	require.Equal(t, uintptr(10), got.Padding, "Struct padding is not correct")
+	require.Equal(t, []string{"Count", "Value", "Flag", "Small"}, got.Suggested,
+		"Suggested field order is not correct")
+	require.Equal(t, uintptr(16), got.SuggestedSize, "Suggested struct size is not correct")
+
+	_, err = Layout(10)
+	require.Equal(t, ErrNotStruct, err, "Able to get the layout of a non-struct")
+}
+
+func TestBuildLayout(t *testing.T) {
+	// The layout of Padded on a 32-bit platform, where int64 is 4-byte aligned.
+	got := BuildLayout(20, 4, []FieldLayout{
+		{Name: "Flag", Offset: 0, Size: 1, Align: 1},
+		{Name: "Count", Offset: 4, Size: 8, Align: 4},
+		{Name: "Small", Offset: 12, Size: 1, Align: 1},
+		{Name: "Value", Offset: 16, Size: 4, Align: 4},
+	})
+	require.Equal(t, uintptr(6), got.Padding, "Struct padding is not correct")
+	require.Equal(t, uintptr(3), got.Fields[0].Padding, "Field padding is not correct")
+	require.Equal(t, []string{"Count", "Value", "Flag", "Small"}, got.Suggested,
+		"Suggested field order is not correct")
+	require.Equal(t, uintptr(16), got.SuggestedSize, "Suggested struct size is not correct")
+}
+
+func ExampleLayout() {
+	// type Padded struct {
+	// 	Flag  bool
+	// 	Count int64
+	// 	Small int8
+	// 	Value int32
+	// }
+	layout, err := Layout(Padded{})
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Printf("Size: %d, padding: %d\n", layout.Size, layout.Padding)
+	fmt.Printf("Suggested: %v (size %d)\n", layout.Suggested, layout.SuggestedSize)
+	// Output:
+	// Size: 24, padding: 10
+	// Suggested: [Count Value Flag Small] (size 16)
+}
+
+func ExampleBuildLayout() {
+	// The layout of a struct type which is not available at runtime, such
+	// as one loaded with go/types, from the sizes of its fields.
+	layout := BuildLayout(12, 4, []FieldLayout{
+		{Name: "Flag", Offset: 0, Size: 1, Align: 1},
+		{Name: "Value", Offset: 4, Size: 4, Align: 4},
+		{Name: "Small", Offset: 8, Size: 1, Align: 1},
+	})
+	fmt.Printf("Padding: %d, suggested: %v (size %d)\n", layout.Padding, layout.Suggested, layout.SuggestedSize)
+	// Output: Padding: 6, suggested: [Value Flag Small] (size 8)
+}
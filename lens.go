@@ -0,0 +1,204 @@
+package attr
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// FieldLens gives access to a field of a struct given by a path, such as
+// "Address.City" or "Items[0].Name", as returned by Lens. The path is parsed
+// once, and resolved to the field indexes once per struct type, so a lens used
+// repeatedly on structs of the same type doesn't look up the fields by name
+// again, and fails early on an invalid path. A lens can be shared by multiple
+// goroutines.
+type FieldLens struct {
+	path string
+	// names holds the segments of the path, such as "Items[0]".
+	names []string
+	// resolved holds the lensPaths of the struct types seen so far, keyed by
+	// their reflect.Type.
+	resolved sync.Map
+}
+
+// lensPath is the path of a lens resolved against a struct type, or the error
+// found in it.
+type lensPath struct {
+	steps []lensStep
+	err   error
+}
+
+// lensStep is a segment of a resolved lens path: the index sequence of a field,
+// like reflect.StructField.Index, and the indexes of the slice or array
+// elements given after its name, if any.
+type lensStep struct {
+	index   []int
+	indexes []int
+}
+
+// Lens returns a FieldLens for the given path of a field, as understood by
+// RawField. The path is checked against the struct type when the lens is
+// used, and the errors are the same as the ones of RawField.
+func Lens(path string) *FieldLens {
+	return &FieldLens{path: path, names: strings.Split(path, pathSeparator)}
+}
+
+// Path returns the dot separated path of the field of the lens.
+func (l *FieldLens) Path() string {
+	return l.path
+}
+
+// Compose returns a new lens for the field given by the path of 'other' in the
+// struct given by the path of this lens, such as "Address.City" for the lenses
+// of "Address" and "City".
+func (l *FieldLens) Compose(other *FieldLens) *FieldLens {
+	names := make([]string, 0, len(l.names)+len(other.names))
+	names = append(append(names, l.names...), other.names...)
+	return &FieldLens{path: l.path + pathSeparator + other.path, names: names}
+}
+
+// Get returns the value of the field of the lens in the given struct 'obj',
+// which can be passed by value or by pointer.
+func (l *FieldLens) Get(obj interface{}) (interface{}, error) {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldValue, err := l.field(objValue)
+	if err != nil {
+		return nil, err
+	}
+	return fieldValue.Interface(), nil
+}
+
+// Set sets the given value to the field of the lens in the given struct 'obj',
+// like SetValue.
+//
+// NOTE: 'obj' struct must be passed by pointer for this API to work. Passing by
+// value results in ErrNotPtr.
+func (l *FieldLens) Set(obj interface{}, newValue interface{}) error {
+	objValue, err := getStructByPtr(obj)
+	if err != nil {
+		return err
+	}
+
+	fieldValue, err := l.field(objValue)
+	if err != nil {
+		return err
+	}
+
+	if fieldValue.Type() != reflect.TypeOf(newValue) {
+		return ErrMismatchValue
+	}
+	fieldValue.Set(reflect.ValueOf(newValue))
+	return nil
+}
+
+// field returns the reflect-value of the field of the lens in a struct.
+func (l *FieldLens) field(objValue reflect.Value) (reflect.Value, error) {
+	resolved := l.resolve(objValue.Type())
+	if resolved.err != nil {
+		return reflect.Value{}, resolved.err
+	}
+
+	fieldValue := objValue
+	for segment, step := range resolved.steps {
+		if fieldValue.Kind() == reflect.Ptr {
+			if fieldValue.IsNil() {
+				return reflect.Value{}, l.nilPathError(segment)
+			}
+			fieldValue = fieldValue.Elem()
+		}
+
+		var ok bool
+		if fieldValue, ok = fieldByIndex(fieldValue, step.index); !ok {
+			// The field is promoted through a nil embedded pointer.
+			return reflect.Value{}, l.nilPathError(segment + 1)
+		}
+		if !fieldValue.CanInterface() {
+			return reflect.Value{}, ErrUnexportedField
+		}
+
+		for _, index := range step.indexes {
+			if fieldValue.Kind() == reflect.Ptr {
+				if fieldValue.IsNil() {
+					return reflect.Value{}, l.nilPathError(segment + 1)
+				}
+				fieldValue = fieldValue.Elem()
+			}
+			if index >= fieldValue.Len() {
+				return reflect.Value{}, ErrOutOfRange
+			}
+			fieldValue = fieldValue.Index(index)
+		}
+	}
+	return fieldValue, nil
+}
+
+// nilPathError returns the error of a nil pointer reached after reading the
+// given number of segments of the path, like getFieldByPath.
+func (l *FieldLens) nilPathError(segment int) error {
+	if segment == 0 {
+		return ErrNilPointer
+	}
+	return &NilPathError{strings.Join(l.names[:segment], pathSeparator), segment}
+}
+
+// resolve returns the path of the lens resolved against a struct type, with
+// the errors of getFieldTypeByPath, resolving it on the first use of the type.
+func (l *FieldLens) resolve(objType reflect.Type) *lensPath {
+	if cached, found := l.resolved.Load(objType); found {
+		return cached.(*lensPath)
+	}
+
+	cached, _ := l.resolved.LoadOrStore(objType, resolveLensPath(objType, l.names))
+	return cached.(*lensPath)
+}
+
+// resolveLensPath resolves the segments of a lens path against a struct type.
+func resolveLensPath(objType reflect.Type, names []string) *lensPath {
+	steps := make([]lensStep, len(names))
+	fieldType := objType
+	for i, name := range names {
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType.Kind() != reflect.Struct {
+			return &lensPath{err: ErrNotStruct}
+		}
+
+		indexes := ""
+		if bracket := strings.IndexByte(name, '['); bracket != -1 {
+			name, indexes = name[:bracket], name[bracket:]
+		}
+
+		field, found := cachedField(fieldType, name)
+		if !found {
+			return &lensPath{err: ErrNoField}
+		}
+		if field.PkgPath != "" {
+			return &lensPath{err: ErrUnexportedField}
+		}
+		steps[i].index = field.Index
+		fieldType = field.Type
+
+		for indexes != "" {
+			index, size, ok := parseIndex(indexes)
+			if !ok {
+				return &lensPath{err: ErrInvalidExpr}
+			}
+			indexes = indexes[size:]
+
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() != reflect.Slice && fieldType.Kind() != reflect.Array {
+				return &lensPath{err: ErrNotSlice}
+			}
+			steps[i].indexes = append(steps[i].indexes, index)
+			fieldType = fieldType.Elem()
+		}
+	}
+	return &lensPath{steps: steps}
+}
@@ -0,0 +1,87 @@
+package attr
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLens(t *testing.T) {
+	employee := Employee{Name: "srathi", Address: &Address{City: "San Jose"}}
+	city := Lens("Address").Compose(Lens("City"))
+	require.Equal(t, "Address.City", city.Path(), "Path of the composed lens is not correct")
+
+	got, err := city.Get(employee)
+	require.Nil(t, err)
+	require.Equal(t, "San Jose", got, "Value of the lens is not correct")
+
+	require.Nil(t, city.Set(&employee, "Fremont"))
+	require.Equal(t, "Fremont", employee.Address.City, "Field not set through the lens")
+	require.Equal(t, ErrMismatchValue, city.Set(&employee, 10), "Able to set a value of a different type")
+	require.Equal(t, ErrNotPtr, city.Set(employee, "abc"), "Able to set a struct passed by value")
+
+	_, err = Lens("Manager.Name").Get(employee)
+	require.Equal(t, &NilPathError{"Manager", 1}, err, "Able to get a field through a nil pointer")
+
+	// The path is resolved once per type.
+	cached, found := city.resolved.Load(reflect.TypeOf(employee))
+	require.True(t, found, "Resolved path is not cached")
+	require.Equal(t, []lensStep{{index: []int{2}}, {index: []int{0}}}, cached.(*lensPath).steps,
+		"Resolved path is not correct")
+
+	// Errors are cached per type.
+	for i := 0; i < 2; i++ {
+		_, err = Lens("internal").Get(employee)
+		require.Equal(t, ErrUnexportedField, err, "Able to get a private field")
+		_, err = city.Get(&user)
+		require.Equal(t, ErrNoField, err, "Able to get a missing field")
+	}
+
+	// Promoted fields of the embedded structs can be used too.
+	manager := Manager{User: User{Username: "srathi"}}
+	got, err = Lens("Username").Get(&manager)
+	require.Nil(t, err)
+	require.Equal(t, "srathi", got, "Promoted field value is not correct")
+
+	// Index segments are resolved like RawField.
+	type Team struct {
+		Name    string
+		Members []*User
+	}
+	team := Team{Members: []*User{{Username: "srathi"}, nil}}
+	got, err = Lens("Members[0].Username").Get(team)
+	require.Nil(t, err)
+	require.Equal(t, "srathi", got, "Value of an indexed lens is not correct")
+	_, err = Lens("Members[1].Username").Get(team)
+	require.Equal(t, &NilPathError{"Members[1]", 1}, err, "Able to get a field through a nil element")
+	_, err = Lens("Members[2].Username").Get(team)
+	require.Equal(t, ErrOutOfRange, err, "Able to get an element out of range")
+	_, err = Lens("Name[0]").Get(team)
+	require.Equal(t, ErrNotSlice, err, "Able to index a non-slice field")
+
+	// The path of a field promoted through a nil embedded pointer is reported.
+	_, err = Lens("Next.X").Get(Outer{Inner: &Inner{}, Next: &Outer{}})
+	require.Equal(t, &NilPathError{"Next.X", 2}, err, "Able to get a field through a nil embedded pointer")
+}
+
+func ExampleLens() {
+	type Order struct {
+		ID      int
+		Address *Address
+	}
+	orders := []Order{{1, &Address{City: "San Jose"}}, {2, &Address{City: "Fremont"}}}
+
+	city := Lens("Address.City")
+	for i := range orders {
+		value, err := city.Get(orders[i])
+		if err != nil {
+			// Handle error.
+		}
+		fmt.Println(value)
+	}
+	// Output:
+	// San Jose
+	// Fremont
+}
@@ -0,0 +1,263 @@
+package attr
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// RedactedValue replaces the values of the fields tagged with
+// `redact:"true"` in LogFields and SlogValue.
+const RedactedValue = "[REDACTED]"
+
+// maxLogSliceLen is the length above which LogFields leaves out a slice, an
+// array or a map, to keep the log lines short.
+const maxLogSliceLen = 32
+
+// LogFields returns the exported (public) fields of a struct as a map which is
+// safe to pass to a structured logger, such as to the With method of zap,
+// logrus or log/slog (after flattening it to key-value pairs).
+//
+//   - The value of a field tagged with `redact:"true"` is replaced with
+//     RedactedValue, and a field tagged with `redact:"omit"` is left out.
+//   - The fields of a nested struct (or of a non-nil pointer to a struct) are
+//     added with the "Parent.Child" keys. Structs nested deeper than one level,
+//     and the slices, arrays, maps and interfaces holding structs, are
+//     formatted as strings after applying the "redact" tags of those structs
+//     (each struct is formatted like a map of its fields).
+//   - Bools, numbers, strings, time.Time and time.Duration values are kept as
+//     they are, nil pointers are nil, and other kinds are formatted as strings
+//     (with their MarshalText method, if any).
+//   - Slices, arrays and maps longer than 32 items, and funcs and channels are
+//     left out.
+func LogFields(obj interface{}) (map[string]interface{}, error) {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	t := newTracker(newOptions(nil))
+	t.enter(valueOf(obj))
+	fields := map[string]interface{}{}
+	addLogFields(fields, objValue, "", t)
+	return fields, nil
+}
+
+// addLogFields adds the loggable fields of a struct to 'fields'. The fields of
+// the top level struct (at depth 1 in the tracker) are added as "Parent.Child".
+func addLogFields(fields map[string]interface{}, objValue reflect.Value, prefix string, t *tracker) {
+	objType := objValue.Type()
+	for i := 0; i < objValue.NumField(); i++ {
+		fieldType := objType.Field(i)
+		fieldValue := objValue.Field(i)
+		if !fieldValue.CanInterface() {
+			continue
+		}
+
+		key := prefix + fieldType.Name
+		switch fieldType.Tag.Get("redact") {
+		case "true":
+			fields[key] = RedactedValue
+			continue
+		case "omit":
+			continue
+		}
+
+		ptrValue := fieldValue
+		if fieldValue.Kind() == reflect.Ptr {
+			if fieldValue.IsNil() {
+				fields[key] = nil
+				continue
+			}
+			fieldValue = fieldValue.Elem()
+		}
+
+		if t.depth == 1 && isLogStruct(fieldValue) {
+			if err := t.enter(ptrValue); err != nil {
+				fields[key] = ptrValue.Type().String()
+				continue
+			}
+			addLogFields(fields, fieldValue, key+pathSeparator, t)
+			t.leave(ptrValue)
+			continue
+		}
+
+		if value, ok := redactValue(ptrValue, t); ok {
+			fields[key] = fmt.Sprint(value)
+			continue
+		}
+		if value, ok := logValue(fieldValue); ok {
+			fields[key] = value
+		}
+	}
+}
+
+// logValue returns a field value as it is logged by LogFields, and false if
+// the field is left out.
+func logValue(value reflect.Value) (interface{}, bool) {
+	switch value.Kind() {
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return nil, false
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if value.Len() > maxLogSliceLen {
+			return nil, false
+		}
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.String:
+		return value.Interface(), true
+	}
+
+	if value.Type() == timeType {
+		return value.Interface(), true
+	}
+
+	if value.Kind() == reflect.Interface && value.IsNil() {
+		return nil, true
+	}
+
+	text, err := formatValue(value, time.RFC3339)
+	if err != nil {
+		return fmt.Sprint(value.Interface()), true
+	}
+	return text, true
+}
+
+// isLogStruct returns true if a value is a struct whose fields are logged, as
+// opposed to a time.Time or a struct which formats itself as text.
+func isLogStruct(value reflect.Value) bool {
+	return value.Kind() == reflect.Struct && value.Type() != timeType && !isTextMarshaler(value)
+}
+
+// redactValue returns a copy of a value holding structs with the "redact" tags
+// of those structs applied, and false if the value doesn't hold any structs.
+// The structs are copied as maps of their loggable fields, and the slices,
+// arrays and maps holding them as []interface{} and map[string]interface{}
+// values. A struct which references itself through pointers is copied as its
+// type name at the second reference.
+func redactValue(value reflect.Value, t *tracker) (interface{}, bool) {
+	if !holdsStruct(value.Type()) {
+		return nil, false
+	}
+
+	switch value.Kind() {
+	case reflect.Interface:
+		if value.IsNil() {
+			return nil, false
+		}
+		return redactValue(value.Elem(), t)
+
+	case reflect.Ptr:
+		if value.IsNil() {
+			return nil, true
+		}
+		if !isLogStruct(value.Elem()) {
+			return redactValue(value.Elem(), t)
+		}
+		if err := t.enter(value); err != nil {
+			return value.Type().String(), true
+		}
+		defer t.leave(value)
+		return redactStruct(value.Elem(), t), true
+
+	case reflect.Struct:
+		if !isLogStruct(value) {
+			return nil, false
+		}
+		t.enter(value)
+		defer t.leave(value)
+		return redactStruct(value, t), true
+
+	case reflect.Slice, reflect.Array:
+		if value.Len() > maxLogSliceLen {
+			return nil, false
+		}
+		items := make([]interface{}, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			if item, ok := redactItem(value.Index(i), t); ok {
+				items = append(items, item)
+			}
+		}
+		return items, true
+
+	case reflect.Map:
+		if value.Len() > maxLogSliceLen {
+			return nil, false
+		}
+		items := make(map[string]interface{}, value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			if item, ok := redactItem(iter.Value(), t); ok {
+				items[fmt.Sprint(iter.Key().Interface())] = item
+			}
+		}
+		return items, true
+	}
+
+	return nil, false
+}
+
+// redactStruct returns the loggable fields of a struct as a map, with the
+// "redact" tags applied.
+func redactStruct(objValue reflect.Value, t *tracker) map[string]interface{} {
+	objType := objValue.Type()
+	fields := map[string]interface{}{}
+	for i := 0; i < objValue.NumField(); i++ {
+		fieldType := objType.Field(i)
+		fieldValue := objValue.Field(i)
+		if !fieldValue.CanInterface() {
+			continue
+		}
+
+		switch fieldType.Tag.Get("redact") {
+		case "true":
+			fields[fieldType.Name] = RedactedValue
+			continue
+		case "omit":
+			continue
+		}
+
+		if value, ok := redactItem(fieldValue, t); ok {
+			fields[fieldType.Name] = value
+		}
+	}
+	return fields
+}
+
+// redactItem returns a field or an item of a slice or a map as it is logged,
+// and false if it is left out.
+func redactItem(value reflect.Value, t *tracker) (interface{}, bool) {
+	if redacted, ok := redactValue(value, t); ok {
+		return redacted, true
+	}
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil, true
+		}
+		value = value.Elem()
+	}
+	return logValue(value)
+}
+
+// holdsStruct returns true if the values of a type can hold structs whose
+// fields are logged, i.e. it is such a struct, an interface, or a pointer,
+// slice, array or map of such values.
+func holdsStruct(typ reflect.Type) bool {
+	// Follow the element types up to a type seen before, such as in
+	// "type Tree map[string]Tree".
+	seen := map[reflect.Type]bool{}
+	for !seen[typ] {
+		seen[typ] = true
+		switch typ.Kind() {
+		case reflect.Interface:
+			return true
+		case reflect.Struct:
+			return typ != timeType && !typ.Implements(textMarshalerType)
+		case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+			typ = typ.Elem()
+		default:
+			return false
+		}
+	}
+	return false
+}
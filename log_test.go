@@ -0,0 +1,135 @@
+package attr
+
+import (
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type LoginEvent struct {
+	User     string
+	Password string `redact:"true"`
+	Token    string `redact:"omit"`
+	Attempt  int
+	At       time.Time
+	Timeout  time.Duration
+	IP       net.IP
+	Client   *Address
+	Session  struct {
+		ID    string
+		Owner Address
+		Key   string `redact:"true"`
+	}
+	Scopes   []string
+	Blob     []int
+	Labels   map[string]string
+	Retry    *int
+	Callback func()
+	Extra    interface{}
+	internal string
+}
+
+func TestLogFields(t *testing.T) {
+	at := time.Date(2021, 1, 4, 10, 30, 0, 0, time.UTC)
+	event := LoginEvent{
+		User:     "srathi",
+		Password: "secret",
+		Token:    "abc",
+		Attempt:  2,
+		At:       at,
+		Timeout:  time.Second,
+		IP:       net.ParseIP("10.0.0.1"),
+		Client:   &Address{City: "San Jose"},
+		Scopes:   []string{"read"},
+		Blob:     make([]int, 100),
+		Labels:   map[string]string{"a": "b"},
+		Callback: func() {},
+		internal: "x",
+	}
+	event.Session.ID = "s1"
+	event.Session.Owner = Address{City: "Fremont"}
+	event.Session.Key = "k"
+
+	got, err := LogFields(&event)
+	require.Nil(t, err)
+	require.Equal(t, map[string]interface{}{
+		"User":          "srathi",
+		"Password":      RedactedValue,
+		"Attempt":       2,
+		"At":            at,
+		"Timeout":       time.Second,
+		"IP":            "10.0.0.1",
+		"Client.City":   "San Jose",
+		"Client.Zip":    "",
+		"Session.ID":    "s1",
+		"Session.Owner": "map[City:Fremont Zip:]",
+		"Session.Key":   RedactedValue,
+		"Scopes":        "[read]",
+		"Labels":        "map[a:b]",
+		"Retry":         nil,
+		"Extra":         nil,
+	}, got, "Log fields are not correct")
+
+	type Creds struct {
+		User     string
+		Password string `redact:"true"`
+		Token    string `redact:"omit"`
+	}
+	type Config struct {
+		DB struct {
+			Creds Creds
+		}
+		Backups []Creds
+		ByName  map[string]*Creds
+		Extra   interface{}
+	}
+	config := Config{
+		Backups: []Creds{{User: "b", Password: "s3cret"}},
+		ByName:  map[string]*Creds{"x": {User: "m", Password: "mapsecret", Token: "t"}, "y": nil},
+		Extra:   []interface{}{Creds{User: "e", Password: "esecret"}},
+	}
+	config.DB.Creds = Creds{User: "u", Password: "hunter2"}
+	got, err = LogFields(&config)
+	require.Nil(t, err)
+	require.Equal(t, map[string]interface{}{
+		"DB.Creds": "map[Password:[REDACTED] User:u]",
+		"Backups":  "[map[Password:[REDACTED] User:b]]",
+		"ByName":   "map[x:map[Password:[REDACTED] User:m] y:<nil>]",
+		"Extra":    "[map[Password:[REDACTED] User:e]]",
+	}, got, "Nested structs are not redacted")
+
+	chain := &Chain{Name: "a"}
+	chain.Next = &Chain{Name: "b", Next: chain}
+	got, err = LogFields(chain)
+	require.Nil(t, err)
+	require.Equal(t, map[string]interface{}{"Name": "a", "Next.Name": "b", "Next.Next": "*attr.Chain"},
+		got, "Self-referencing struct is not correct")
+
+	// A type holding itself is not followed forever.
+	type Tree map[string]Tree
+	got, err = LogFields(struct{ Root Tree }{Tree{"a": nil}})
+	require.Nil(t, err)
+	require.Equal(t, map[string]interface{}{"Root": "map[a:map[]]"}, got, "Recursive type is not correct")
+
+	_, err = LogFields(10)
+	require.Equal(t, ErrNotStruct, err, "Able to get log fields of a non-struct")
+}
+
+func ExampleLogFields() {
+	type Login struct {
+		User     string
+		Password string `redact:"true"`
+		Address  Address
+	}
+	login := Login{User: "srathi", Password: "secret", Address: Address{City: "San Jose"}}
+
+	fields, err := LogFields(login)
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Println(fields)
+	// Output: map[Address.City:San Jose Address.Zip: Password:[REDACTED] User:srathi]
+}
@@ -0,0 +1,88 @@
+package attr
+
+import "reflect"
+
+// Conflict is a field changed to different values on both sides of a
+// three-way merge, as reported by Merge3. Field is the field name, or a dot
+// separated path of names for a field of a nested struct.
+type Conflict struct {
+	Field  string
+	Base   interface{}
+	Mine   interface{}
+	Theirs interface{}
+}
+
+// Merge3 merges two edited versions of a struct, 'mine' and 'theirs', against
+// their common ancestor 'base', field by field. A field changed on one side
+// only gets the changed value, and a field changed on both sides to the same
+// value gets that value. A field changed on both sides to different values is
+// reported as a Conflict, and keeps the value of 'mine' in the result.
+//
+// The fields of the nested structs are merged individually, while other values
+// (including pointers, slices and maps) are compared with reflect.DeepEqual as
+// a whole, or with the function registered by RegisterComparer for their
+// type. Unexported fields are taken from 'mine'.
+//
+// All three structs must be of the same type, else ErrMismatchValue is
+// returned. They can be passed by value or by pointer, and the result is
+// returned in the same form as 'mine' is passed, i.e. a struct for a struct
+// and a pointer to a new struct for a pointer.
+func Merge3(base, mine, theirs interface{}) (interface{}, []Conflict, error) {
+	baseValue, err := getReflectValue(base)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mineValue, err := getReflectValue(mine)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	theirsValue, err := getReflectValue(theirs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if baseValue.Type() != mineValue.Type() || theirsValue.Type() != mineValue.Type() {
+		return nil, nil, ErrMismatchValue
+	}
+
+	result := reflect.New(mineValue.Type())
+	result.Elem().Set(mineValue)
+
+	conflicts := []Conflict{}
+	merge3(result.Elem(), baseValue, theirsValue, "", &conflicts)
+
+	if reflect.ValueOf(mine).Kind() == reflect.Ptr {
+		return result.Interface(), conflicts, nil
+	}
+	return result.Elem().Interface(), conflicts, nil
+}
+
+// merge3 merges the fields of 'theirs' into 'mine', which holds a copy of the
+// struct of 'mine', against the fields of 'base'.
+func merge3(mine, base, theirs reflect.Value, prefix string, conflicts *[]Conflict) {
+	objType := mine.Type()
+	for i := 0; i < mine.NumField(); i++ {
+		mineField := mine.Field(i)
+		if !mineField.CanSet() {
+			continue
+		}
+
+		path := prefix + objType.Field(i).Name
+		baseField, theirsField := base.Field(i), theirs.Field(i)
+		if compareByField(mineField.Type()) {
+			merge3(mineField, baseField, theirsField, path+pathSeparator, conflicts)
+			continue
+		}
+
+		mineValue, baseValue, theirsValue := mineField.Interface(), baseField.Interface(), theirsField.Interface()
+		switch {
+		case valuesEqual(mineValue, theirsValue), valuesEqual(theirsValue, baseValue):
+		case valuesEqual(mineValue, baseValue):
+			mineField.Set(theirsField)
+		default:
+			*conflicts = append(*conflicts, Conflict{path, baseValue, mineValue, theirsValue})
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package attr
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerge3(t *testing.T) {
+	base := Employee{Name: "srathi", Tags: []string{"go"}, Labels: map[string]int{"a": 1}, internal: "base"}
+	mine := base
+	mine.Tags = []string{"go", "rust"}
+	mine.Extra = "mine"
+	mine.internal = "mine"
+	theirs := base
+	theirs.Name = "shyam"
+	theirs.Labels = map[string]int{"b": 2}
+	theirs.Extra = "theirs"
+
+	got, conflicts, err := Merge3(base, &mine, &theirs)
+	require.Nil(t, err)
+	merged := got.(*Employee)
+	require.Equal(t, "shyam", merged.Name, "Change of theirs is not merged")
+	require.Equal(t, []string{"go", "rust"}, merged.Tags, "Change of mine is not merged")
+	require.Equal(t, map[string]int{"b": 2}, merged.Labels, "Map change of theirs is not merged")
+	require.Equal(t, "mine", merged.Extra, "Conflicting field doesn't keep mine")
+	require.Equal(t, "mine", merged.internal, "Unexported field is not taken from mine")
+	require.Equal(t, []Conflict{{"Extra", nil, "mine", "theirs"}}, conflicts, "Conflicts are not correct")
+	require.Equal(t, "srathi", mine.Name, "Input struct is modified")
+
+	type Settings struct {
+		Theme string
+		Size  Address
+	}
+	baseSettings := Settings{"dark", Address{"San Jose", "95134"}}
+	mineSettings := Settings{"light", Address{"Fremont", "95134"}}
+	theirsSettings := Settings{"light", Address{"Milpitas", "95035"}}
+	got, conflicts, err = Merge3(baseSettings, mineSettings, theirsSettings)
+	require.Nil(t, err)
+	require.Equal(t, Settings{"light", Address{"Fremont", "95035"}}, got, "Merge of nested fields is not correct")
+	require.Equal(t, []Conflict{{"Size.City", "San Jose", "Fremont", "Milpitas"}}, conflicts,
+		"Conflict of a nested field is not correct")
+
+	_, _, err = Merge3(base, mine, user)
+	require.Equal(t, ErrMismatchValue, err, "Able to merge different struct types")
+
+	_, _, err = Merge3(base, 10, theirs)
+	require.Equal(t, ErrNotStruct, err, "Able to merge a non-struct")
+}
+
+func ExampleMerge3() {
+	base := User{Username: "srathi", Age: 30}
+	mine := User{Username: "shyam", Age: 30}
+	theirs := User{Username: "sam", Age: 31}
+
+	merged, conflicts, err := Merge3(base, mine, theirs)
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Println(merged.(User).Age, merged.(User).Username)
+	for _, conflict := range conflicts {
+		fmt.Printf("%s: %v vs %v\n", conflict.Field, conflict.Mine, conflict.Theirs)
+	}
+	// Output:
+	// 31 shyam
+	// Username: shyam vs sam
+}
@@ -0,0 +1,84 @@
+package attr
+
+import (
+	"expvar"
+	"reflect"
+)
+
+// PublishExpvar publishes the numeric, bool and string exported (public)
+// fields of a struct under the given expvar name, as a JSON object keyed by
+// the field paths (such as "Address.City" for the fields of nested structs).
+// The fields are read again every time the variable is read, such as on a
+// request to /debug/vars.
+//
+// ErrNameInUse is returned if the name is already published, since expvar
+// doesn't allow to replace a variable.
+//
+// NOTE: 'obj' struct must be passed by pointer for this API to work. Passing by
+// value results in ErrNotPtr. Reading the variable doesn't synchronize with the
+// goroutines modifying the struct, so the caller must make sure that the
+// struct isn't modified concurrently, such as by using the atomic operations.
+func PublishExpvar(name string, obj interface{}) error {
+	objValue, err := getStructByPtr(obj)
+	if err != nil {
+		return err
+	}
+
+	if expvar.Get(name) != nil {
+		return ErrNameInUse
+	}
+
+	expvar.Publish(name, expvar.Func(func() interface{} {
+		snapshot := map[string]interface{}{}
+		snapshotFields(objValue, "", snapshot, newTracker(newOptions(nil)))
+		for path, value := range snapshot {
+			fieldValue := reflect.ValueOf(value)
+			if _, ok := metricValue(fieldValue); !ok && fieldValue.Kind() != reflect.String {
+				delete(snapshot, path)
+			}
+		}
+		return snapshot
+	}))
+	return nil
+}
+
+// MetricsSnapshot returns the values of the numeric and bool exported (public)
+// fields of a struct as float64 numbers, keyed by the field paths (such as
+// "Stats.Count" for the fields of nested structs), to feed them to a metrics
+// system. A bool is returned as 1 for true and 0 for false, and a
+// time.Duration as its number of nanoseconds.
+func MetricsSnapshot(obj interface{}) (map[string]float64, error) {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := map[string]interface{}{}
+	snapshotFields(objValue, "", snapshot, newTracker(newOptions(nil)))
+
+	metrics := map[string]float64{}
+	for path, value := range snapshot {
+		if metric, ok := metricValue(reflect.ValueOf(value)); ok {
+			metrics[path] = metric
+		}
+	}
+	return metrics, nil
+}
+
+// metricValue returns a numeric or bool value as a float64 number.
+func metricValue(value reflect.Value) (float64, bool) {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(value.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), true
+	case reflect.Bool:
+		if value.Bool() {
+			return 1, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
@@ -0,0 +1,80 @@
+package attr
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type ServerStats struct {
+	Requests uint64
+	Errors   int
+	Ratio    float32
+	Healthy  bool
+	Version  string
+	Uptime   time.Duration
+	Backend  struct{ Conns int }
+	Started  time.Time
+	Labels   []string
+	hits     int
+}
+
+func TestPublishExpvar(t *testing.T) {
+	// expvar names can't be unpublished, so use a new name on every run.
+	name := fmt.Sprintf("attr_test_stats_%d", time.Now().UnixNano())
+	stats := ServerStats{Requests: 10, Version: "1.0", hits: 3}
+	require.Nil(t, PublishExpvar(name, &stats))
+	require.Equal(t, ErrNameInUse, PublishExpvar(name, &stats), "Able to publish a name twice")
+	require.Equal(t, ErrNotPtr, PublishExpvar(name+"_other", stats), "Able to publish a struct passed by value")
+
+	stats.Requests = 11
+	stats.Backend.Conns = 2
+	got := map[string]interface{}{}
+	require.Nil(t, json.Unmarshal([]byte(expvar.Get(name).String()), &got))
+	require.Equal(t, map[string]interface{}{
+		"Requests":      11.0,
+		"Errors":        0.0,
+		"Ratio":         0.0,
+		"Healthy":       false,
+		"Version":       "1.0",
+		"Uptime":        0.0,
+		"Backend.Conns": 2.0,
+	}, got, "Published fields are not correct")
+}
+
+func TestMetricsSnapshot(t *testing.T) {
+	stats := ServerStats{Requests: 10, Errors: -1, Ratio: 0.5, Healthy: true, Uptime: time.Second}
+	stats.Backend.Conns = 4
+	got, err := MetricsSnapshot(stats)
+	require.Nil(t, err)
+	require.Equal(t, map[string]float64{
+		"Requests":      10,
+		"Errors":        -1,
+		"Ratio":         0.5,
+		"Healthy":       1,
+		"Uptime":        1e9,
+		"Backend.Conns": 4,
+	}, got, "Metrics are not correct")
+
+	_, err = MetricsSnapshot("abc")
+	require.Equal(t, ErrNotStruct, err, "Able to get metrics of a non-struct")
+}
+
+func ExampleMetricsSnapshot() {
+	type CacheStats struct {
+		Hits   int
+		Misses int
+		Name   string
+	}
+
+	metrics, err := MetricsSnapshot(CacheStats{Hits: 90, Misses: 10, Name: "users"})
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Println(metrics)
+	// Output: map[Hits:90 Misses:10]
+}
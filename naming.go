@@ -0,0 +1,118 @@
+package attr
+
+import (
+	"strings"
+	"unicode"
+)
+
+// splitWords splits a Go identifier or a delimited name into its words, such
+// as "HTTPServerID" into "HTTP", "Server" and "ID", and "user_name" into "user"
+// and "name". Underscores, dashes, dots and spaces are treated as delimiters.
+func splitWords(name string) []string {
+	words := []string{}
+	runes := []rune(name)
+	start := -1
+	for i, r := range runes {
+		if r == '_' || r == '-' || r == '.' || unicode.IsSpace(r) {
+			if start != -1 {
+				words = append(words, string(runes[start:i]))
+				start = -1
+			}
+			continue
+		}
+
+		if start == -1 {
+			start = i
+			continue
+		}
+
+		// A new word starts at an upper case letter after a lower case letter
+		// or a digit ("userID"), or at the last upper case letter of an
+		// acronym followed by a lower case letter ("HTTPServer").
+		prev := runes[i-1]
+		if unicode.IsUpper(r) && (unicode.IsLower(prev) || unicode.IsDigit(prev) ||
+			(unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+
+	if start != -1 {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// KeyNaming converts a field name to a key, for the APIs which derive the keys
+// of the fields without a tag, such as ToValues and FromMap. Any function can
+// be used, or one of the built-in naming strategies.
+type KeyNaming func(fieldName string) string
+
+// Built-in naming strategies for WithKeyNaming.
+var (
+	// SnakeCase converts "HTTPServerID" to "http_server_id".
+	SnakeCase KeyNaming = ToSnake
+	// CamelCase converts "HTTPServerID" to "httpServerId".
+	CamelCase KeyNaming = ToCamel
+	// PascalCase converts "HTTPServerID" to "HttpServerId".
+	PascalCase KeyNaming = ToPascal
+	// KebabCase converts "HTTPServerID" to "http-server-id".
+	KebabCase KeyNaming = ToKebab
+	// UpperSnake converts "HTTPServerID" to "HTTP_SERVER_ID".
+	UpperSnake KeyNaming = ToUpperSnake
+)
+
+// keyName returns the key of a field name with the given naming strategy, or
+// with the default one if it is nil. A nil default keeps the field name.
+func keyName(fieldName string, naming, defaultNaming KeyNaming) string {
+	if naming == nil {
+		naming = defaultNaming
+	}
+	if naming == nil {
+		return fieldName
+	}
+	return naming(fieldName)
+}
+
+// ToSnake converts a name to the snake case form, such as "HTTPServerID" to
+// "http_server_id". Go identifiers (with acronyms) and names delimited by
+// underscores, dashes, dots or spaces are split into words in the same way.
+func ToSnake(name string) string {
+	return strings.ToLower(strings.Join(splitWords(name), "_"))
+}
+
+// ToKebab converts a name to the kebab case form, such as "HTTPServerID" to
+// "http-server-id".
+func ToKebab(name string) string {
+	return strings.ToLower(strings.Join(splitWords(name), "-"))
+}
+
+// ToCamel converts a name to the camel case form, such as "HTTPServerID" to
+// "httpServerId" and "user_name" to "userName".
+func ToCamel(name string) string {
+	words := splitWords(name)
+	for i, word := range words {
+		word = strings.ToLower(word)
+		if i > 0 {
+			word = capitalize(word)
+		}
+		words[i] = word
+	}
+	return strings.Join(words, "")
+}
+
+// ToPascal converts a name to the Pascal case form, such as "HTTPServerID" to
+// "HttpServerId" and "user_name" to "UserName".
+func ToPascal(name string) string {
+	words := splitWords(name)
+	for i, word := range words {
+		words[i] = capitalize(strings.ToLower(word))
+	}
+	return strings.Join(words, "")
+}
+
+// ToUpperSnake converts a name to the upper case snake form, such as
+// "HTTPServerID" to "HTTP_SERVER_ID".
+func ToUpperSnake(name string) string {
+	return strings.ToUpper(strings.Join(splitWords(name), "_"))
+}
@@ -0,0 +1,101 @@
+package attr
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitWords(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		want []string
+	}{
+		{"Username", []string{"Username"}},
+		{"userID", []string{"user", "ID"}},
+		{"HTTPServerID", []string{"HTTP", "Server", "ID"}},
+		{"MaxConns2Go", []string{"Max", "Conns2", "Go"}},
+		{"user_name-id.v2", []string{"user", "name", "id", "v2"}},
+		{"", []string{}},
+	} {
+		require.Equal(t, test.want, splitWords(test.name), "Words of %q are not correct", test.name)
+	}
+}
+
+func TestKeyNaming(t *testing.T) {
+	for _, test := range []struct {
+		naming KeyNaming
+		want   string
+	}{
+		{SnakeCase, "http_server_id"},
+		{CamelCase, "httpServerId"},
+		{KebabCase, "http-server-id"},
+		{PascalCase, "HttpServerId"},
+		{UpperSnake, "HTTP_SERVER_ID"},
+	} {
+		require.Equal(t, test.want, test.naming("HTTPServerID"), "Key name is not correct")
+	}
+}
+
+func TestCaseConversion(t *testing.T) {
+	for _, test := range []struct {
+		convert func(string) string
+		name    string
+		want    string
+	}{
+		{ToSnake, "userID", "user_id"},
+		{ToSnake, "user-name", "user_name"},
+		{ToKebab, "MaxConns2Go", "max-conns2-go"},
+		{ToCamel, "user_name", "userName"},
+		{ToCamel, "ID", "id"},
+		{ToPascal, "user.name", "UserName"},
+		{ToUpperSnake, "dbHost", "DB_HOST"},
+		{ToCamel, "", ""},
+	} {
+		require.Equal(t, test.want, test.convert(test.name), "Conversion of %q is not correct", test.name)
+	}
+}
+
+func ExampleToSnake() {
+	fmt.Println(ToSnake("HTTPServerID"), ToCamel("user_name"), ToKebab("MaxConns"), ToPascal("user-id"))
+	// Output: http_server_id userName max-conns UserId
+}
+
+func TestWithKeyNaming(t *testing.T) {
+	account := Account{Base: Base{ID: 7}, Name: "srathi", Active: true}
+	values, err := ToValues(&account, "url", WithKeyNaming(KebabCase))
+	require.Nil(t, err)
+	require.Equal(t, "srathi", values.Get("name"), "Key of an untagged field not converted")
+	require.Equal(t, "true", values.Get("active"), "Key of an untagged field not converted")
+
+	query, _, err := InsertSQL(&account, "accounts", WithKeyNaming(strings.ToUpper))
+	require.Nil(t, err)
+	require.Equal(t, "INSERT INTO accounts (id, name, balance, ACTIVE) VALUES (?, ?, ?, ?)", query,
+		"Column of an untagged field not converted")
+
+	var config AppConfig
+	input := map[string]interface{}{"hosts": []string{"a"}, "DB": map[string]interface{}{"max_conns": 10}}
+	require.Nil(t, FromMap(&config, input, "env", WithKeyNaming(SnakeCase), WithStrict()))
+	require.Equal(t, []string{"a"}, config.Hosts, "Field set from a converted key")
+	require.Equal(t, 10, config.DB.MaxConns, "Field set from a converted key")
+
+	env, err := ToEnv(&DBConfig{MaxConns: 3}, "APP", WithKeyNaming(KebabCase))
+	require.Nil(t, err)
+	require.Contains(t, env, "APP_max-conns=3", "Environment name not converted")
+}
+
+func ExampleWithKeyNaming() {
+	type Request struct {
+		UserID   int
+		PageSize int `url:"size"`
+	}
+
+	values, err := ToValues(Request{UserID: 7, PageSize: 20}, "url", WithKeyNaming(SnakeCase))
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Println(values.Encode())
+	// Output: size=20&user_id=7
+}
@@ -0,0 +1,162 @@
+package attr
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNilPointerInputs(t *testing.T) {
+	var nilUser *User
+	var nilUsers *[]User
+
+	for name, call := range map[string]func() error{
+		"GetValue":      func() error { _, err := GetValue(nilUser, "Age"); return err },
+		"SetValue":      func() error { return SetValue(nilUser, "Age", 1) },
+		"DelValue":      func() error { return DelValue(nilUser, "Age") },
+		"Has":           func() error { _, err := Has(nilUser, "Age"); return err },
+		"Names":         func() error { _, err := Names(nilUser); return err },
+		"Values":        func() error { _, err := Values(nilUser); return err },
+		"Tags":          func() error { _, err := Tags(nilUser, "json"); return err },
+		"Kinds":         func() error { _, err := Kinds(nilUser); return err },
+		"GetTag":        func() error { _, err := GetTag(nilUser, "Age", "json"); return err },
+		"GetKind":       func() error { _, err := GetKind(nilUser, "Age"); return err },
+		"EachValue":     func() error { return EachValue(nilUser, func(string, interface{}) bool { return true }) },
+		"OrderedValues": func() error { _, err := OrderedValues(nilUser); return err },
+		"RawField":      func() error { _, _, err := RawField(nilUser, "Age"); return err },
+		"FieldLen":      func() error { _, err := FieldLen(nilUser, "Age"); return err },
+		"Describe":      func() error { _, err := Describe(nilUser); return err },
+		"Stats":         func() error { _, err := Stats(nilUser); return err },
+		"JSONValues":    func() error { _, err := JSONValues(nilUser); return err },
+		"ToValues":      func() error { _, err := ToValues(nilUser, "url"); return err },
+		"ToEnv":         func() error { _, err := ToEnv(nilUser, "APP"); return err },
+		"FromMap":       func() error { return FromMap(nilUser, map[string]interface{}{"Age": 1}, "") },
+		"FromValues":    func() error { return FromValues(nilUser, url.Values{}, "") },
+		"Reset":         func() error { return Reset(nilUser) },
+		"ResetFields":   func() error { return ResetFields(nilUser, "Age") },
+		"SetPaths":      func() error { return SetPaths(nilUser, map[string]interface{}{"Age": 1}) },
+		"Diff":          func() error { _, err := Diff(nilUser, user); return err },
+		"EqualExported": func() error { _, err := EqualExported(user, nilUser); return err },
+		"DeepSize":      func() error { _, err := DeepSize(nilUser); return err },
+		"CheckRequired": func() error { _, err := CheckRequired(nilUser); return err },
+		"FindFields":    func() error { _, err := FieldsEqual(nilUser, 1); return err },
+		"TransformKind": func() error { return TransformKind(nilUser, reflect.Int, func(v interface{}) interface{} { return v }) },
+		"InsertSQL":     func() error { _, _, err := InsertSQL(nilUser, "users"); return err },
+		"Graph":         func() error { _, err := Graph(nilUser); return err },
+		"Retag": func() error {
+			_, err := Retag(nilUser, func(_ string, tag reflect.StructTag) reflect.StructTag { return tag })
+			return err
+		},
+		"Dir":                func() error { _, err := Dir(nilUser); return err },
+		"CallField":          func() error { _, err := CallField(nilUser, "Age"); return err },
+		"IsKind":             func() error { _, err := IsKind(nilUser, "Age", reflect.Int); return err },
+		"Lens":               func() error { _, err := Lens("Age").Get(nilUser); return err },
+		"WithValue":          func() error { _, err := WithValue(nilUser, "Age", 1); return err },
+		"Pluck":              func() error { _, err := Pluck(nilUsers, "Age"); return err },
+		"Filter":             func() error { _, err := FilterEqual(nilUsers, "Age", 1); return err },
+		"SortByField":        func() error { return SortByField(nilUsers, "Age", false) },
+		"SetValueEach":       func() error { return SetValueEach(nilUsers, "Age", 1) },
+		"SetInSlice":         func() error { return SetInSlice(nilUsers, 0, "Age", 1) },
+		"SetInMap":           func() error { return SetInMap((*map[string]User)(nil), "a", "Age", 1) },
+		"ToPointerStruct":    func() error { return ToPointerStruct(nilUser, &UserPatch{}) },
+		"FromPointerStruct":  func() error { return FromPointerStruct((*UserPatch)(nil), &User{}) },
+		"SetValueFromString": func() error { return SetValueFromString(nilUser, "Age", "1") },
+		"CheckEnums":         func() error { _, err := CheckEnums(nilUser); return err },
+		"SumField":           func() error { _, err := SumField(nilUsers, "Age"); return err },
+		"GroupBy":            func() error { _, err := GroupBy(nilUsers, "Age"); return err },
+		"CompareAndSwap":     func() error { _, err := CompareAndSwapField(nilUser, "Age", 0, 1); return err },
+	} {
+		var err error
+		require.NotPanics(t, func() { err = call() }, "%s panics on a nil pointer", name)
+		require.True(t, errors.Is(err, ErrNilPointer), "%s returns %v for a nil pointer", name, err)
+	}
+
+	_, ok := TryGet[int](nilUser, "Age")
+	require.False(t, ok)
+	_, ok = GetOk(nilUser, "Age")
+	require.False(t, ok)
+
+	// A nil interface is not a struct, and a nil slice is an empty slice.
+	_, err := GetValue(nil, "Age")
+	require.Equal(t, ErrNotStruct, err)
+	require.Equal(t, ErrNotPtr, SetValue(nil, "Age", 1))
+	ages, err := Pluck([]User(nil), "Age")
+	require.Nil(t, err)
+	require.Equal(t, []int{}, ages, "Plucked values of a nil slice are not correct")
+	_, err = Pluck((*int)(nil), "Age")
+	require.Equal(t, ErrNotSlice, err)
+	_, err = GetValue((*int)(nil), "Age")
+	require.Equal(t, ErrNotStruct, err)
+}
+
+// Inner and Outer have a field promoted through an embedded pointer.
+type Inner struct {
+	X int
+}
+
+type Outer struct {
+	*Inner
+	Y    int
+	Next *Outer
+}
+
+func TestNilEmbeddedPointer(t *testing.T) {
+	outer := &Outer{Next: &Outer{}}
+	nested := &NilPathError{"Next.X", 2}
+
+	_, err := GetValue(outer, "X")
+	require.Equal(t, ErrNilPointer, err)
+
+	err = SetValue(outer, "X", 1)
+	require.Equal(t, ErrNilPointer, err)
+
+	_, err = GetKind(outer, "X")
+	require.Equal(t, ErrNilPointer, err)
+
+	err = DelValue(outer, "X")
+	require.Equal(t, &NilPathError{"X", 1}, err)
+
+	_, _, err = RawField(outer, "Next.X")
+	require.Equal(t, nested, err)
+
+	_, ok := TryGet[int](outer, "Next.X")
+	require.False(t, ok)
+
+	_, ok = GetOk(outer, "X")
+	require.False(t, ok)
+
+	_, err = Eval(outer, "Next.X == 1")
+	require.Equal(t, nested, err)
+
+	err = SetPaths(outer, map[string]interface{}{"Next.X": 1})
+	require.Equal(t, &FieldError{"Next.X", nested}, err)
+
+	_, err = WithValue(outer, "Next.X", 1)
+	require.Equal(t, nested, err)
+
+	_, err = GetPathAny(outer, "Next.X")
+	require.Equal(t, nested, err)
+
+	found, err := Has(outer, "X")
+	require.NoError(t, err)
+	require.True(t, found)
+
+	// The promoted fields are read once the embedded struct is set.
+	outer.Next.Inner = &Inner{X: 5}
+	value, err := GetPathAny(outer, "Next.X")
+	require.NoError(t, err)
+	require.Equal(t, 5, value)
+	require.Nil(t, SetPaths(outer, map[string]interface{}{"Next.X": 6}))
+	require.Equal(t, 6, outer.Next.X)
+}
+
+func ExampleGetValue_nilPointer() {
+	var testUser *User
+	_, err := GetValue(testUser, "Age")
+	fmt.Println(err == ErrNilPointer)
+	// Output: true
+}
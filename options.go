@@ -0,0 +1,344 @@
+package attr
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Option configures the optional behavior of the APIs which accept it.
+// Options which don't apply to an API are ignored by it.
+type Option func(*options)
+
+// options holds the optional settings of an API call.
+type options struct {
+	seed         int64
+	dollarParams bool
+	timeLayout   string
+	squash       bool
+	maxDepth     int
+	unexported   bool
+	strict       bool
+	weakTypes    bool
+	hooks        []DecodeHook
+	keyNaming    KeyNaming
+	jsonRequired bool
+	interfaces   bool
+	changeHook   func(ChangeRecord)
+	fields       []string
+	nullValues   bool
+	funcPolicy   FuncPolicy
+	fieldPattern *regexp.Regexp
+	sortedKeys   bool
+	squashTag    string
+	accessors    bool
+	ctx          context.Context
+	parallelism  int
+	// embedFilter, if set, leaves out the embedded structs it returns false
+	// for (and the fields promoted from them) with the squash option. It is
+	// set by the APIs themselves, not by an Option.
+	embedFilter func(reflect.StructField) bool
+	// err is an invalid option given by the caller, returned by the APIs
+	// which use the option.
+	err error
+}
+
+// newOptions returns the settings for an API call, starting from the default
+// values and applying the given options in order.
+func newOptions(opts []Option) *options {
+	o := &options{
+		seed:       time.Now().UnixNano(),
+		timeLayout: time.RFC3339,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithSeed sets the seed of the pseudo-random generator used by Fill, so that
+// the generated data is reproducible.
+func WithSeed(seed int64) Option {
+	return func(o *options) {
+		o.seed = seed
+	}
+}
+
+// WithDollarParams makes the SQL generating APIs use numbered parameters, such
+// as "$1" and "$2" (PostgreSQL style), instead of "?".
+func WithDollarParams() Option {
+	return func(o *options) {
+		o.dollarParams = true
+	}
+}
+
+// WithTimeLayout sets the layout used to format and parse the time.Time
+// values as text. The default layout is time.RFC3339.
+func WithTimeLayout(layout string) Option {
+	return func(o *options) {
+		o.timeLayout = layout
+	}
+}
+
+// WithSquash makes Names, Values, Tags and Kinds promote the fields of the
+// embedded structs into the result, like the "squash" option of mapstructure,
+// instead of reporting each embedded struct as a single field named after its
+// type. A field of the outer struct hides a promoted field of the same name.
+func WithSquash() Option {
+	return func(o *options) {
+		o.squash = true
+	}
+}
+
+// WithSquashTag sets the tag whose ",squash" option flattens a struct field
+// into the key space of its parent in FromMap, FromValues and ToValues, such
+// as "mapstructure" for the `mapstructure:",squash"` convention. The fields
+// of the flattened struct are looked up and added with the keys of the parent,
+// whether the struct field is embedded or not, and by value or by pointer.
+// By default, the ",squash" option is read from the tag given to these APIs.
+func WithSquashTag(tagKey string) Option {
+	return func(o *options) {
+		o.squashTag = tagKey
+	}
+}
+
+// WithAccessors makes GetValue and SetValue fall back to the getter and setter
+// methods of a struct for a field name which isn't a field, such as GetArea
+// and SetArea for "Area", so that computed attributes can be used like plain
+// fields. A getter takes no arguments and returns the value, optionally
+// followed by an error. A setter takes the value and returns nothing or an
+// error. The errors of the methods are returned as is, and ErrNoField is
+// returned if there is no such method (or it doesn't have such a signature).
+// The methods with a pointer receiver are found only if 'obj' is passed by
+// pointer, and WithChangeHook is ignored for the setters.
+func WithAccessors() Option {
+	return func(o *options) {
+		o.accessors = true
+	}
+}
+
+// WithContext makes the APIs which recurse into the nested structs check the
+// given context as they go, and return its error (such as context.Canceled or
+// context.DeadlineExceeded) once it is done, such as to bound the time spent
+// on a very large object graph in a request handler. It is honored by Diff,
+// FindFields, FieldsEqual, CheckRequired, TransformStrings, TransformKind,
+// ToValues, ToEnv and the APIs setting the fields from sources (such as
+// FromMap and FromEnv). The context is checked before each nested struct, and
+// the struct may be partially updated by the APIs which modify it.
+func WithContext(ctx context.Context) Option {
+	return func(o *options) {
+		o.ctx = ctx
+	}
+}
+
+// WithParallelism makes the bulk APIs over slices (Filter, FilterEqual,
+// Pluck and SetValueEach) split the elements into 'n' contiguous shards
+// processed by concurrent goroutines, and merge their results in order, such
+// as for slices of millions of elements. A value of 1 or less processes the
+// elements in the calling goroutine, which is the default. It pays off only
+// for large slices, as starting the goroutines has a cost.
+func WithParallelism(n int) Option {
+	return func(o *options) {
+		o.parallelism = n
+	}
+}
+
+// WithMaxDepth limits the APIs which recurse into the nested structs to the
+// given number of nesting levels, where 1 means only the fields of the top
+// level struct. The nested structs beyond the limit are skipped. A depth of 0
+// or less means no limit, which is the default.
+//
+// Regardless of the depth, these APIs return ErrCycleDetected if a struct
+// references itself through pointers, instead of recursing forever.
+func WithMaxDepth(depth int) Option {
+	return func(o *options) {
+		o.maxDepth = depth
+	}
+}
+
+// WithUnexported makes Names, Tags and Kinds include the unexported (private)
+// fields of a struct, such as for inspecting its whole schema. The values of
+// the unexported fields can't be read, so Values ignores this option.
+func WithUnexported() Option {
+	return func(o *options) {
+		o.unexported = true
+	}
+}
+
+// WithStrict makes FromMap, FromValues and FromEnv return an *UnknownKeysError
+// listing the keys of the source which don't match any struct field, such as
+// misspelled configuration keys, instead of silently ignoring them.
+func WithStrict() Option {
+	return func(o *options) {
+		o.strict = true
+	}
+}
+
+// WithWeakTypes makes FromMap, FromValues and FromEnv convert the values whose
+// types don't match the fields more leniently, such as a number to a bool or a
+// string, a bool to a number, an empty string to a zero number and a single
+// value to a slice. This is useful for the loosely typed YAML or JSON data.
+func WithWeakTypes() Option {
+	return func(o *options) {
+		o.weakTypes = true
+	}
+}
+
+// WithKeyNaming sets the naming strategy of the keys of the fields without a
+// tag, such as SnakeCase, in ToValues, ToEnv, InsertSQL, UpdateSQL, FromMap,
+// FromValues and FromEnv. By default, ToEnv and FromEnv use UpperSnake, the
+// SQL APIs use the lower case field names and the others use the field names.
+func WithKeyNaming(naming KeyNaming) Option {
+	return func(o *options) {
+		o.keyNaming = naming
+	}
+}
+
+// WithJSONRequired makes CheckRequired treat the fields with a "json" tag
+// without "omitempty" as required fields, in addition to the tagged ones.
+func WithJSONRequired() Option {
+	return func(o *options) {
+		o.jsonRequired = true
+	}
+}
+
+// WithInterfaces makes the APIs which descend into the nested structs, such as
+// FindFields, ToValues and RawField, descend into the dynamic values of the
+// interface fields too, when they hold a struct or a pointer to a struct. By
+// default, an interface field is treated as a single opaque value.
+func WithInterfaces() Option {
+	return func(o *options) {
+		o.interfaces = true
+	}
+}
+
+// WithChangeHook sets a function which is called with a ChangeRecord for every
+// field set by SetValue, SetPaths or ApplyChanges, after the field is set. It
+// can be used to write an audit log of the changes, which can be replayed with
+// ApplyChanges.
+func WithChangeHook(hook func(ChangeRecord)) Option {
+	return func(o *options) {
+		o.changeHook = hook
+	}
+}
+
+// WithFields limits Diff and DiffString to the given fields. A field is given
+// by its name, or by a dot separated path of names for a field of a nested
+// struct. The path of a nested struct, such as "Address", allows all its
+// fields.
+func WithFields(paths ...string) Option {
+	return func(o *options) {
+		o.fields = append(o.fields, paths...)
+	}
+}
+
+// WithNullValues makes GetValue and Values return the value held by a field of
+// a database/sql null type (such as sql.NullString, sql.NullTime or
+// sql.Null[T]), or nil if its Valid field is false, instead of the null type
+// itself. SetValue accepts such a value or nil for these fields without the
+// option.
+func WithNullValues() Option {
+	return func(o *options) {
+		o.nullValues = true
+	}
+}
+
+// FuncPolicy tells how the func and chan fields are handled by the APIs which
+// read the values of all the fields, as set by WithFuncPolicy.
+type FuncPolicy int
+
+// Func and chan field policies.
+const (
+	// FuncInclude includes the func and chan fields as opaque values, which is
+	// the default.
+	FuncInclude FuncPolicy = iota
+	// FuncSkip skips the func and chan fields silently.
+	FuncSkip
+	// FuncError returns a *FieldError wrapping ErrFuncField for the first func
+	// or chan field.
+	FuncError
+)
+
+// WithFuncPolicy sets how Values, ToValues and ToEnv handle the func and chan
+// fields (including the ones held by an interface field with WithInterfaces),
+// which have no meaningful value to copy or format. Without this option, they
+// are included as opaque values.
+func WithFuncPolicy(policy FuncPolicy) Option {
+	return func(o *options) {
+		o.funcPolicy = policy
+	}
+}
+
+// skipFunc applies the WithFuncPolicy option to a field value. It returns true
+// if the field is to be skipped, along with a *FieldError with the given field
+// name for the FuncError policy.
+func (o *options) skipFunc(fieldValue reflect.Value, name string) (bool, error) {
+	kind := fieldValue.Kind()
+	if (kind != reflect.Func && kind != reflect.Chan) || o.funcPolicy == FuncInclude {
+		return false, nil
+	}
+
+	if o.funcPolicy == FuncError {
+		return true, &FieldError{name, ErrFuncField}
+	}
+	return true, nil
+}
+
+// WithFieldPattern limits Names, Values, Tags, Kinds, TagMatrix and
+// FilterByRole to the fields whose names match the given regular expression,
+// such as `^Secret` or `At$`. With WithSquash, the names of the promoted
+// fields are matched.
+func WithFieldPattern(re *regexp.Regexp) Option {
+	return func(o *options) {
+		o.fieldPattern = re
+	}
+}
+
+// WithSortedKeys makes OrderedValues, OrderedTags, OrderedKinds and ToEnv list
+// the fields sorted by their names (or the variable names for ToEnv) instead
+// of their declaration order.
+func WithSortedKeys() Option {
+	return func(o *options) {
+		o.sortedKeys = true
+	}
+}
+
+// allowsField returns true if the field with the given path is allowed by the
+// WithFields option, or if the option isn't given.
+func (o *options) allowsField(path string) bool {
+	if len(o.fields) == 0 {
+		return true
+	}
+
+	for _, field := range o.fields {
+		if path == field || strings.HasPrefix(path, field+pathSeparator) {
+			return true
+		}
+	}
+	return false
+}
+
+// WithDecodeHook adds a hook which FromMap, FromValues and FromEnv call on the
+// value of every field from the source, before converting it to the field
+// type. The hooks are called in the order they are given.
+//
+// The hook can be a DecodeHook or any of the hook function types of
+// mapstructure, i.e. with the signatures
+//
+//	func(from, to reflect.Type, data interface{}) (interface{}, error)
+//	func(from, to reflect.Kind, data interface{}) (interface{}, error)
+//	func(from, to reflect.Value) (interface{}, error)
+//
+// Any other value makes the API return ErrInvalidHook.
+func WithDecodeHook(hook interface{}) Option {
+	return func(o *options) {
+		decodeHook, err := toDecodeHook(hook)
+		if err != nil {
+			o.err = err
+			return
+		}
+		o.hooks = append(o.hooks, decodeHook)
+	}
+}
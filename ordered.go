@@ -0,0 +1,81 @@
+package attr
+
+import "sort"
+
+// FieldEntry is a field name along with a value of the field, such as its
+// value, tag or kind, as returned by the ordered variants of Values, Tags and
+// Kinds.
+type FieldEntry[T any] struct {
+	Name  string
+	Value T
+}
+
+// OrderedValues is the same as Values, but returns the field values as a slice
+// in the declaration order of the fields (or sorted by the field names with
+// WithSortedKeys), so that the output is deterministic, such as for the golden
+// files of tests.
+func OrderedValues(obj interface{}, opts ...Option) ([]FieldEntry[interface{}], error) {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	o := newOptions(opts)
+	entries := []FieldEntry[interface{}]{}
+	for _, field := range structFields(objValue, o) {
+		value, ok, err := fieldValueOf(field, o)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			entries = append(entries, FieldEntry[interface{}]{field.Name, value})
+		}
+	}
+
+	return sortEntries(entries, o), nil
+}
+
+// OrderedTags is the same as Tags, but returns the tag values as a slice in
+// the order of OrderedValues.
+func OrderedTags(obj interface{}, tagKey string, opts ...Option) ([]FieldEntry[string], error) {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	o := newOptions(opts)
+	entries := []FieldEntry[string]{}
+	for _, field := range structFields(objValue, o) {
+		entries = append(entries, FieldEntry[string]{field.Name, field.Tag.Get(tagKey)})
+	}
+
+	return sortEntries(entries, o), nil
+}
+
+// OrderedKinds is the same as Kinds, but returns the kinds as a slice in the
+// order of OrderedValues.
+func OrderedKinds(obj interface{}, opts ...Option) ([]FieldEntry[string], error) {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	o := newOptions(opts)
+	entries := []FieldEntry[string]{}
+	for _, field := range structFields(objValue, o) {
+		entries = append(entries, FieldEntry[string]{field.Name, field.value.Kind().String()})
+	}
+
+	return sortEntries(entries, o), nil
+}
+
+// sortEntries sorts the entries by their names if the WithSortedKeys option
+// is set.
+func sortEntries[T any](entries []FieldEntry[T], o *options) []FieldEntry[T] {
+	if o.sortedKeys {
+		sort.Slice(entries, func(i, j int) bool {
+			return entries[i].Name < entries[j].Name
+		})
+	}
+	return entries
+}
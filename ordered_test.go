@@ -0,0 +1,68 @@
+package attr
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderedValues(t *testing.T) {
+	got, err := OrderedValues(&user)
+	require.Nil(t, err)
+	require.Equal(t, []FieldEntry[interface{}]{{"Username", "srathi"}, {"Age", 30}}, got,
+		"Ordered values are not correct")
+
+	got, err = OrderedValues(Manager{User: user}, WithSquash(), WithSortedKeys())
+	require.Nil(t, err)
+	require.Equal(t, []FieldEntry[interface{}]{{"Age", 30}, {"Reports", []*User(nil)}, {"Username", "srathi"}},
+		got, "Sorted values are not correct")
+
+	_, err = OrderedValues(Server{Handler: func(string) string { return "" }}, WithFuncPolicy(FuncError))
+	require.Equal(t, &FieldError{"Handler", ErrFuncField}, err, "Func field not reported")
+
+	_, err = OrderedValues(10)
+	require.Equal(t, ErrNotStruct, err, "Able to get the values of a non-struct")
+}
+
+func TestOrderedTags(t *testing.T) {
+	got, err := OrderedTags(user, "json", WithUnexported())
+	require.Nil(t, err)
+	require.Equal(t, []FieldEntry[string]{{"Username", "username"}, {"Age", "age"}, {"password", ""}}, got,
+		"Ordered tags are not correct")
+
+	got, err = OrderedTags(user, "json", WithSortedKeys())
+	require.Nil(t, err)
+	require.Equal(t, []FieldEntry[string]{{"Age", "age"}, {"Username", "username"}}, got,
+		"Sorted tags are not correct")
+}
+
+func TestOrderedKinds(t *testing.T) {
+	got, err := OrderedKinds(&Manager{})
+	require.Nil(t, err)
+	require.Equal(t, []FieldEntry[string]{{"User", "struct"}, {"Reports", "slice"}}, got,
+		"Ordered kinds are not correct")
+}
+
+func TestToEnvSortedKeys(t *testing.T) {
+	config := DBConfig{Host: "localhost", Port: 5432, MaxConns: 10}
+	got, err := ToEnv(config, "DB", WithSortedKeys())
+	require.Nil(t, err)
+	require.Equal(t, []string{"DB_HOST=localhost", "DB_MAX_CONNS=10", "DB_PORT=5432"}, got,
+		"Sorted environment is not correct")
+}
+
+func ExampleOrderedValues() {
+	testUser := User{Username: "srathi", password: "secret", Age: 30}
+
+	entries, err := OrderedValues(testUser)
+	if err != nil {
+		// Handle error.
+	}
+	for _, entry := range entries {
+		fmt.Printf("%s=%v\n", entry.Name, entry.Value)
+	}
+	// Output:
+	// Username=srathi
+	// Age=30
+}
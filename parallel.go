@@ -0,0 +1,35 @@
+package attr
+
+import "sync"
+
+// parallelFor calls 'fn' on the ranges [start, end) splitting the indexes from
+// 0 to 'length' into 'workers' contiguous shards, each run by its own
+// goroutine, and waits for all of them. With fewer than 2 workers (or fewer
+// than 2 indexes), 'fn' is called once on the whole range instead. The error
+// of the first shard which failed, in the order of the indexes, is returned,
+// so the result doesn't depend on the scheduling.
+func parallelFor(workers, length int, fn func(start, end int) error) error {
+	workers = min(workers, length)
+	if workers < 2 {
+		return fn(0, length)
+	}
+
+	errs := make([]error, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		start, end := w*length/workers, (w+1)*length/workers
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			errs[w] = fn(start, end)
+		}(w)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package attr
+
+import "reflect"
+
+// ToPointerStruct converts a struct to a struct of pointers with the same
+// field names, such as User{Name string} to UserPatch{Name *string}, as used
+// for PATCH requests. 'src' can be passed by value or by pointer, and 'dstPtr'
+// must be a pointer to the struct of pointers.
+//
+// Every exported pointer field of the destination is set to a pointer to a
+// copy of the source field with the same name, if the source field is not
+// zero. If field names are given, only the listed fields are set, even if they
+// are zero. The other pointer fields are set to nil, and the fields of the
+// destination which are not pointers are left unchanged.
+//
+// A *FieldError is returned for a pointer field of the destination (or a given
+// field name) without a source field of the same name (ErrNoField), or whose
+// source field is not of its element type (ErrMismatchValue).
+func ToPointerStruct(src, dstPtr interface{}, fields ...string) error {
+	srcValue, err := getReflectValue(src)
+	if err != nil {
+		return err
+	}
+
+	dstValue, err := getStructByPtr(dstPtr)
+	if err != nil {
+		return err
+	}
+
+	listed := map[string]bool{}
+	for _, name := range fields {
+		field, found := cachedField(dstValue.Type(), name)
+		if !found || field.PkgPath != "" || field.Type.Kind() != reflect.Ptr {
+			return &FieldError{name, ErrNoField}
+		}
+		listed[name] = true
+	}
+
+	return eachPatchField(dstValue, srcValue, func(name string, ptrValue, fieldValue reflect.Value) {
+		if (len(listed) > 0 && !listed[name]) || (len(listed) == 0 && fieldValue.IsZero()) {
+			ptrValue.Set(reflect.Zero(ptrValue.Type()))
+			return
+		}
+		copyValue := reflect.New(fieldValue.Type())
+		copyValue.Elem().Set(fieldValue)
+		ptrValue.Set(copyValue)
+	})
+}
+
+// FromPointerStruct is the reverse of ToPointerStruct. It sets the fields of
+// the struct pointed to by 'dstPtr' to the values pointed to by the non-nil
+// pointer fields of 'src' with the same names, such as to apply a PATCH
+// request to a stored record. The nil pointer fields and the fields of 'src'
+// which are not pointers are skipped. 'src' can be passed by value or by
+// pointer.
+//
+// A *FieldError is returned for a pointer field of 'src' without a destination
+// field of the same name (ErrNoField), or whose destination field is not of
+// its element type (ErrMismatchValue). The destination is left unchanged if
+// an error is returned.
+func FromPointerStruct(src, dstPtr interface{}) error {
+	srcValue, err := getReflectValue(src)
+	if err != nil {
+		return err
+	}
+
+	dstValue, err := getStructByPtr(dstPtr)
+	if err != nil {
+		return err
+	}
+
+	return eachPatchField(srcValue, dstValue, func(name string, ptrValue, fieldValue reflect.Value) {
+		if !ptrValue.IsNil() {
+			fieldValue.Set(ptrValue.Elem())
+		}
+	})
+}
+
+// eachPatchField calls 'fn' for every exported pointer field of a struct of
+// pointers with the field of the same name of a struct. All such fields are
+// checked before 'fn' is called, so nothing is set if an error is returned.
+func eachPatchField(patchValue, objValue reflect.Value, fn func(name string, ptrValue, fieldValue reflect.Value)) error {
+	patchType := patchValue.Type()
+	type pair struct {
+		name                 string
+		ptrValue, fieldValue reflect.Value
+	}
+	pairs := make([]pair, 0, patchType.NumField())
+	for i := 0; i < patchType.NumField(); i++ {
+		patchField := patchType.Field(i)
+		if patchField.PkgPath != "" || patchField.Type.Kind() != reflect.Ptr {
+			continue
+		}
+
+		field, found := cachedField(objValue.Type(), patchField.Name)
+		if !found || field.PkgPath != "" {
+			return &FieldError{patchField.Name, ErrNoField}
+		}
+		if field.Type != patchField.Type.Elem() {
+			return &FieldError{patchField.Name, ErrMismatchValue}
+		}
+
+		fieldValue, ok := fieldByIndex(objValue, field.Index)
+		if !ok {
+			return &FieldError{patchField.Name, ErrNilPointer}
+		}
+		pairs = append(pairs, pair{patchField.Name, patchValue.Field(i), fieldValue})
+	}
+
+	for _, p := range pairs {
+		fn(p.name, p.ptrValue, p.fieldValue)
+	}
+	return nil
+}
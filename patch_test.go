@@ -0,0 +1,102 @@
+package attr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type UserPatch struct {
+	Username *string
+	Age      *int
+	Note     string
+	password *string
+}
+
+func TestToPointerStruct(t *testing.T) {
+	src := User{Username: "srathi"}
+	patch := UserPatch{Age: new(int), Note: "kept"}
+	err := ToPointerStruct(src, &patch)
+	require.NoError(t, err)
+	require.Equal(t, "srathi", *patch.Username)
+	require.Nil(t, patch.Age, "A zero field was set")
+	require.Equal(t, "kept", patch.Note)
+
+	// The pointers are to copies of the source fields.
+	src.Username = "changed"
+	require.Equal(t, "srathi", *patch.Username)
+
+	// The listed fields are set even if they are zero.
+	err = ToPointerStruct(&src, &patch, "Age")
+	require.NoError(t, err)
+	require.Nil(t, patch.Username)
+	require.Equal(t, 0, *patch.Age)
+
+	err = ToPointerStruct(src, &patch, "Note")
+	require.Equal(t, &FieldError{"Note", ErrNoField}, err)
+
+	err = ToPointerStruct(src, patch)
+	require.Equal(t, ErrNotPtr, err)
+
+	type BadPatch struct{ Age *string }
+	err = ToPointerStruct(src, &BadPatch{})
+	require.Equal(t, &FieldError{"Age", ErrMismatchValue}, err)
+
+	type ExtraPatch struct{ Email *string }
+	err = ToPointerStruct(src, &ExtraPatch{})
+	require.True(t, errors.Is(err, ErrNoField))
+}
+
+func TestFromPointerStruct(t *testing.T) {
+	age := 31
+	dst := User{Username: "srathi", Age: 30}
+	err := FromPointerStruct(UserPatch{Age: &age}, &dst)
+	require.NoError(t, err)
+	require.Equal(t, User{Username: "srathi", Age: 31}, dst)
+
+	name := "srathi-alt"
+	err = FromPointerStruct(&UserPatch{Username: &name}, &dst)
+	require.NoError(t, err)
+	require.Equal(t, "srathi-alt", dst.Username)
+
+	// Nothing is set if a field doesn't match.
+	type MixedPatch struct {
+		Age   *int
+		Email *string
+	}
+	err = FromPointerStruct(MixedPatch{Age: new(int)}, &dst)
+	require.Equal(t, &FieldError{"Email", ErrNoField}, err)
+	require.Equal(t, 31, dst.Age)
+
+	err = FromPointerStruct(UserPatch{}, dst)
+	require.Equal(t, ErrNotPtr, err)
+
+	err = FromPointerStruct(UserPatch{}, (*User)(nil))
+	require.Equal(t, ErrNilPointer, err)
+
+	// Round trip.
+	patch := UserPatch{}
+	require.NoError(t, ToPointerStruct(user, &patch))
+	got := User{}
+	require.NoError(t, FromPointerStruct(patch, &got))
+	require.Equal(t, User{Username: user.Username, Age: user.Age}, got)
+}
+
+func ExampleFromPointerStruct() {
+	stored := User{Username: "srathi", Age: 30}
+
+	// A PATCH request with only the age.
+	age := 31
+	patch := UserPatch{Age: &age}
+
+	if err := FromPointerStruct(patch, &stored); err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(stored.Username, stored.Age)
+
+	// Output:
+	// srathi 31
+}
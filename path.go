@@ -0,0 +1,197 @@
+package attr
+
+import (
+	"reflect"
+	"strings"
+)
+
+// pathSeparator separates the field names in the path of a nested field, such
+// as "Address.City".
+const pathSeparator = "."
+
+// maxIndex is the largest index of a slice or an array in a path.
+const maxIndex = int(^uint(0) >> 1)
+
+// getFieldByPath returns the reflect-value of a nested field of a struct given
+// by a dot separated path of field names. The pointers to the structs in the
+// path are followed. Every field in the path must be an exported field. A
+// field name can be followed by indexes of the elements of a slice or an array
+// field, such as "Tags[0]" or "Owners[1].Name".
+//
+// Returns an error if a field in the path is not found, is unexported, is not a
+// struct (or a pointer to a struct) or is a nil pointer. A nil pointer field in
+// the path is reported by a *NilPathError. ErrNotSlice is returned for an index
+// of a field which isn't a slice or an array, ErrOutOfRange for an index past
+// its length and ErrInvalidExpr for a malformed index.
+//
+// If 'interfaces' is true, the dynamic values of the interface fields in the
+// path are followed too, as set by the WithInterfaces option.
+func getFieldByPath(objValue reflect.Value, path string, interfaces bool) (reflect.Value, error) {
+	fieldValue, segment, err := walkPath(objValue, path, interfaces)
+	if err == ErrNilPointer && segment > 0 {
+		names := strings.SplitN(path, pathSeparator, segment+1)
+		return fieldValue, &NilPathError{strings.Join(names[:segment], pathSeparator), segment}
+	}
+	return fieldValue, err
+}
+
+// walkPath is getFieldByPath without the allocation of an error, for the
+// callers which only need to know whether the field can be read. On an error,
+// it also returns the number of the fields in the path which were read before
+// the failing one.
+func walkPath(objValue reflect.Value, path string, interfaces bool) (reflect.Value, int, error) {
+	var retval reflect.Value
+	fieldValue := objValue
+	for segment := 0; ; segment++ {
+		name := path
+		idx := strings.Index(path, pathSeparator)
+		if idx != -1 {
+			name, path = path[:idx], path[idx+1:]
+		}
+
+		if interfaces && fieldValue.Kind() == reflect.Interface {
+			if fieldValue.IsNil() {
+				return retval, segment, ErrNilPointer
+			}
+			fieldValue = fieldValue.Elem()
+		}
+
+		if fieldValue.Kind() == reflect.Ptr {
+			if fieldValue.IsNil() {
+				return retval, segment, ErrNilPointer
+			}
+			fieldValue = fieldValue.Elem()
+		}
+
+		if fieldValue.Kind() != reflect.Struct {
+			return retval, segment, ErrNotStruct
+		}
+
+		indexes := ""
+		if bracket := strings.IndexByte(name, '['); bracket != -1 {
+			name, indexes = name[:bracket], name[bracket:]
+		}
+
+		var err error
+		fieldValue, err = fieldByName(fieldValue, name)
+		if err == ErrNilPointer {
+			// The field is promoted through a nil embedded pointer.
+			return retval, segment + 1, err
+		}
+		if err != nil {
+			return retval, segment, err
+		}
+
+		if !fieldValue.CanInterface() {
+			return retval, segment, ErrUnexportedField
+		}
+
+		for indexes != "" {
+			index, size, ok := parseIndex(indexes)
+			if !ok {
+				return retval, segment, ErrInvalidExpr
+			}
+			indexes = indexes[size:]
+
+			if fieldValue.Kind() == reflect.Ptr {
+				if fieldValue.IsNil() {
+					return retval, segment + 1, ErrNilPointer
+				}
+				fieldValue = fieldValue.Elem()
+			}
+
+			if fieldValue.Kind() != reflect.Slice && fieldValue.Kind() != reflect.Array {
+				return retval, segment, ErrNotSlice
+			}
+
+			if index >= fieldValue.Len() {
+				return retval, segment, ErrOutOfRange
+			}
+			fieldValue = fieldValue.Index(index)
+		}
+
+		if idx == -1 {
+			return fieldValue, segment + 1, nil
+		}
+	}
+}
+
+// parseIndex parses the first index of a path segment, such as "[1]" in
+// "[1][2]", and returns the index and the number of bytes parsed. It returns
+// false if the index is malformed or negative.
+func parseIndex(indexes string) (int, int, bool) {
+	end := strings.IndexByte(indexes, ']')
+	if indexes[0] != '[' || end < 2 {
+		return 0, 0, false
+	}
+
+	index := 0
+	for _, digit := range indexes[1:end] {
+		if digit < '0' || digit > '9' {
+			return 0, 0, false
+		}
+		if index > (maxIndex-9)/10 {
+			return 0, 0, false
+		}
+		index = index*10 + int(digit-'0')
+	}
+	return index, end + 1, true
+}
+
+// unwrapInterface returns the dynamic value of a non-nil interface value if the
+// WithInterfaces option is set, else the given value.
+func unwrapInterface(value reflect.Value, o *options) reflect.Value {
+	if o.interfaces && value.Kind() == reflect.Interface && !value.IsNil() {
+		return value.Elem()
+	}
+	return value
+}
+
+// getFieldTypeByPath returns the type of a nested field of a struct type given
+// by a dot separated path of field names, like getFieldByPath does for values.
+// An index of a slice or an array field gives its element type.
+func getFieldTypeByPath(objType reflect.Type, path string) (reflect.Type, error) {
+	fieldType := objType
+	for _, name := range strings.Split(path, pathSeparator) {
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		if fieldType.Kind() != reflect.Struct {
+			return nil, ErrNotStruct
+		}
+
+		indexes := ""
+		if bracket := strings.IndexByte(name, '['); bracket != -1 {
+			name, indexes = name[:bracket], name[bracket:]
+		}
+
+		field, found := cachedField(fieldType, name)
+		if !found {
+			return nil, ErrNoField
+		}
+
+		if field.PkgPath != "" {
+			return nil, ErrUnexportedField
+		}
+		fieldType = field.Type
+
+		for indexes != "" {
+			_, size, ok := parseIndex(indexes)
+			if !ok {
+				return nil, ErrInvalidExpr
+			}
+			indexes = indexes[size:]
+
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() != reflect.Slice && fieldType.Kind() != reflect.Array {
+				return nil, ErrNotSlice
+			}
+			fieldType = fieldType.Elem()
+		}
+	}
+
+	return fieldType, nil
+}
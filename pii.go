@@ -0,0 +1,76 @@
+package attr
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"reflect"
+)
+
+// Pseudonymize replaces the personal data in the exported (public) fields of a
+// struct as listed in their "pii" tag, such as before sending the struct to an
+// analytics system:
+//
+//   - A string field tagged with `pii:"hash"` is replaced with the hex encoded
+//     HMAC-SHA256 of its value keyed by 'salt', so that equal values still
+//     match each other. A []byte field gets the raw HMAC instead. Empty values
+//     are kept empty.
+//   - A field of any type tagged with `pii:"drop"` is set to its zero value.
+//
+// The nested structs, including the ones referenced by non-nil pointers, held
+// by interfaces and the elements of slices, arrays and maps, are pseudonymized
+// too. The struct is not modified if an error is returned: ErrInvalidTag if a
+// "pii" tag has another value or "hash" is used on a field of another type,
+// and a *FieldError with ErrNotPtr if a struct with "pii" tags is held by
+// value in a map or an interface, as it can't be modified in place (hold a
+// pointer to it instead).
+//
+// NOTE: 'obj' struct must be passed by pointer for this API to work. Passing by
+// value results in ErrNotPtr.
+func Pseudonymize(obj interface{}, salt []byte) error {
+	objValue, err := getStructByPtr(obj)
+	if err != nil {
+		return err
+	}
+
+	err = checkTypeTags(objValue.Type(), "pii", map[reflect.Type]bool{},
+		func(tag string, fieldType reflect.Type) bool {
+			return tag == "drop" || (tag == "hash" && isStringOrBytes(fieldType))
+		})
+	if err != nil {
+		return err
+	}
+
+	// Find all the fields first, so that the struct is not modified if an
+	// error is returned.
+	fieldValues, tags := []reflect.Value{}, []string{}
+	err = taggedFields(objValue, "pii", "", map[visitKey]bool{},
+		func(_, tag string, fieldValue reflect.Value) error {
+			fieldValues = append(fieldValues, fieldValue)
+			tags = append(tags, tag)
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+
+	for i, fieldValue := range fieldValues {
+		if tags[i] == "drop" {
+			fieldValue.Set(reflect.Zero(fieldValue.Type()))
+			continue
+		}
+
+		if fieldValue.Len() == 0 {
+			continue
+		}
+		mac := hmac.New(sha256.New, salt)
+		if fieldValue.Kind() == reflect.String {
+			mac.Write([]byte(fieldValue.String()))
+			fieldValue.SetString(hex.EncodeToString(mac.Sum(nil)))
+		} else {
+			mac.Write(fieldValue.Bytes())
+			fieldValue.SetBytes(mac.Sum(nil))
+		}
+	}
+	return nil
+}
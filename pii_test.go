@@ -0,0 +1,106 @@
+package attr
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Visit struct {
+	Email   string `pii:"hash"`
+	Phone   string `pii:"drop"`
+	Page    string
+	Token   []byte `pii:"hash"`
+	Age     int    `pii:"drop"`
+	Contact *Visit
+	History []Visit
+	Empty   string `pii:"hash"`
+}
+
+func TestPseudonymize(t *testing.T) {
+	salt := []byte("salt")
+	mac := hmac.New(sha256.New, salt)
+	mac.Write([]byte("a@b.c"))
+	hashed := hex.EncodeToString(mac.Sum(nil))
+
+	visit := Visit{
+		Email:   "a@b.c",
+		Phone:   "555",
+		Page:    "/home",
+		Token:   []byte("t"),
+		Age:     30,
+		Contact: &Visit{Email: "a@b.c", Phone: "555"},
+		History: []Visit{{Email: "a@b.c", Page: "/about"}},
+	}
+	visit.Contact.Contact = visit.Contact
+	require.Nil(t, Pseudonymize(&visit, salt))
+
+	require.Equal(t, hashed, visit.Email, "Hashed field is not correct")
+	require.Equal(t, "", visit.Phone, "Dropped field is not reset")
+	require.Equal(t, 0, visit.Age, "Dropped field is not reset")
+	require.Equal(t, "/home", visit.Page, "Untagged field is modified")
+	require.Equal(t, 32, len(visit.Token), "Hashed bytes field is not correct")
+	require.Equal(t, "", visit.Empty, "Empty field is hashed")
+	require.Equal(t, hashed, visit.Contact.Email, "Field of a nested pointer is not hashed")
+	require.Equal(t, "", visit.Contact.Phone, "Field of a nested pointer is not dropped")
+	require.Equal(t, hashed, visit.History[0].Email, "Field of a slice element is not hashed")
+	require.Equal(t, "/about", visit.History[0].Page, "Untagged field of a slice element is modified")
+
+	// Structs held by pointers in maps and interfaces are pseudonymized, and
+	// the ones held by value are rejected without modifying anything.
+	type Session struct {
+		ByName map[string]*Visit
+		Extra  interface{}
+		Labels map[string]string
+	}
+	session := Session{
+		ByName: map[string]*Visit{"home": {Email: "a@b.c"}, "none": nil},
+		Extra:  &Visit{Phone: "555"},
+		Labels: map[string]string{"a": "b"},
+	}
+	require.Nil(t, Pseudonymize(&session, salt))
+	require.Equal(t, hashed, session.ByName["home"].Email, "Field of a map value is not hashed")
+	require.Equal(t, "", session.Extra.(*Visit).Phone, "Field of an interface value is not dropped")
+
+	byValue := Session{ByName: map[string]*Visit{"home": {Email: "a@b.c"}}, Extra: Visit{Phone: "555"}}
+	require.Equal(t, &FieldError{"Extra", ErrNotPtr}, Pseudonymize(&byValue, salt),
+		"Able to pseudonymize a struct held by value in an interface")
+	require.Equal(t, "a@b.c", byValue.ByName["home"].Email, "Struct is modified on an error")
+
+	visits := struct{ ByName map[string]Visit }{map[string]Visit{"home": {Email: "a@b.c"}}}
+	require.Equal(t, &FieldError{"ByName[home]", ErrNotPtr}, Pseudonymize(&visits, salt),
+		"Able to pseudonymize a struct held by value in a map")
+
+	type Bad struct {
+		Name string
+		Age  int `pii:"hash"`
+	}
+	bad := Bad{Name: "srathi"}
+	require.Equal(t, ErrInvalidTag, Pseudonymize(&bad, salt), "Hash tag on an int not reported")
+
+	type Unknown struct {
+		Name string `pii:"mask"`
+	}
+	require.Equal(t, ErrInvalidTag, Pseudonymize(&Unknown{}, salt), "Unknown pii tag not reported")
+	require.Equal(t, ErrNotPtr, Pseudonymize(visit, salt), "Able to pseudonymize a struct passed by value")
+}
+
+func ExamplePseudonymize() {
+	type Event struct {
+		UserID string `pii:"hash"`
+		IP     string `pii:"drop"`
+		Action string
+	}
+	event := Event{UserID: "srathi", IP: "10.0.0.1", Action: "login"}
+
+	err := Pseudonymize(&event, []byte("secret-salt"))
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Printf("%d %q %s\n", len(event.UserID), event.IP, event.Action)
+	// Output: 64 "" login
+}
@@ -0,0 +1,66 @@
+package attr
+
+import (
+	"reflect"
+	"sync"
+)
+
+// StructPool is a pool of the structs of type T, as returned by Pool. It is
+// backed by a sync.Pool, so it can be shared by multiple goroutines, and the
+// structs put back in it are reset like Reset does before they are reused.
+type StructPool[T any] struct {
+	pool sync.Pool
+	// exported is true if all the fields of T are exported (or T is not a
+	// struct), so that a struct is reset by assigning the zero value of T.
+	exported bool
+}
+
+// Pool returns a StructPool of the structs of type T, such as the request and
+// response structs of a high-throughput server, which are recycled without
+// writing a reset function per type. It is typically kept in a package
+// variable:
+//
+//	var requestPool = attr.Pool[Request]()
+//
+//	req := requestPool.Get()
+//	defer requestPool.Put(req)
+func Pool[T any]() *StructPool[T] {
+	objType := reflect.TypeOf((*T)(nil)).Elem()
+	p := &StructPool[T]{exported: true}
+	if objType.Kind() == reflect.Struct {
+		for i := 0; i < objType.NumField(); i++ {
+			if objType.Field(i).PkgPath != "" {
+				p.exported = false
+				break
+			}
+		}
+	}
+	p.pool.New = func() interface{} {
+		return new(T)
+	}
+	return p
+}
+
+// Get returns a struct from the pool, or a new zero valued struct if the pool
+// is empty. The exported fields of the returned struct are always zero.
+func (p *StructPool[T]) Get() *T {
+	return p.pool.Get().(*T)
+}
+
+// Put resets a struct and adds it to the pool. The exported (public) fields
+// are set to their zero values like Reset does, while the unexported fields
+// are kept, such as a scratch buffer which the struct reuses. A nil pointer is
+// ignored. The struct must not be used after it is put back in the pool.
+func (p *StructPool[T]) Put(obj *T) {
+	if obj == nil {
+		return
+	}
+
+	if p.exported {
+		var zero T
+		*obj = zero
+	} else {
+		resetStruct(reflect.ValueOf(obj).Elem())
+	}
+	p.pool.Put(obj)
+}
@@ -0,0 +1,87 @@
+package attr
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Request keeps a scratch buffer across the uses from a pool.
+type Request struct {
+	Path    string
+	Headers map[string]string
+	Body    []byte
+	scratch []byte
+}
+
+func TestPool(t *testing.T) {
+	pool := Pool[Request]()
+	req := pool.Get()
+	require.Equal(t, &Request{}, req)
+
+	req.Path = "/users"
+	req.Headers = map[string]string{"Accept": "*/*"}
+	req.Body = []byte("{}")
+	req.scratch = make([]byte, 0, 64)
+	pool.Put(req)
+	require.Equal(t, "", req.Path)
+	require.Nil(t, req.Headers)
+	require.Nil(t, req.Body)
+	require.Equal(t, 64, cap(req.scratch), "The unexported field was reset")
+
+	// A struct with only exported fields is reset as a whole.
+	users := Pool[User]()
+	u := users.Get()
+	u.Username, u.Age = "srathi", 30
+	users.Put(u)
+	require.Equal(t, User{}, *u)
+
+	managers := Pool[Manager]()
+	m := managers.Get()
+	m.User = user
+	m.Reports = []*User{&user}
+	managers.Put(m)
+	require.Equal(t, Manager{}, *m)
+
+	// Non-struct types are zeroed too.
+	ints := Pool[[]int]()
+	list := ints.Get()
+	*list = append(*list, 1)
+	ints.Put(list)
+	require.Nil(t, *list)
+
+	pool.Put(nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				req := pool.Get()
+				require.Equal(t, "", req.Path, "A used struct was not reset")
+				req.Path = fmt.Sprint(i, j)
+				pool.Put(req)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func ExamplePool() {
+	pool := Pool[Request]()
+
+	req := pool.Get()
+	req.Path = "/users"
+	fmt.Println(req.Path)
+	pool.Put(req)
+
+	req = pool.Get()
+	fmt.Printf("%q\n", req.Path)
+
+	// Output:
+	// /users
+	// ""
+}
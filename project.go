@@ -0,0 +1,150 @@
+package attr
+
+import (
+	"reflect"
+	"strings"
+)
+
+// selection is a parsed field of a Project selection, with the fields selected
+// from its nested struct, if any.
+type selection struct {
+	name   string
+	fields []selection
+}
+
+// Project returns a nested map with only the selected fields of a struct, such
+// as for the sparse fieldsets of a REST response. The selection is a comma
+// separated list of the "json" names of the fields, where the fields of a
+// nested struct are selected in braces, such as "id,name,address{city,zip}".
+// A selection in braces on a slice or an array of structs applies to all its
+// elements, which are returned as a []interface{} of maps.
+//
+// The fields without a "json" tag are selected by their field names, and the
+// fields of the embedded structs without a tag are promoted like in
+// encoding/json. The values of the fields without a nested selection are
+// returned as they are, and a nil pointer to a nested struct is returned as
+// nil.
+//
+// ErrInvalidExpr is returned if the selection can't be parsed, and a
+// *FieldError with the path of the json names is returned if a selected field
+// doesn't exist (ErrNoField) or has a nested selection but is not a struct
+// (ErrNotStruct).
+func Project(obj interface{}, selection string) (map[string]interface{}, error) {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, rest, err := parseSelection(strings.ReplaceAll(selection, " ", ""))
+	if err != nil || rest != "" {
+		return nil, ErrInvalidExpr
+	}
+	return projectStruct(objValue, fields, "")
+}
+
+// parseSelection parses a comma separated list of fields with their nested
+// selections, up to the end of the text or an unmatched closing brace, and
+// returns the text after it.
+func parseSelection(text string) ([]selection, string, error) {
+	fields := []selection{}
+	for {
+		end := strings.IndexAny(text, ",{}")
+		if end == -1 {
+			end = len(text)
+		}
+		field := selection{name: text[:end]}
+		if field.name == "" {
+			return nil, "", ErrInvalidExpr
+		}
+		text = text[end:]
+
+		if strings.HasPrefix(text, "{") {
+			var err error
+			field.fields, text, err = parseSelection(text[1:])
+			if err != nil || !strings.HasPrefix(text, "}") {
+				return nil, "", ErrInvalidExpr
+			}
+			text = text[1:]
+		}
+		fields = append(fields, field)
+
+		if !strings.HasPrefix(text, ",") {
+			return fields, text, nil
+		}
+		text = text[1:]
+	}
+}
+
+// projectStruct returns a map of the selected fields of a struct. 'prefix' is
+// the path of the struct, for the errors.
+func projectStruct(objValue reflect.Value, fields []selection, prefix string) (map[string]interface{}, error) {
+	members := cachedJSONMembers(objValue.Type())
+	result := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		path := prefix + field.name
+		found := -1
+		for i, member := range members {
+			if member.name == field.name {
+				found = i
+				break
+			}
+		}
+		if found == -1 {
+			return nil, &FieldError{path, ErrNoField}
+		}
+
+		// A field promoted through a nil embedded pointer is nil, like a nil
+		// pointer field.
+		fieldValue, ok := fieldByIndex(objValue, members[found].index)
+		if !ok {
+			result[field.name] = nil
+			continue
+		}
+		value, err := projectValue(fieldValue, field.fields, path)
+		if err != nil {
+			return nil, err
+		}
+		result[field.name] = value
+	}
+	return result, nil
+}
+
+// projectValue returns the value of a selected field, projected to the nested
+// selection if there is one.
+func projectValue(fieldValue reflect.Value, fields []selection, path string) (interface{}, error) {
+	if len(fields) == 0 {
+		return fieldValue.Interface(), nil
+	}
+
+	if fieldValue.Kind() == reflect.Ptr {
+		if fieldValue.IsNil() {
+			return nil, nil
+		}
+		fieldValue = fieldValue.Elem()
+	}
+
+	switch fieldValue.Kind() {
+	case reflect.Struct:
+		return projectStruct(fieldValue, fields, path+pathSeparator)
+
+	case reflect.Slice, reflect.Array:
+		if structType(fieldValue.Type().Elem()).Kind() != reflect.Struct {
+			break
+		}
+		if fieldValue.Kind() == reflect.Slice && fieldValue.IsNil() {
+			return nil, nil
+		}
+
+		items := make([]interface{}, fieldValue.Len())
+		for i := range items {
+			item, err := projectValue(fieldValue.Index(i), fields, path)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = item
+		}
+		return items, nil
+	}
+
+	return nil, &FieldError{path, ErrNotStruct}
+}
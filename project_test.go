@@ -0,0 +1,80 @@
+package attr
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProject(t *testing.T) {
+	manager := Manager{
+		User:    User{Username: "srathi", Age: 40},
+		Reports: []*User{{Username: "shyam", Age: 30}, nil},
+	}
+	got, err := Project(&manager, "username, reports{username}")
+	require.Nil(t, err)
+	require.Equal(t, map[string]interface{}{
+		"username": "srathi",
+		"reports":  []interface{}{map[string]interface{}{"username": "shyam"}, nil},
+	}, got, "Projection of promoted fields and a slice is not correct")
+
+	employee := Employee{Name: "srathi", Address: &Address{City: "San Jose", Zip: "95134"}}
+	got, err = Project(employee, "name,address{city},manager{name}")
+	require.Nil(t, err)
+	require.Equal(t, map[string]interface{}{
+		"name":    "srathi",
+		"address": map[string]interface{}{"city": "San Jose"},
+		"manager": nil,
+	}, got, "Projection of nested structs is not correct")
+
+	// Promoted fields follow encoding/json, including the ambiguous names and
+	// the embedded pointers.
+	type Audit struct {
+		Owner string `json:"owner"`
+		Name  string `json:"name"`
+	}
+	type Labels struct {
+		Name string `json:"name"`
+	}
+	type Document struct {
+		*Audit
+		Labels
+	}
+	got, err = Project(Document{Audit: &Audit{Owner: "srathi"}}, "owner")
+	require.Nil(t, err)
+	require.Equal(t, map[string]interface{}{"owner": "srathi"}, got, "Projection through an embedded pointer is not correct")
+	got, err = Project(Document{}, "owner")
+	require.Nil(t, err)
+	require.Equal(t, map[string]interface{}{"owner": nil}, got, "Projection through a nil embedded pointer is not correct")
+	_, err = Project(Document{}, "name")
+	require.Equal(t, &FieldError{"name", ErrNoField}, err, "Able to project an ambiguous field")
+
+	for _, test := range []struct {
+		selection string
+		want      error
+	}{
+		{"name,address{street}", &FieldError{"address.street", ErrNoField}},
+		{"Ignored", &FieldError{"Ignored", ErrNoField}},
+		{"name{first}", &FieldError{"name", ErrNotStruct}},
+		{"tags{first}", &FieldError{"tags", ErrNotStruct}},
+		{"name,", ErrInvalidExpr},
+		{"address{city", ErrInvalidExpr},
+		{"address{}", ErrInvalidExpr},
+		{"name}", ErrInvalidExpr},
+	} {
+		_, err := Project(employee, test.selection)
+		require.Equal(t, test.want, err, "Error is not correct for %q", test.selection)
+	}
+}
+
+func ExampleProject() {
+	employee := Employee{Name: "srathi", Address: &Address{City: "San Jose", Zip: "95134"}}
+
+	fields, err := Project(employee, "name,address{city}")
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Println(fields)
+	// Output: map[address:map[city:San Jose] name:srathi]
+}
@@ -0,0 +1,162 @@
+package attr
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ProtoTag is a parsed "protobuf" struct tag, as generated by protoc-gen-go,
+// such as `protobuf:"varint,2,opt,name=age,json=userAge,proto3"`.
+type ProtoTag struct {
+	// WireType is the encoding of the field, such as "varint", "bytes",
+	// "fixed32", "fixed64" or "zigzag64".
+	WireType string
+	// Number is the field number.
+	Number int
+	// Cardinality is "opt", "req" or "rep".
+	Cardinality string
+	// Name is the field name in the .proto file, and JSONName is its JSON
+	// name if it differs.
+	Name     string
+	JSONName string
+	// Enum is the name of the enum type of an enum field.
+	Enum   string
+	Packed bool
+	Proto3 bool
+}
+
+// ParseProtoTag parses the value of a "protobuf" struct tag. ErrInvalidTag is
+// returned if the wire type, the field number or the cardinality is missing or
+// the field number is not a positive number.
+func ParseProtoTag(value string) (ProtoTag, error) {
+	opts := strings.Split(value, ",")
+	if len(opts) < 3 {
+		return ProtoTag{}, ErrInvalidTag
+	}
+
+	number, err := strconv.Atoi(opts[1])
+	if err != nil || number <= 0 || opts[0] == "" || opts[2] == "" {
+		return ProtoTag{}, ErrInvalidTag
+	}
+
+	tag := ProtoTag{WireType: opts[0], Number: number, Cardinality: opts[2]}
+	for _, opt := range opts[3:] {
+		switch {
+		case strings.HasPrefix(opt, "name="):
+			tag.Name = opt[len("name="):]
+		case strings.HasPrefix(opt, "json="):
+			tag.JSONName = opt[len("json="):]
+		case strings.HasPrefix(opt, "enum="):
+			tag.Enum = opt[len("enum="):]
+		case opt == "packed":
+			tag.Packed = true
+		case opt == "proto3":
+			tag.Proto3 = true
+		}
+	}
+	return tag, nil
+}
+
+// ProtoTags returns the parsed "protobuf" tags of all the exported (public)
+// fields of a struct which have one, keyed by their field names. A
+// *FieldError wrapping ErrInvalidTag is returned for the first field whose tag
+// can't be parsed.
+func ProtoTags(obj interface{}) (map[string]ProtoTag, error) {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return protoTags(objValue.Type())
+}
+
+// protoTags returns the parsed "protobuf" tags of a struct type.
+func protoTags(objType reflect.Type) (map[string]ProtoTag, error) {
+	tags := map[string]ProtoTag{}
+	for i := 0; i < objType.NumField(); i++ {
+		field := objType.Field(i)
+		value, found := field.Tag.Lookup("protobuf")
+		if !found || field.PkgPath != "" {
+			continue
+		}
+
+		tag, err := ParseProtoTag(value)
+		if err != nil {
+			return nil, &FieldError{field.Name, err}
+		}
+		tags[field.Name] = tag
+	}
+	return tags, nil
+}
+
+// ProtoConflict is a field number change found by CheckProtoNumbers.
+type ProtoConflict struct {
+	// Field is the name of the field in the new struct.
+	Field string
+	// OldNumber is the number of the field in the old struct, or 0 if the
+	// field is not in the old struct.
+	OldNumber int
+	// NewNumber is the number of the field in the new struct.
+	NewNumber int
+	// ReusedFrom is the name of a different field of the old struct which
+	// used NewNumber, if any.
+	ReusedFrom string
+}
+
+// CheckProtoNumbers compares the "protobuf" tags of two versions of a struct,
+// 'a' being the old version and 'b' being the new one, and returns the fields
+// of 'b' which break the wire compatibility: the fields whose number has
+// changed, and the fields which reuse the number of a different field of 'a'.
+// The conflicts are listed in the declaration order of the fields of 'b'.
+//
+// Both 'a' and 'b' can be passed by value or by pointer.
+func CheckProtoNumbers(a, b interface{}) ([]ProtoConflict, error) {
+	aValue, err := getReflectValue(a)
+	if err != nil {
+		return nil, err
+	}
+
+	bValue, err := getReflectValue(b)
+	if err != nil {
+		return nil, err
+	}
+
+	aTags, err := protoTags(aValue.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	bTags, err := protoTags(bValue.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	aNames := map[int]string{}
+	for name, tag := range aTags {
+		aNames[tag.Number] = name
+	}
+
+	conflicts := []ProtoConflict{}
+	bType := bValue.Type()
+	for i := 0; i < bType.NumField(); i++ {
+		name := bType.Field(i).Name
+		bTag, found := bTags[name]
+		if !found {
+			continue
+		}
+
+		conflict := ProtoConflict{Field: name, NewNumber: bTag.Number}
+		if aTag, found := aTags[name]; found {
+			conflict.OldNumber = aTag.Number
+		}
+		if other, found := aNames[bTag.Number]; found && other != name {
+			conflict.ReusedFrom = other
+		}
+
+		if conflict.ReusedFrom != "" || (conflict.OldNumber != 0 && conflict.OldNumber != bTag.Number) {
+			conflicts = append(conflicts, conflict)
+		}
+	}
+	return conflicts, nil
+}
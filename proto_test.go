@@ -0,0 +1,88 @@
+package attr
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type PersonV1 struct {
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3"`
+	Age   int32  `protobuf:"varint,2,opt,name=age,json=userAge,proto3"`
+	Email string `protobuf:"bytes,3,opt,name=email,proto3"`
+	cache string
+}
+
+type PersonV2 struct {
+	Name   string   `protobuf:"bytes,1,opt,name=name,proto3"`
+	Age    int32    `protobuf:"varint,4,opt,name=age,proto3"`
+	Phone  string   `protobuf:"bytes,3,opt,name=phone,proto3"`
+	Scores []int32  `protobuf:"varint,5,rep,packed,name=scores,proto3"`
+	Extra  struct{} `json:"extra"`
+}
+
+func TestParseProtoTag(t *testing.T) {
+	got, err := ParseProtoTag("varint,2,opt,name=age,json=userAge,enum=pb.Kind,proto3")
+	require.Nil(t, err)
+	require.Equal(t, ProtoTag{WireType: "varint", Number: 2, Cardinality: "opt", Name: "age",
+		JSONName: "userAge", Enum: "pb.Kind", Proto3: true}, got, "Parsed tag is not correct")
+
+	got, err = ParseProtoTag("varint,5,rep,packed,name=scores")
+	require.Nil(t, err)
+	require.True(t, got.Packed, "Packed flag not parsed")
+
+	for _, value := range []string{"", "varint,2", "varint,x,opt", "varint,0,opt", ",1,opt", "bytes,1,"} {
+		_, err = ParseProtoTag(value)
+		require.Equal(t, ErrInvalidTag, err, "Able to parse %q", value)
+	}
+}
+
+func TestProtoTags(t *testing.T) {
+	got, err := ProtoTags(&PersonV2{})
+	require.Nil(t, err)
+	require.Equal(t, 4, len(got), "Incorrect number of protobuf tags")
+	require.Equal(t, 5, got["Scores"].Number, "Field number is not correct")
+
+	type Bad struct {
+		ID int `protobuf:"varint,id,opt"`
+	}
+	_, err = ProtoTags(Bad{})
+	require.Equal(t, &FieldError{"ID", ErrInvalidTag}, err, "Invalid tag not reported")
+}
+
+func TestCheckProtoNumbers(t *testing.T) {
+	got, err := CheckProtoNumbers(PersonV1{}, &PersonV2{})
+	require.Nil(t, err)
+	require.Equal(t, []ProtoConflict{
+		{Field: "Age", OldNumber: 2, NewNumber: 4},
+		{Field: "Phone", NewNumber: 3, ReusedFrom: "Email"},
+	}, got, "Field number conflicts are not correct")
+
+	got, err = CheckProtoNumbers(PersonV1{}, PersonV1{})
+	require.Nil(t, err)
+	require.Empty(t, got, "Conflicts reported for the same struct")
+
+	_, err = CheckProtoNumbers(PersonV1{}, 10)
+	require.Equal(t, ErrNotStruct, err, "Able to compare a non-struct")
+}
+
+func ExampleCheckProtoNumbers() {
+	type Old struct {
+		ID   int64  `protobuf:"varint,1,opt,name=id,proto3"`
+		Name string `protobuf:"bytes,2,opt,name=name,proto3"`
+	}
+	type New struct {
+		ID    int64  `protobuf:"varint,1,opt,name=id,proto3"`
+		Email string `protobuf:"bytes,2,opt,name=email,proto3"`
+	}
+
+	conflicts, err := CheckProtoNumbers(Old{}, New{})
+	if err != nil {
+		// Handle error.
+	}
+	for _, conflict := range conflicts {
+		fmt.Printf("%s reuses number %d of %s\n", conflict.Field, conflict.NewNumber, conflict.ReusedFrom)
+	}
+	// Output: Email reuses number 2 of Name
+}
@@ -0,0 +1,153 @@
+package attr
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// ToValues converts the exported (public) fields of a struct to url.Values,
+// such as for the query parameters of a request. Keys are taken from the
+// 'tagKey' tags (such as "url"), or are the field names if there is no tag.
+//
+// Fields tagged with "-" are skipped, and so are the zero valued fields tagged
+// with ",omitempty" and the nil pointers. Slices and arrays are added as
+// repeated keys, time.Time values are formatted with the layout given by the
+// WithTimeLayout option (time.RFC3339 by default), []byte fields are encoded
+// as given by their "attr" tag (such as `attr:"base64"` or `attr:"hex"`), and
+// the fields of nested structs are added with the "parent.child" keys.
+//
+// The fields of embedded structs without a tag are promoted to the parent
+// struct, and so are the fields of the struct fields tagged with ",squash", or
+// with the ",squash" option of the tag given by WithSquashTag (such as
+// "mapstructure").
+//
+// The nested structs deeper than the WithMaxDepth option are skipped, and
+// ErrCycleDetected is returned if a struct references itself through pointers.
+// With the WithInterfaces option, the values held by the interface fields are
+// added like the values of the fields of their types. Use WithFuncPolicy to
+// skip or reject the func and chan fields.
+func ToValues(obj interface{}, tagKey string, opts ...Option) (url.Values, error) {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	o := newOptions(opts)
+	if o.squashTag == "" {
+		o.squashTag = tagKey
+	}
+	t := newTracker(o)
+	t.enter(valueOf(obj))
+
+	values := url.Values{}
+	err = addValues(values, objValue, tagKey, "", o, t)
+	return values, err
+}
+
+// addValues adds all the exported fields of a struct to url.Values with the
+// given key prefix.
+func addValues(values url.Values, objValue reflect.Value, tagKey, prefix string,
+	o *options, t *tracker) error {
+	if err := t.err(); err != nil {
+		return err
+	}
+
+	objType := objValue.Type()
+	for i := 0; i < objValue.NumField(); i++ {
+		fieldType := objType.Field(i)
+		fieldValue := objValue.Field(i)
+		tag, hasTag := fieldType.Tag.Lookup(tagKey)
+		if tag == "-" {
+			continue
+		}
+
+		if fieldType.Anonymous && !hasTag && fieldValue.Kind() == reflect.Struct {
+			if err := addValues(values, fieldValue, tagKey, prefix, o, t); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if isSquashed(fieldType, o.squashTag) && fieldValue.CanInterface() {
+			squashed := fieldValue
+			if squashed.Kind() == reflect.Ptr && !squashed.IsNil() {
+				squashed = squashed.Elem()
+			}
+			if squashed.Kind() == reflect.Struct {
+				if err := addValues(values, squashed, tagKey, prefix, o, t); err != nil {
+					return err
+				}
+				continue
+			}
+			if squashed.Kind() == reflect.Ptr {
+				continue
+			}
+		}
+
+		if !fieldValue.CanInterface() {
+			continue
+		}
+
+		if strings.Contains(tag, ",omitempty") && fieldValue.IsZero() {
+			continue
+		}
+
+		key := tagName(tag)
+		if key == "" {
+			key = keyName(fieldType.Name, o.keyNaming, nil)
+		}
+		key = prefix + key
+
+		fieldValue = unwrapInterface(fieldValue, o)
+		if skip, err := o.skipFunc(fieldValue, key); skip {
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		ptrValue := fieldValue
+		if fieldValue.Kind() == reflect.Ptr {
+			if fieldValue.IsNil() {
+				continue
+			}
+			fieldValue = fieldValue.Elem()
+		}
+
+		switch {
+		case fieldValue.Kind() == reflect.Struct && !isValueStruct(fieldValue.Type()) &&
+			!isTextMarshaler(fieldValue):
+			if !t.canDescend() {
+				continue
+			}
+			if err := t.enter(ptrValue); err != nil {
+				return err
+			}
+			err := addValues(values, fieldValue, tagKey, key+".", o, t)
+			t.leave(ptrValue)
+			if err != nil {
+				return err
+			}
+
+		case (fieldValue.Kind() == reflect.Slice && fieldValue.Type().Elem().Kind() != reflect.Uint8) ||
+			fieldValue.Kind() == reflect.Array:
+			for j := 0; j < fieldValue.Len(); j++ {
+				text, err := formatValue(fieldValue.Index(j), o.timeLayout)
+				if err != nil {
+					return err
+				}
+				values.Add(key, text)
+			}
+
+		default:
+			text, err := formatField(fieldType, fieldValue, o.timeLayout)
+			if err != nil {
+				return err
+			}
+			values.Add(key, text)
+		}
+	}
+
+	return nil
+}
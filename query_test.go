@@ -0,0 +1,98 @@
+package attr
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Page struct {
+	Number int `url:"page"`
+	Size   int `url:"size,omitempty"`
+}
+
+type Search struct {
+	Page
+	Query   string    `url:"q"`
+	Labels  []string  `url:"label"`
+	Since   time.Time `url:"since"`
+	Limit   *int      `url:"limit"`
+	Exact   bool      `url:"exact,omitempty"`
+	Server  net.IP    `url:"server,omitempty"`
+	Range   Page      `url:"range"`
+	Skipped string    `url:"-"`
+	Order   string
+	secret  string
+}
+
+func TestToValues(t *testing.T) {
+	search := Search{
+		Page:   Page{Number: 2},
+		Query:  "go attr",
+		Labels: []string{"go", "reflect"},
+		Since:  time.Date(2021, 1, 4, 10, 30, 0, 0, time.UTC),
+		Server: net.ParseIP("10.0.0.1"),
+		Range:  Page{1, 10},
+		Order:  "asc",
+	}
+	want := url.Values{
+		"page":       {"2"},
+		"q":          {"go attr"},
+		"label":      {"go", "reflect"},
+		"since":      {"2021-01-04"},
+		"server":     {"10.0.0.1"},
+		"range.page": {"1"},
+		"range.size": {"10"},
+		"Order":      {"asc"},
+	}
+	got, err := ToValues(&search, "url", WithTimeLayout("2006-01-02"))
+	require.Nil(t, err)
+	require.Equal(t, want, got, "URL values are not correct")
+
+	_, err = ToValues([]Search{}, "url")
+	require.Equal(t, ErrNotStruct, err, "Able to convert a non-struct")
+
+	// Nil pointer elements are formatted as empty values.
+	type Schedule struct {
+		Times []*time.Time `url:"t"`
+	}
+	since := search.Since
+	got, err = ToValues(Schedule{Times: []*time.Time{&since, nil}}, "url", WithTimeLayout("2006-01-02"))
+	require.Nil(t, err)
+	require.Equal(t, url.Values{"t": {"2021-01-04", ""}}, got, "URL values of nil pointers are not correct")
+}
+
+func TestToValuesSquash(t *testing.T) {
+	type Filter struct {
+		Page   `url:",squash"`
+		Query  string `url:"q"`
+		Parent *Page  `url:"parent,squash"`
+		Next   *Page  `url:"next,squash"`
+	}
+
+	filter := Filter{Page: Page{Number: 2, Size: 20}, Query: "go", Parent: &Page{Number: 1}}
+	got, err := ToValues(filter, "url")
+	require.Nil(t, err)
+	require.Equal(t, url.Values{"page": {"2", "1"}, "size": {"20"}, "q": {"go"}}, got,
+		"Squashed URL values are not correct")
+
+	got, err = ToValues(filter, "url", WithSquashTag("mapstructure"))
+	require.Nil(t, err)
+	require.Equal(t, url.Values{"Page.page": {"2"}, "Page.size": {"20"}, "q": {"go"}, "parent.page": {"1"}}, got,
+		"URL values without squashing are not correct")
+}
+
+func ExampleToValues() {
+	testUser := User{Username: "srathi", password: "secret", Age: 30}
+
+	values, err := ToValues(&testUser, "json")
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Println(values.Encode())
+	// Output: age=30&username=srathi
+}
@@ -0,0 +1,86 @@
+package attr
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReflectValueInputs(t *testing.T) {
+	u := User{Username: "srathi", Age: 30}
+
+	// An addressable struct value works like a pointer to the struct.
+	elem := reflect.ValueOf(&u).Elem()
+	value, err := GetValue(elem, "Username")
+	require.NoError(t, err)
+	require.Equal(t, "srathi", value)
+
+	err = SetValue(elem, "Age", 31)
+	require.NoError(t, err)
+	require.Equal(t, 31, u.Age)
+
+	swapped, err := CompareAndSwapField(elem, "Age", 31, 32)
+	require.NoError(t, err)
+	require.True(t, swapped)
+	require.Equal(t, 32, u.Age)
+
+	names, err := Names(elem)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Username", "Age"}, names)
+
+	// A pointer value is used as it is.
+	err = SetValue(reflect.ValueOf(&u), "Username", "srathi-alt")
+	require.NoError(t, err)
+	require.Equal(t, "srathi-alt", u.Username)
+
+	// A struct value which isn't addressable can only be read.
+	copied := reflect.ValueOf(u)
+	value, err = GetValue(copied, "Age")
+	require.NoError(t, err)
+	require.Equal(t, 32, value)
+
+	err = SetValue(copied, "Age", 40)
+	require.Equal(t, ErrNotPtr, err)
+
+	// The struct fields of an addressable struct are addressable too.
+	m := Manager{User: User{Username: "boss"}}
+	err = SetValue(reflect.ValueOf(&m).Elem().Field(0), "Username", "lead")
+	require.NoError(t, err)
+	require.Equal(t, "lead", m.Username)
+
+	// Slices are accepted as values too.
+	list := []User{{Username: "a"}, {Username: "b"}}
+	plucked, err := Pluck(reflect.ValueOf(list), "Username")
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b"}, plucked)
+
+	err = SetValueEach(reflect.ValueOf(&list).Elem(), "Age", 7)
+	require.NoError(t, err)
+	require.Equal(t, 7, list[1].Age)
+
+	// Invalid and non-struct values are rejected.
+	_, err = GetValue(reflect.Value{}, "Age")
+	require.Equal(t, ErrNotStruct, err)
+
+	_, err = GetValue(reflect.ValueOf(10), "Age")
+	require.Equal(t, ErrNotStruct, err)
+
+	_, err = GetValue(reflect.ValueOf((*User)(nil)), "Age")
+	require.Equal(t, ErrNilPointer, err)
+}
+
+func ExampleSetValue_reflectValue() {
+	u := User{Username: "srathi"}
+	elem := reflect.ValueOf(&u).Elem()
+
+	if err := SetValue(elem, "Username", "srathi-alt"); err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(u.Username)
+
+	// Output:
+	// srathi-alt
+}
@@ -0,0 +1,93 @@
+package attr
+
+import (
+	"reflect"
+	"strings"
+)
+
+// CheckRequired returns the paths of all the exported (public) fields of a
+// struct which are required but still have their zero value (such as "", 0 or
+// a nil pointer). A field is required if it is tagged with `required:"true"`
+// or its "validate" tag contains "required", like in JSONSchema. With the
+// WithJSONRequired option, a field with a "json" tag without "omitempty" is
+// required too.
+//
+// The nested structs (including the ones referenced by non-nil pointers) are
+// checked as well, and the path of a nested field is a dot separated list of
+// field names, such as "Address.City". The nested structs deeper than the
+// WithMaxDepth option are not checked, and the structs held by the interface
+// fields are checked only with the WithInterfaces option.
+//
+// An empty slice is returned if all the required fields are set.
+func CheckRequired(obj interface{}, opts ...Option) ([]string, error) {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	o := newOptions(opts)
+	t := newTracker(o)
+	t.enter(objValue)
+
+	paths := []string{}
+	checkRequired(objValue, "", o, t, &paths)
+	if err := t.err(); err != nil {
+		return nil, err
+	}
+	return paths, nil
+}
+
+// checkRequired adds the paths of the required fields of a struct which are
+// not set to 'paths'.
+func checkRequired(objValue reflect.Value, prefix string, o *options, t *tracker, paths *[]string) {
+	objType := objValue.Type()
+	for i := 0; i < objValue.NumField(); i++ {
+		fieldType := objType.Field(i)
+		fieldValue := objValue.Field(i)
+		if fieldType.PkgPath != "" {
+			continue
+		}
+
+		path := prefix + fieldType.Name
+		if fieldValue.IsZero() && isRequiredField(fieldType, o) {
+			*paths = append(*paths, path)
+		}
+
+		if !t.canDescend() || fieldValue.Type() == timeType {
+			continue
+		}
+
+		fieldValue = unwrapInterface(fieldValue, o)
+		if fieldValue.Kind() == reflect.Ptr && !fieldValue.IsNil() &&
+			fieldValue.Elem().Kind() == reflect.Struct {
+			// A struct referenced by one of the enclosing structs is already
+			// being checked.
+			if err := t.enter(fieldValue); err != nil {
+				continue
+			}
+			checkRequired(fieldValue.Elem(), path+pathSeparator, o, t, paths)
+			t.leave(fieldValue)
+			continue
+		}
+
+		if fieldValue.Kind() == reflect.Struct {
+			t.enter(fieldValue)
+			checkRequired(fieldValue, path+pathSeparator, o, t, paths)
+			t.leave(fieldValue)
+		}
+	}
+}
+
+// isRequiredField returns true if a struct field must be set for
+// CheckRequired.
+func isRequiredField(field reflect.StructField, o *options) bool {
+	if isRequired(field.Tag) {
+		return true
+	}
+
+	if !o.jsonRequired {
+		return false
+	}
+	tag, found := field.Tag.Lookup("json")
+	return found && tag != "-" && !strings.Contains(tag, ",omitempty")
+}
@@ -0,0 +1,58 @@
+package attr
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Profile struct {
+	Name    string   `json:"name" required:"true"`
+	Nick    string   `json:"nick,omitempty"`
+	Email   string   `validate:"email,required"`
+	Address *Address `json:"address" required:"true"`
+	Home    Address  `json:"home"`
+	Next    *Profile `json:"-"`
+}
+
+func TestCheckRequired(t *testing.T) {
+	got, err := CheckRequired(&Profile{})
+	require.Nil(t, err)
+	require.Equal(t, []string{"Name", "Email", "Address", "Home.City"}, got,
+		"Missing required fields are not correct")
+
+	profile := Profile{Name: "srathi", Email: "a@b.c", Address: &Address{}, Home: Address{City: "SF"}}
+	got, err = CheckRequired(&profile)
+	require.Nil(t, err)
+	require.Equal(t, []string{"Address.City"}, got, "Missing nested required field is not correct")
+
+	profile.Next = &profile
+	got, err = CheckRequired(&profile)
+	require.Nil(t, err)
+	require.Equal(t, []string{"Address.City", "Next.Address.City"}, got,
+		"Fields of a self-referencing struct are not correct")
+
+	got, err = CheckRequired(&Profile{}, WithJSONRequired())
+	require.Nil(t, err)
+	require.Equal(t, []string{"Name", "Email", "Address", "Home", "Home.City"}, got,
+		"Fields required by json tags are not correct")
+
+	_, err = CheckRequired("abc")
+	require.Equal(t, ErrNotStruct, err, "Able to check a non-struct")
+}
+
+func ExampleCheckRequired() {
+	type Config struct {
+		Host string `required:"true"`
+		Port int    `json:"port"`
+		Name string `json:"name,omitempty"`
+	}
+
+	missing, err := CheckRequired(Config{}, WithJSONRequired())
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Println(missing)
+	// Output: [Host Port]
+}
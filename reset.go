@@ -0,0 +1,99 @@
+package attr
+
+import "reflect"
+
+// Reset sets all the exported (public) fields of a struct to their zero
+// values, such as 0, "", false or nil. Unexported fields are not modified.
+//
+// NOTE: 'obj' struct must be passed by pointer for this API to work. Passing by
+// value results in ErrNotPtr.
+func Reset(obj interface{}) error {
+	objValue, err := getStructByPtr(obj)
+	if err != nil {
+		return err
+	}
+
+	resetStruct(objValue)
+	return nil
+}
+
+// resetStruct sets all the exported fields of an addressable struct to their
+// zero values, for Reset.
+func resetStruct(objValue reflect.Value) {
+	for i := 0; i < objValue.NumField(); i++ {
+		fieldValue := objValue.Field(i)
+		if fieldValue.CanSet() {
+			fieldValue.Set(reflect.Zero(fieldValue.Type()))
+		}
+	}
+}
+
+// ResetFields sets the given fields of a struct to their zero values, such as
+// 0, "", false or nil. A field of a nested struct can be given by a dot
+// separated path, such as "Address.City". Only exported (public) fields can be
+// reset using this API.
+//
+// All the fields are looked up before resetting any of them, so the struct is
+// not modified if an error is returned.
+//
+// NOTE: 'obj' struct must be passed by pointer for this API to work. Passing by
+// value results in ErrNotPtr.
+func ResetFields(obj interface{}, fieldNames ...string) error {
+	objValue, err := getStructByPtr(obj)
+	if err != nil {
+		return err
+	}
+
+	fieldValues := make([]reflect.Value, 0, len(fieldNames))
+	for _, fieldName := range fieldNames {
+		fieldValue, err := getFieldByPath(objValue, fieldName, false)
+		if err != nil {
+			return err
+		}
+		fieldValues = append(fieldValues, fieldValue)
+	}
+
+	for _, fieldValue := range fieldValues {
+		fieldValue.Set(reflect.Zero(fieldValue.Type()))
+	}
+
+	return nil
+}
+
+// DelValue clears a given field of a struct by setting it to its zero value,
+// such as 0, "" or false, and nil for a pointer, slice, map or interface. It
+// completes GetValue, SetValue and Has, like delattr in Python. A field of a
+// nested struct can be given by a path like ResetFields, such as
+// "Address.City" or "Owners[1].Name". Only exported (public) fields can be
+// cleared using this API.
+//
+// Use WithChangeHook to get a ChangeRecord of the change.
+//
+// NOTE: 'obj' struct must be passed by pointer for this API to work. Passing by
+// value results in ErrNotPtr.
+func DelValue(obj interface{}, fieldName string, opts ...Option) error {
+	objValue, err := getStructByPtr(obj)
+	if err != nil {
+		return err
+	}
+
+	fieldValue, err := getFieldByPath(objValue, fieldName, false)
+	if err != nil {
+		return err
+	}
+
+	if !fieldValue.CanSet() {
+		return ErrUnexportedField
+	}
+
+	zeroValue := reflect.Zero(fieldValue.Type())
+	if len(opts) == 0 {
+		fieldValue.Set(zeroValue)
+		return nil
+	}
+
+	oldValue := fieldValue.Interface()
+	fieldValue.Set(zeroValue)
+	newOptions(opts).emitChange(fieldName, oldValue, zeroValue.Interface())
+	return nil
+}
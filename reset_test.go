@@ -0,0 +1,99 @@
+package attr
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReset(t *testing.T) {
+	testUser := User{Username: "srathi", password: "secret", Age: 30}
+	require.Nil(t, Reset(&testUser))
+	require.Equal(t, User{password: "secret"}, testUser, "Public fields not reset")
+
+	require.Equal(t, ErrNotPtr, Reset(testUser), "Able to reset a struct passed by value")
+	require.Equal(t, ErrNotStruct, Reset(&[]int{}), "Able to reset a non-struct")
+}
+
+func TestResetFields(t *testing.T) {
+	employee := Employee{
+		Name:    "srathi",
+		Tags:    []string{"admin"},
+		Address: &Address{City: "San Jose", Zip: "95134"},
+	}
+	require.Nil(t, ResetFields(&employee, "Tags", "Address.City"))
+	require.Nil(t, employee.Tags, "Slice field not reset")
+	require.Equal(t, "", employee.Address.City, "Nested field not reset")
+	require.Equal(t, "95134", employee.Address.Zip, "Other nested field is reset")
+	require.Equal(t, "srathi", employee.Name, "Other field is reset")
+
+	for _, test := range []struct {
+		attrName string
+		wantErr  error
+		errMsg   string
+	}{
+		{"ABC", ErrNoField, "Able to reset a non-existent field"},
+		{"internal", ErrUnexportedField, "Able to reset a private field"},
+		{"Manager.Name", &NilPathError{"Manager", 1}, "Able to reset a field of a nil pointer"},
+		{"Name.ABC", ErrNotStruct, "Able to reset a field of a non-struct"},
+	} {
+		err := ResetFields(&employee, "Name", test.attrName)
+		require.Equal(t, test.wantErr, err, test.errMsg)
+		require.Equal(t, "srathi", employee.Name, "Field reset on an error")
+	}
+}
+
+func TestDelValue(t *testing.T) {
+	employee := Employee{
+		Name:    "srathi",
+		Tags:    []string{"admin", "dev"},
+		Address: &Address{City: "San Jose", Zip: "95134"},
+		Labels:  map[string]int{"level": 3},
+	}
+	require.Nil(t, DelValue(&employee, "Labels"))
+	require.Nil(t, employee.Labels, "Map field not cleared")
+
+	require.Nil(t, DelValue(&employee, "Tags[1]"))
+	require.Equal(t, []string{"admin", ""}, employee.Tags, "Slice element not cleared")
+
+	var got ChangeRecord
+	err := DelValue(&employee, "Address.City", WithChangeHook(func(record ChangeRecord) { got = record }))
+	require.Nil(t, err)
+	require.Equal(t, &Address{Zip: "95134"}, employee.Address, "Nested field not cleared")
+	require.Equal(t, "Address.City", got.Field, "Change record field is not correct")
+	require.Equal(t, "San Jose", got.Old, "Change record old value is not correct")
+	require.Equal(t, "", got.New, "Change record new value is not correct")
+
+	require.Nil(t, DelValue(&employee, "Address"))
+	require.Nil(t, employee.Address, "Pointer field not cleared")
+
+	require.Equal(t, ErrNotPtr, DelValue(employee, "Name"), "Able to clear a field of a value")
+	require.Equal(t, ErrNoField, DelValue(&employee, "ABC"), "Able to clear a non-existent field")
+	require.Equal(t, ErrUnexportedField, DelValue(&employee, "internal"), "Able to clear a private field")
+	require.Equal(t, &NilPathError{"Address", 1}, DelValue(&employee, "Address.City"),
+		"Able to clear a field of a nil pointer")
+	require.Equal(t, "srathi", employee.Name, "Field cleared on an error")
+}
+
+func ExampleDelValue() {
+	testUser := User{Username: "srathi", password: "secret", Age: 30}
+
+	err := DelValue(&testUser, "Username")
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Printf("Username: %q, Age: %d\n", testUser.Username, testUser.Age)
+	// Output: Username: "", Age: 30
+}
+
+func ExampleResetFields() {
+	testUser := User{Username: "srathi", password: "secret", Age: 30}
+
+	err := ResetFields(&testUser, "Age")
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Printf("Username: %s, Age: %d\n", testUser.Username, testUser.Age)
+	// Output: Username: srathi, Age: 0
+}
@@ -0,0 +1,54 @@
+package attr
+
+import "reflect"
+
+// Retag returns a copy of a struct whose type is identical except for the
+// struct tags, which are rewritten by 'fn' for every field. This is useful to
+// serialize a value differently for different backends, such as using the
+// "json" tags as the "bson" tags. The returned value is a pointer to the copy,
+// whose type is an unnamed struct type built at runtime.
+//
+// Only the exported (public) fields are copied, as a struct type with
+// unexported fields can't be built at runtime. For the same reason, an
+// embedded field whose type has methods (such as time.Time) is copied as a
+// regular named field, so its methods are not promoted to the copy.
+func Retag(obj interface{}, fn func(fieldName string, tag reflect.StructTag) reflect.StructTag) (interface{}, error) {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	objType := objValue.Type()
+	fields := []reflect.StructField{}
+	indexes := []int{}
+	for i := 0; i < objType.NumField(); i++ {
+		field := objType.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		field.Tag = fn(field.Name, field.Tag)
+		field.Offset = 0
+		field.Index = nil
+		if field.Anonymous && hasMethods(field.Type) {
+			field.Anonymous = false
+		}
+		fields = append(fields, field)
+		indexes = append(indexes, i)
+	}
+
+	newValue := reflect.New(reflect.StructOf(fields))
+	for j, i := range indexes {
+		newValue.Elem().Field(j).Set(objValue.Field(i))
+	}
+
+	return newValue.Interface(), nil
+}
+
+// hasMethods returns true if a type, or a pointer to it, has any methods.
+func hasMethods(typ reflect.Type) bool {
+	if typ.NumMethod() > 0 {
+		return true
+	}
+	return typ.Kind() != reflect.Ptr && reflect.PtrTo(typ).NumMethod() > 0
+}
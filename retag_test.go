@@ -0,0 +1,69 @@
+package attr
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetag(t *testing.T) {
+	account := Account{Base: Base{ID: 7}, Name: "srathi", Balance: 10.5, token: "secret"}
+	got, err := Retag(&account, func(fieldName string, tag reflect.StructTag) reflect.StructTag {
+		if name, ok := tag.Lookup("db"); ok {
+			return reflect.StructTag(fmt.Sprintf(`json:%q`, name))
+		}
+		return `json:"-"`
+	})
+	require.Nil(t, err)
+
+	data, err := json.Marshal(got)
+	require.Nil(t, err)
+	require.JSONEq(t, `{"name": "srathi", "balance": 10.5}`, string(data),
+		"Retagged struct is not marshaled correctly")
+
+	value, err := GetValue(got, "Name")
+	require.Nil(t, err)
+	require.Equal(t, "srathi", value, "Field value not copied")
+
+	names, err := Names(got)
+	require.Nil(t, err)
+	require.Equal(t, []string{"Base", "Name", "Email", "Balance", "Internal", "Active"}, names,
+		"Unexported fields are copied")
+
+	// An embedded type with methods is copied as a named field.
+	type Event struct {
+		time.Time
+		Name string
+	}
+	when := time.Date(2021, 1, 4, 10, 30, 0, 0, time.UTC)
+	got, err = Retag(Event{Time: when, Name: "launch"}, func(_ string, tag reflect.StructTag) reflect.StructTag {
+		return tag
+	})
+	require.Nil(t, err)
+	require.NotEmpty(t, fmt.Sprint(got), "Retagged struct is not formatted")
+	value, err = GetValue(got, "Time")
+	require.Nil(t, err)
+	require.Equal(t, when, value, "Embedded field value not copied")
+
+	_, err = Retag(10, func(string, reflect.StructTag) reflect.StructTag { return "" })
+	require.Equal(t, ErrNotStruct, err, "Able to retag a non-struct")
+}
+
+func ExampleRetag() {
+	testUser := User{Username: "srathi", password: "secret", Age: 30}
+
+	// Use the "db" tags as the "json" tags.
+	retagged, err := Retag(&testUser, func(fieldName string, tag reflect.StructTag) reflect.StructTag {
+		return reflect.StructTag(fmt.Sprintf(`json:%q`, tag.Get("db")))
+	})
+	if err != nil {
+		// Handle error.
+	}
+	data, _ := json.Marshal(retagged)
+	fmt.Println(string(data))
+	// Output: {"uname":"srathi","Age":30}
+}
@@ -0,0 +1,167 @@
+package attr
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// SanitizeFunc transforms a string value for Sanitize. 'arg' is the text after
+// "=" in the tag, such as "64" in `sanitize:"truncate=64"`, or "" if there is
+// none.
+type SanitizeFunc func(value, arg string) (string, error)
+
+// sanitizers is the registry of the sanitizers usable in a "sanitize" tag.
+var sanitizers = struct {
+	sync.RWMutex
+	funcs map[string]SanitizeFunc
+}{
+	funcs: map[string]SanitizeFunc{
+		"trim": func(value, _ string) (string, error) {
+			return strings.TrimSpace(value), nil
+		},
+		"lower": func(value, _ string) (string, error) {
+			return strings.ToLower(value), nil
+		},
+		"upper": func(value, _ string) (string, error) {
+			return strings.ToUpper(value), nil
+		},
+		"collapse": func(value, _ string) (string, error) {
+			return strings.Join(strings.Fields(value), " "), nil
+		},
+		"truncate": func(value, arg string) (string, error) {
+			size, err := strconv.Atoi(arg)
+			if err != nil || size < 0 {
+				return "", ErrInvalidTag
+			}
+			if utf8.RuneCountInString(value) <= size {
+				return value, nil
+			}
+			return string([]rune(value)[:size]), nil
+		},
+	},
+}
+
+// RegisterSanitizer adds a custom sanitizer which can be used by its name in
+// a "sanitize" tag. A built-in sanitizer with the same name is replaced.
+func RegisterSanitizer(name string, fn SanitizeFunc) {
+	sanitizers.Lock()
+	defer sanitizers.Unlock()
+	sanitizers.funcs[name] = fn
+}
+
+// Sanitize transforms the exported (public) string fields of a struct as
+// listed in their "sanitize" tag, such as `sanitize:"trim,lower,truncate=64"`.
+// The sanitizers are applied in the listed order, and the nested structs are
+// sanitized too, handling the shared structs and the cycles like
+// TransformStrings. The struct is left unchanged if an error is returned.
+//
+// Built-in sanitizers are "trim" (remove leading and trailing white space),
+// "lower", "upper", "collapse" (replace white space runs with a single space)
+// and "truncate=N" (keep at most N characters). More can be added with
+// RegisterSanitizer.
+//
+// ErrInvalidTag is returned if a sanitizer is unknown or the tag is on a
+// non-string field.
+//
+// NOTE: 'obj' struct must be passed by pointer for this API to work. Passing by
+// value results in ErrNotPtr.
+func Sanitize(obj interface{}) error {
+	objValue, err := getStructByPtr(obj)
+	if err != nil {
+		return err
+	}
+
+	// The sanitizers are looked up once, so that they are not called with the
+	// registry locked, such as a sanitizer which registers another one.
+	funcs := map[string]SanitizeFunc{}
+	sanitizers.RLock()
+	err = checkSanitizeTags(objValue.Type(), map[reflect.Type]bool{}, funcs)
+	sanitizers.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	// The fields are set only once all their new values are computed, so that
+	// an error leaves the struct unchanged.
+	fields := []reflect.Value{}
+	values := []string{}
+	t := newTracker(newOptions(nil))
+	t.enter(valueOf(obj))
+	err = transformFields(objValue, reflect.String, map[visitKey]bool{}, t,
+		func(fieldType reflect.StructField, fieldValue reflect.Value) error {
+			tag, found := fieldType.Tag.Lookup("sanitize")
+			if !found {
+				return nil
+			}
+
+			value := fieldValue.String()
+			for _, rule := range strings.Split(tag, ",") {
+				name, arg := sanitizeRule(rule)
+				var err error
+				if value, err = funcs[name](value, arg); err != nil {
+					return err
+				}
+			}
+
+			fields = append(fields, fieldValue)
+			values = append(values, value)
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+
+	for i, fieldValue := range fields {
+		fieldValue.SetString(values[i])
+	}
+	return nil
+}
+
+// sanitizeRule splits a rule of a "sanitize" tag into the sanitizer name and
+// its argument, such as "truncate" and "64" for "truncate=64".
+func sanitizeRule(rule string) (string, string) {
+	name, arg := rule, ""
+	if idx := strings.Index(rule, "="); idx != -1 {
+		name, arg = rule[:idx], rule[idx+1:]
+	}
+	return strings.TrimSpace(name), arg
+}
+
+// checkSanitizeTags returns ErrInvalidTag if a "sanitize" tag is found on a
+// non-string field of a struct type, or of its nested struct types, or if it
+// lists an unknown sanitizer. The sanitizers listed are added to 'funcs'. The
+// registry must be locked by the caller.
+func checkSanitizeTags(objType reflect.Type, checked map[reflect.Type]bool,
+	funcs map[string]SanitizeFunc) error {
+	checked[objType] = true
+	for i := 0; i < objType.NumField(); i++ {
+		fieldType := objType.Field(i)
+		if tag, found := fieldType.Tag.Lookup("sanitize"); found {
+			if fieldType.Type.Kind() != reflect.String {
+				return ErrInvalidTag
+			}
+			for _, rule := range strings.Split(tag, ",") {
+				name, _ := sanitizeRule(rule)
+				fn, ok := sanitizers.funcs[name]
+				if !ok {
+					return ErrInvalidTag
+				}
+				funcs[name] = fn
+			}
+		}
+
+		nestedType := fieldType.Type
+		if nestedType.Kind() == reflect.Ptr {
+			nestedType = nestedType.Elem()
+		}
+		if nestedType.Kind() == reflect.Struct && !checked[nestedType] {
+			if err := checkSanitizeTags(nestedType, checked, funcs); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package attr
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Comment struct {
+	Author string `sanitize:"trim,lower"`
+	Title  string `sanitize:"collapse,truncate=10"`
+	Body   string `sanitize:"trim,reverse"`
+	Raw    string
+	Reply  *Comment
+}
+
+func TestSanitize(t *testing.T) {
+	RegisterSanitizer("reverse", func(value, _ string) (string, error) {
+		runes := []rune(value)
+		for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+			runes[i], runes[j] = runes[j], runes[i]
+		}
+		return string(runes), nil
+	})
+
+	comment := Comment{
+		Author: "  SRathi ",
+		Title:  "A   very\tlong title",
+		Body:   " olleh ",
+		Raw:    " raw ",
+		Reply:  &Comment{Author: "Someone "},
+	}
+	want := Comment{
+		Author: "srathi",
+		Title:  "A very lon",
+		Body:   "hello",
+		Raw:    " raw ",
+		Reply:  &Comment{Author: "someone"},
+	}
+	require.Nil(t, Sanitize(&comment))
+	require.Equal(t, want, comment, "Struct not sanitized")
+
+	type Unknown struct {
+		Name string `sanitize:"abc"`
+	}
+	require.Equal(t, ErrInvalidTag, Sanitize(&Unknown{}), "Able to use an unknown sanitizer")
+
+	type BadSize struct {
+		Name string `sanitize:"truncate=abc"`
+	}
+	require.Equal(t, ErrInvalidTag, Sanitize(&BadSize{}), "Able to truncate to a bad size")
+
+	type NotString struct {
+		Age int `sanitize:"trim"`
+	}
+	require.Equal(t, ErrInvalidTag, Sanitize(&NotString{}), "Able to sanitize an int field")
+
+	// Nothing is changed if a later field fails.
+	type Partial struct {
+		Name  string `sanitize:"trim"`
+		Other string `sanitize:"truncate=abc"`
+		Next  *Partial
+	}
+	partial := Partial{Name: " a ", Next: &Partial{Name: " b ", Other: "c"}}
+	require.Equal(t, ErrInvalidTag, Sanitize(&partial), "Able to truncate to a bad size")
+	require.Equal(t, " a ", partial.Name, "Struct changed on an error")
+	require.Equal(t, " b ", partial.Next.Name, "Nested struct changed on an error")
+
+	// A sanitizer can use the registry, as it is not locked while it runs.
+	RegisterSanitizer("register", func(value, _ string) (string, error) {
+		RegisterSanitizer("registered", func(value, _ string) (string, error) { return value, nil })
+		return value, nil
+	})
+	type Registering struct {
+		Name string `sanitize:"register"`
+	}
+	require.Nil(t, Sanitize(&Registering{Name: "a"}))
+
+	require.Equal(t, ErrNotPtr, Sanitize(comment), "Able to sanitize a struct passed by value")
+}
+
+func ExampleSanitize() {
+	type Signup struct {
+		Email string `sanitize:"trim,lower"`
+		Name  string `sanitize:"collapse,truncate=8"`
+	}
+	signup := Signup{Email: " SRathi@Example.com ", Name: "Shyam   Sundar"}
+
+	err := Sanitize(&signup)
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Println(strings.Join([]string{signup.Email, signup.Name}, "|"))
+	// Output: srathi@example.com|Shyam Su
+}
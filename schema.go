@@ -0,0 +1,225 @@
+package attr
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// timeType is the reflect type of time.Time, which needs special handling.
+var timeType = reflect.TypeOf(time.Time{})
+
+// FieldChange describes how a single struct field differs between two struct
+// types. For a retyped field, Old and New are the type names, and for a
+// retagged field, they are the raw struct tags.
+type FieldChange struct {
+	Field string
+	Old   string
+	New   string
+}
+
+// SchemaDiff is the result of CompareSchemas. Each slice lists the affected
+// exported (public) field names in the declaration order of the struct they
+// are found in.
+type SchemaDiff struct {
+	Added    []string
+	Removed  []string
+	Retyped  []FieldChange
+	Retagged []FieldChange
+}
+
+// Compatible returns true if a value of the old struct type can be
+// represented by the new struct type, i.e. no field was removed, retyped or
+// retagged. Added fields are considered compatible.
+func (d SchemaDiff) Compatible() bool {
+	return len(d.Removed) == 0 && len(d.Retyped) == 0 && len(d.Retagged) == 0
+}
+
+// Equal returns true if both the struct types have the same exported fields,
+// with the same types and tags.
+func (d SchemaDiff) Equal() bool {
+	return d.Compatible() && len(d.Added) == 0
+}
+
+// CompareSchemas compares the exported (public) fields of two struct types,
+// 'a' being the old version and 'b' being the new one, and returns the fields
+// which were added, removed, retyped or retagged in 'b'.
+// Both 'a' and 'b' can be passed by value or by pointer.
+//
+// Field types are compared by their names (such as "int" or "time.Time"), so
+// that two different versions of a nested named type are considered equal.
+func CompareSchemas(a, b interface{}) (SchemaDiff, error) {
+	diff := SchemaDiff{
+		Added:    []string{},
+		Removed:  []string{},
+		Retyped:  []FieldChange{},
+		Retagged: []FieldChange{},
+	}
+
+	aValue, err := getReflectValue(a)
+	if err != nil {
+		return diff, err
+	}
+
+	bValue, err := getReflectValue(b)
+	if err != nil {
+		return diff, err
+	}
+
+	aType, bType := aValue.Type(), bValue.Type()
+	for i := 0; i < aType.NumField(); i++ {
+		aField := aType.Field(i)
+		if aField.PkgPath != "" {
+			continue
+		}
+
+		bField, found := bType.FieldByName(aField.Name)
+		if !found || bField.PkgPath != "" || len(bField.Index) != 1 {
+			diff.Removed = append(diff.Removed, aField.Name)
+			continue
+		}
+
+		if aField.Type.String() != bField.Type.String() {
+			diff.Retyped = append(diff.Retyped, FieldChange{
+				aField.Name, aField.Type.String(), bField.Type.String()})
+		}
+
+		if aField.Tag != bField.Tag {
+			diff.Retagged = append(diff.Retagged, FieldChange{
+				aField.Name, string(aField.Tag), string(bField.Tag)})
+		}
+	}
+
+	for i := 0; i < bType.NumField(); i++ {
+		bField := bType.Field(i)
+		if bField.PkgPath != "" {
+			continue
+		}
+
+		aField, found := aType.FieldByName(bField.Name)
+		if !found || aField.PkgPath != "" || len(aField.Index) != 1 {
+			diff.Added = append(diff.Added, bField.Name)
+		}
+	}
+
+	return diff, nil
+}
+
+// jsonSchemaDraft is the JSON schema version emitted by JSONSchema.
+const jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+// JSONSchema generates a draft-07 JSON Schema document for the given struct,
+// which can be passed by value or by pointer.
+//
+// Property names are taken from the "json" tags of the exported (public)
+// fields ("-" fields are skipped), and nested structs, slices, arrays, maps
+// and pointers are described recursively. A field is listed as "required" if
+// it is tagged with `required:"true"` or its "validate" tag contains "required".
+func JSONSchema(obj interface{}) ([]byte, error) {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	objType := objValue.Type()
+	schema := jsonSchemaOf(objType, objType, map[reflect.Type]bool{})
+	schema["$schema"] = jsonSchemaDraft
+	if objType.Name() != "" {
+		schema["title"] = objType.Name()
+	}
+
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+// jsonSchemaOf returns the JSON schema of a given type. 'inProgress' holds the
+// struct types being expanded to stop infinite recursion on recursive types.
+func jsonSchemaOf(typ, root reflect.Type, inProgress map[reflect.Type]bool) map[string]interface{} {
+	if typ == timeType {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Ptr:
+		return jsonSchemaOf(typ.Elem(), root, inProgress)
+	case reflect.Slice, reflect.Array:
+		if typ.Elem().Kind() == reflect.Uint8 && typ.Kind() == reflect.Slice {
+			return map[string]interface{}{"type": "string", "contentEncoding": "base64"}
+		}
+		schema := map[string]interface{}{
+			"type":  "array",
+			"items": jsonSchemaOf(typ.Elem(), root, inProgress),
+		}
+		if typ.Kind() == reflect.Array {
+			schema["minItems"] = typ.Len()
+			schema["maxItems"] = typ.Len()
+		}
+		return schema
+	case reflect.Map:
+		return map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": jsonSchemaOf(typ.Elem(), root, inProgress),
+		}
+	case reflect.Struct:
+		if typ == root && inProgress[typ] {
+			return map[string]interface{}{"$ref": "#"}
+		}
+		if inProgress[typ] {
+			return map[string]interface{}{"type": "object"}
+		}
+		inProgress[typ] = true
+		defer delete(inProgress, typ)
+
+		properties := map[string]interface{}{}
+		required := []string{}
+		jsonSchemaFields(typ, root, inProgress, properties, &required)
+
+		schema := map[string]interface{}{"type": "object", "properties": properties}
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+		return schema
+	}
+
+	// Interfaces and other kinds can hold any value.
+	return map[string]interface{}{}
+}
+
+// jsonSchemaFields adds the schema of all the exported fields of a struct type
+// to 'properties', promoting the fields of embedded structs like encoding/json
+// (see jsonMembers).
+func jsonSchemaFields(typ, root reflect.Type, inProgress map[reflect.Type]bool,
+	properties map[string]interface{}, required *[]string) {
+	for _, member := range cachedJSONMembers(typ) {
+		field := typ.FieldByIndex(member.index)
+		properties[member.name] = jsonSchemaOf(field.Type, root, inProgress)
+
+		if isRequired(field.Tag) {
+			*required = append(*required, member.name)
+		}
+	}
+}
+
+// isRequired returns true if a struct field is tagged as a required field,
+// either with `required:"true"` or with a "required" rule in a "validate" tag.
+func isRequired(tag reflect.StructTag) bool {
+	if tag.Get("required") == "true" {
+		return true
+	}
+
+	for _, rule := range strings.Split(tag.Get("validate"), ",") {
+		if rule == "required" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,179 @@
+package attr
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type UserV2 struct {
+	Username string  `json:"user_name" db:"uname"`
+	Age      float64 `json:"age" meta:"important"`
+	Email    string  `json:"email"`
+}
+
+func TestCompareSchemas(t *testing.T) {
+	got, err := CompareSchemas(user, &user)
+	require.Nil(t, err)
+	require.True(t, got.Equal(), "Same struct types are reported as different")
+
+	want := SchemaDiff{
+		Added:   []string{"Email"},
+		Removed: []string{},
+		Retyped: []FieldChange{{"Age", "int", "float64"}},
+		Retagged: []FieldChange{
+			{"Username", `json:"username" db:"uname"`, `json:"user_name" db:"uname"`}},
+	}
+	got, err = CompareSchemas(user, UserV2{})
+	require.Nil(t, err)
+	require.Equal(t, want, got, "Schema difference is not correct")
+	require.False(t, got.Compatible(), "Retyped fields are reported as compatible")
+
+	got, err = CompareSchemas(UserV2{}, user)
+	require.Nil(t, err)
+	require.Equal(t, []string{"Email"}, got.Removed, "Removed field not reported")
+
+	_, err = CompareSchemas(user, "abc")
+	require.Equal(t, ErrNotStruct, err, "Able to compare a non-struct")
+}
+
+func ExampleCompareSchemas() {
+	// type User struct {
+	// 	Username string `json:"username" db:"uname"`
+	// 	Age      int    `json:"age" meta:"important"`
+	// 	password string
+	// }
+	type NewUser struct {
+		Username string `json:"username" db:"uname"`
+		Age      int    `json:"age" meta:"important"`
+		Email    string `json:"email"`
+	}
+
+	diff, err := CompareSchemas(User{}, NewUser{})
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Printf("Added: %v, compatible: %v\n", diff.Added, diff.Compatible())
+	// Output: Added: [Email], compatible: true
+}
+
+type Address struct {
+	City string `json:"city" validate:"required"`
+	Zip  string `json:"zip,omitempty"`
+}
+
+type Employee struct {
+	Name     string         `json:"name" required:"true"`
+	Tags     []string       `json:"tags"`
+	Address  *Address       `json:"address"`
+	Manager  *Employee      `json:"manager"`
+	Labels   map[string]int `json:"labels"`
+	Ignored  string         `json:"-"`
+	Extra    interface{}    `json:"extra"`
+	internal string
+}
+
+func TestJSONSchema(t *testing.T) {
+	want := `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "properties": {
+    "address": {
+      "properties": {
+        "city": {
+          "type": "string"
+        },
+        "zip": {
+          "type": "string"
+        }
+      },
+      "required": [
+        "city"
+      ],
+      "type": "object"
+    },
+    "extra": {},
+    "labels": {
+      "additionalProperties": {
+        "type": "integer"
+      },
+      "type": "object"
+    },
+    "manager": {
+      "$ref": "#"
+    },
+    "name": {
+      "type": "string"
+    },
+    "tags": {
+      "items": {
+        "type": "string"
+      },
+      "type": "array"
+    }
+  },
+  "required": [
+    "name"
+  ],
+  "title": "Employee",
+  "type": "object"
+}`
+	got, err := JSONSchema(&Employee{})
+	require.Nil(t, err)
+	require.Equal(t, want, string(got), "JSON schema is not correct")
+
+	_, err = JSONSchema([]int{})
+	require.Equal(t, ErrNotStruct, err, "Able to get JSON schema of a non-struct")
+
+	// A struct embedding itself must not recurse forever.
+	type Node struct {
+		*Node
+		X int `json:"x"`
+	}
+	got, err = JSONSchema(Node{})
+	require.Nil(t, err)
+	require.Contains(t, string(got), `"x": {`, "JSON schema of a self-embedding struct is not correct")
+
+	// A shallower field shadows a promoted one of the same name.
+	type Inner struct {
+		Name int
+		Rank int
+	}
+	type Outer struct {
+		Name string
+		Inner
+	}
+	got, err = JSONSchema(Outer{})
+	require.Nil(t, err)
+	var schema struct {
+		Properties map[string]map[string]interface{}
+	}
+	require.Nil(t, json.Unmarshal(got, &schema))
+	require.Equal(t, "string", schema.Properties["Name"]["type"], "Promoted field shadows a shallower one")
+	require.Equal(t, "integer", schema.Properties["Rank"]["type"], "Promoted field is missing")
+}
+
+func ExampleJSONSchema() {
+	testUser := User{Username: "srathi", password: "secret", Age: 30}
+
+	schema, err := JSONSchema(testUser)
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Println(string(schema))
+	// Output:
+	// {
+	//   "$schema": "http://json-schema.org/draft-07/schema#",
+	//   "properties": {
+	//     "age": {
+	//       "type": "integer"
+	//     },
+	//     "username": {
+	//       "type": "string"
+	//     }
+	//   },
+	//   "title": "User",
+	//   "type": "object"
+	// }
+}
@@ -0,0 +1,210 @@
+package attr
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// SetPaths sets the given values to many fields of a struct at once, like
+// SetValue. The keys of 'values' are the field names, or dot separated paths
+// for the fields of the nested structs, such as "Address.City", with optional
+// indexes of the elements of the slice and array fields, such as "Tags[0]".
+// Only exported (public) fields can be set using this API.
+//
+// All the fields are looked up and the types of all the values are checked
+// before setting any of them, so the struct is never left half-updated. The
+// error is a *FieldError with the path of the first bad field, in the sorted
+// order of the paths. Use WithChangeHook to get a ChangeRecord of every change,
+// in the same order.
+//
+// NOTE: 'obj' struct must be passed by pointer for this API to work. Passing by
+// value results in ErrNotPtr.
+func SetPaths(obj interface{}, values map[string]interface{}, opts ...Option) error {
+	objValue, err := getStructByPtr(obj)
+	if err != nil {
+		return err
+	}
+
+	paths := make([]string, 0, len(values))
+	for path := range values {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	fieldValues := make([]reflect.Value, len(paths))
+	for i, path := range paths {
+		fieldValue, err := getFieldByPath(objValue, path, false)
+		if err != nil {
+			return &FieldError{path, err}
+		}
+
+		if fieldValue.Type() != reflect.TypeOf(values[path]) {
+			return &FieldError{path, ErrMismatchValue}
+		}
+		fieldValues[i] = fieldValue
+	}
+
+	o := newOptions(opts)
+	for i, fieldValue := range fieldValues {
+		oldValue := fieldValue.Interface()
+		fieldValue.Set(reflect.ValueOf(values[paths[i]]))
+		o.emitChange(paths[i], oldValue, values[paths[i]])
+	}
+	return nil
+}
+
+// SetValueFromString parses a string as the type of a field of a struct and
+// sets the field to it, such as "42" for an int field or "1m30s" for a
+// time.Duration field. The field can be a dot separated path for a field of a
+// nested struct, such as "Address.City". The enum types registered by
+// RegisterEnum are parsed by their names, time.Time fields with the layout
+// given by the WithTimeLayout option, slices from comma separated items, []byte
+// fields with the encoding of their "attr" tag (such as `attr:"base64"` or
+// `attr:"hex"`), and the types implementing encoding.TextUnmarshaler with it.
+// Only exported (public) fields can be set using this API.
+//
+// ErrInvalidValue is returned if the string can't be parsed (including an
+// unknown enum name), and ErrMismatchValue if the field type can't be parsed
+// from a string. Use WithChangeHook to get a ChangeRecord of the change.
+//
+// NOTE: 'obj' struct must be passed by pointer for this API to work. Passing by
+// value results in ErrNotPtr.
+func SetValueFromString(obj interface{}, path, text string, opts ...Option) error {
+	if _, err := getStructByPtr(obj); err != nil {
+		return err
+	}
+
+	field, fieldValue, err := RawField(obj, path)
+	if err != nil {
+		return err
+	}
+
+	if !fieldValue.CanSet() {
+		return ErrUnexportedField
+	}
+
+	var value reflect.Value
+	decoded := false
+	// An element of a []byte field, such as "Data[0]", is a single byte.
+	if field.Type == fieldValue.Type() {
+		if value, decoded, err = decodeBytes(field, text); err != nil {
+			return err
+		}
+	}
+
+	o := newOptions(opts)
+	if !decoded {
+		if value, err = parseString(text, fieldValue.Type(), o); err != nil {
+			return err
+		}
+	}
+
+	oldValue := fieldValue.Interface()
+	fieldValue.Set(value)
+	o.emitChange(path, oldValue, value.Interface())
+	return nil
+}
+
+// WithValue returns a shallow copy of a struct with the given value set to a
+// field, like SetValue, leaving the original struct untouched. The field can be
+// a dot separated path for a field of a nested struct, such as "Address.City",
+// and the structs referenced by the pointers in the path are copied as well.
+//
+// The copy is returned in the same form as 'obj' is passed, i.e. a struct for
+// a struct and a pointer to the copy for a pointer.
+func WithValue(obj interface{}, fieldName string, newValue interface{}) (interface{}, error) {
+	result, _, err := withValues(obj, map[string]interface{}{fieldName: newValue})
+	return result, err
+}
+
+// WithValues is the same as WithValue, but sets many fields at once, like
+// SetPaths. The error is a *FieldError with the path of the first bad field, in
+// the sorted order of the paths.
+func WithValues(obj interface{}, values map[string]interface{}) (interface{}, error) {
+	result, path, err := withValues(obj, values)
+	if err != nil && path != "" {
+		return nil, &FieldError{path, err}
+	}
+	return result, err
+}
+
+// withValues returns a copy of a struct with the given values set, and the
+// path of the field which caused an error.
+func withValues(obj interface{}, values map[string]interface{}) (interface{}, string, error) {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return nil, "", err
+	}
+
+	copyValue := reflect.New(objValue.Type())
+	copyValue.Elem().Set(objValue)
+
+	paths := make([]string, 0, len(values))
+	for path := range values {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	// copied holds the nested structs already copied for the earlier paths.
+	copied := map[uintptr]bool{}
+	for _, path := range paths {
+		fieldValue, err := copyPath(copyValue.Elem(), path, copied)
+		if err != nil {
+			return nil, path, err
+		}
+
+		if fieldValue.Type() != reflect.TypeOf(values[path]) {
+			return nil, path, ErrMismatchValue
+		}
+		fieldValue.Set(reflect.ValueOf(values[path]))
+	}
+
+	if valueOf(obj).Kind() == reflect.Ptr {
+		return copyValue.Interface(), "", nil
+	}
+	return copyValue.Elem().Interface(), "", nil
+}
+
+// copyPath returns the reflect-value of a nested field of a struct given by a
+// dot separated path, like getFieldByPath, after replacing every pointer to a
+// struct in the path with a pointer to a shallow copy of the struct.
+func copyPath(objValue reflect.Value, path string, copied map[uintptr]bool) (reflect.Value, error) {
+	names := strings.Split(path, pathSeparator)
+	fieldValue := objValue
+	for i, name := range names {
+		if fieldValue.Kind() == reflect.Ptr {
+			if fieldValue.IsNil() {
+				return reflect.Value{}, &NilPathError{strings.Join(names[:i], pathSeparator), i}
+			}
+
+			if !copied[fieldValue.Pointer()] {
+				elemCopy := reflect.New(fieldValue.Type().Elem())
+				elemCopy.Elem().Set(fieldValue.Elem())
+				fieldValue.Set(elemCopy)
+				copied[elemCopy.Pointer()] = true
+			}
+			fieldValue = fieldValue.Elem()
+		}
+
+		if fieldValue.Kind() != reflect.Struct {
+			return reflect.Value{}, ErrNotStruct
+		}
+
+		var err error
+		fieldValue, err = fieldByName(fieldValue, name)
+		if err == ErrNilPointer {
+			// The field is promoted through a nil embedded pointer.
+			return reflect.Value{}, &NilPathError{strings.Join(names[:i+1], pathSeparator), i + 1}
+		}
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		if !fieldValue.CanSet() {
+			return reflect.Value{}, ErrUnexportedField
+		}
+	}
+
+	return fieldValue, nil
+}
@@ -0,0 +1,86 @@
+package attr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetPaths(t *testing.T) {
+	employee := Employee{Name: "srathi", Address: &Address{City: "San Jose"}}
+	err := SetPaths(&employee, map[string]interface{}{
+		"Name":         "shyam",
+		"Address.City": "Fremont",
+		"Tags":         []string{"go"},
+	})
+	require.Nil(t, err)
+	require.Equal(t, "shyam", employee.Name, "Field not set")
+	require.Equal(t, "Fremont", employee.Address.City, "Nested field not set")
+	require.Equal(t, []string{"go"}, employee.Tags, "Slice field not set")
+
+	for _, test := range []struct {
+		values map[string]interface{}
+		want   error
+	}{
+		{map[string]interface{}{"Name": "abc", "Address.Zip": 95134}, &FieldError{"Address.Zip", ErrMismatchValue}},
+		{map[string]interface{}{"Name": "abc", "Manager.Name": "abc"}, &FieldError{"Manager.Name", &NilPathError{"Manager", 1}}},
+		{map[string]interface{}{"Name": "abc", "internal": "abc"}, &FieldError{"internal", ErrUnexportedField}},
+		{map[string]interface{}{"ABC": 1, "Name": "abc"}, &FieldError{"ABC", ErrNoField}},
+	} {
+		err := SetPaths(&employee, test.values)
+		require.Equal(t, test.want, err, "Error is not correct for %v", test.values)
+		require.Equal(t, "shyam", employee.Name, "Struct is updated on an error")
+	}
+
+	err = SetPaths(employee, map[string]interface{}{"Name": "abc"})
+	require.True(t, errors.Is(err, ErrNotPtr), "Able to set a struct passed by value")
+}
+
+func ExampleSetPaths() {
+	testUser := User{Username: "srathi", password: "secret", Age: 30}
+
+	err := SetPaths(&testUser, map[string]interface{}{"Username": "shyam", "Age": "31"})
+	fmt.Println(err, testUser.Username)
+	// Output: Age: Specified value to set is of a different type srathi
+}
+
+func TestWithValue(t *testing.T) {
+	employee := Employee{Name: "srathi", Tags: []string{"go"}, Address: &Address{City: "San Jose"}}
+	got, err := WithValue(employee, "Name", "shyam")
+	require.Nil(t, err)
+	require.Equal(t, "shyam", got.(Employee).Name, "Field not set in the copy")
+	require.Equal(t, "srathi", employee.Name, "Original struct is modified")
+	require.Equal(t, employee.Address, got.(Employee).Address, "Pointer field not copied")
+
+	got, err = WithValues(&employee, map[string]interface{}{
+		"Address.City": "Fremont",
+		"Address.Zip":  "94536",
+	})
+	require.Nil(t, err)
+	updated := got.(*Employee)
+	require.Equal(t, Address{City: "Fremont", Zip: "94536"}, *updated.Address, "Nested fields not set in the copy")
+	require.Equal(t, "San Jose", employee.Address.City, "Original nested struct is modified")
+	require.Equal(t, "srathi", updated.Name, "Other field not copied")
+
+	_, err = WithValue(employee, "Manager.Name", "abc")
+	require.Equal(t, &NilPathError{"Manager", 1}, err, "Able to set a field of a nil pointer")
+
+	_, err = WithValues(employee, map[string]interface{}{"Name": "abc", "internal": "abc"})
+	require.Equal(t, &FieldError{"internal", ErrUnexportedField}, err, "Able to set a private field")
+
+	_, err = WithValue(employee, "Name", 10)
+	require.Equal(t, ErrMismatchValue, err, "Able to set a value of a different type")
+}
+
+func ExampleWithValue() {
+	testUser := User{Username: "srathi", password: "secret", Age: 30}
+
+	older, err := WithValue(testUser, "Age", 31)
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Println(testUser.Age, older.(User).Age)
+	// Output: 30 31
+}
@@ -0,0 +1,134 @@
+package attr
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// Approximate memory used by the runtime for a map header and for each bucket
+// of 8 entries, excluding the keys and values themselves.
+const (
+	mapHeaderSize     = 48
+	mapBucketEntries  = 8
+	mapBucketOverhead = mapBucketEntries + unsafe.Sizeof(uintptr(0))
+)
+
+// visitKey identifies a memory block already counted by DeepSize. The type is
+// needed as a struct and its first field share the same address.
+type visitKey struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// DeepSize returns the total memory footprint of a struct in bytes. This
+// includes the struct itself and everything reachable from it, such as the
+// pointed-to values, the backing arrays of slices and strings, channel
+// buffers and the values held in interfaces. Both exported and unexported
+// fields are counted.
+//
+// Map sizes are approximated from the number of entries, as the exact memory
+// used by a map depends on the runtime. A memory block referenced more than
+// once (including through a cycle) is counted only once.
+func DeepSize(obj interface{}) (uint64, error) {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return 0, err
+	}
+
+	// A struct passed by pointer can be referenced by its own fields.
+	visited := map[visitKey]bool{}
+	if ptrValue := valueOf(obj); ptrValue.Kind() == reflect.Ptr {
+		markVisited(ptrValue, visited)
+	}
+	return uint64(objValue.Type().Size()) + indirectSize(objValue, visited), nil
+}
+
+// indirectSize returns the number of bytes referenced by a value, excluding
+// the memory used by the value itself.
+func indirectSize(value reflect.Value, visited map[visitKey]bool) uint64 {
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() || !markVisited(value, visited) {
+			return 0
+		}
+		elem := value.Elem()
+		return uint64(elem.Type().Size()) + indirectSize(elem, visited)
+
+	case reflect.Struct:
+		size := uint64(0)
+		for i := 0; i < value.NumField(); i++ {
+			size += indirectSize(value.Field(i), visited)
+		}
+		return size
+
+	case reflect.Array:
+		size := uint64(0)
+		for i := 0; i < value.Len(); i++ {
+			size += indirectSize(value.Index(i), visited)
+		}
+		return size
+
+	case reflect.Slice:
+		if value.IsNil() || !markVisited(value, visited) {
+			return 0
+		}
+		size := uint64(value.Cap()) * uint64(value.Type().Elem().Size())
+		for i := 0; i < value.Len(); i++ {
+			size += indirectSize(value.Index(i), visited)
+		}
+		return size
+
+	case reflect.String:
+		return uint64(value.Len())
+
+	case reflect.Map:
+		if value.IsNil() || !markVisited(value, visited) {
+			return 0
+		}
+		mapType := value.Type()
+		buckets := uint64(1)
+		for buckets*mapBucketEntries < uint64(value.Len()) {
+			buckets *= 2
+		}
+		bucketSize := uint64(mapBucketOverhead) +
+			mapBucketEntries*uint64(mapType.Key().Size()+mapType.Elem().Size())
+		size := mapHeaderSize + buckets*bucketSize
+
+		iter := value.MapRange()
+		for iter.Next() {
+			size += indirectSize(iter.Key(), visited) + indirectSize(iter.Value(), visited)
+		}
+		return size
+
+	case reflect.Chan:
+		if value.IsNil() || !markVisited(value, visited) {
+			return 0
+		}
+		return uint64(value.Cap()) * uint64(value.Type().Elem().Size())
+
+	case reflect.Interface:
+		if value.IsNil() {
+			return 0
+		}
+		elem := value.Elem()
+		switch elem.Kind() {
+		case reflect.Ptr, reflect.Map, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+			// Pointer shaped values are stored directly in the interface.
+			return indirectSize(elem, visited)
+		}
+		return uint64(elem.Type().Size()) + indirectSize(elem, visited)
+	}
+
+	return 0
+}
+
+// markVisited marks the memory block referenced by a pointer-like value as
+// visited. Returns false if it was already visited before.
+func markVisited(value reflect.Value, visited map[visitKey]bool) bool {
+	key := visitKey{value.Pointer(), value.Type()}
+	if visited[key] {
+		return false
+	}
+	visited[key] = true
+	return true
+}
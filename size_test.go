@@ -0,0 +1,57 @@
+package attr
+
+import (
+	"fmt"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Node struct {
+	Name     string
+	Values   []int64
+	Next     *Node
+	Children []*Node
+}
+
+func TestDeepSize(t *testing.T) {
+	node := Node{Name: "root", Values: make([]int64, 2, 4)}
+	want := uint64(unsafe.Sizeof(node)) + 4 + 4*8
+	got, err := DeepSize(&node)
+	require.Nil(t, err)
+	require.Equal(t, want, got, "Deep size is not correct")
+
+	// A cycle must be counted once.
+	node.Next = &node
+	got, err = DeepSize(&node)
+	require.Nil(t, err)
+	require.Equal(t, want, got, "Deep size with a cycle is not correct")
+
+	child := &Node{Name: "child"}
+	node.Next = nil
+	node.Children = []*Node{child, child}
+	want += 2*uint64(unsafe.Sizeof(child)) + uint64(unsafe.Sizeof(*child)) + 5
+	got, err = DeepSize(node)
+	require.Nil(t, err)
+	require.Equal(t, want, got, "Deep size with shared pointers is not correct")
+
+	// Only the private string's bytes are added to the struct size.
+	got, err = DeepSize(User{password: "secret"})
+	require.Nil(t, err)
+	require.Equal(t, uint64(unsafe.Sizeof(user))+6, got, "Deep size is not correct")
+
+	_, err = DeepSize("abc")
+	require.Equal(t, ErrNotStruct, err, "Able to get deep size of a non-struct")
+}
+
+func ExampleDeepSize() {
+	testUser := User{Username: "srathi", password: "secret", Age: 30}
+
+	size, err := DeepSize(&testUser)
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Printf("Memory used: %d bytes\n", size)
+	// Output: Memory used: 52 bytes
+}
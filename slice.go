@@ -0,0 +1,463 @@
+package attr
+
+import (
+	"reflect"
+	"sort"
+	"time"
+)
+
+// getSliceValue gets the reflect-value of a given slice (or array) of structs
+// or pointers to structs. If it is a pointer to a slice, then it gives the
+// reflect-value of the underlying slice.
+//
+// Returns ErrNotSlice if the given obj is not such a slice, and ErrNilPointer if
+// it is a nil pointer to a slice or an array. A nil slice is an empty slice.
+func getSliceValue(obj interface{}) (reflect.Value, error) {
+	value := valueOf(obj)
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			if kind := value.Type().Elem().Kind(); kind == reflect.Slice || kind == reflect.Array {
+				return reflect.Value{}, ErrNilPointer
+			}
+			return reflect.Value{}, ErrNotSlice
+		}
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return reflect.Value{}, ErrNotSlice
+	}
+
+	elemType := value.Type().Elem()
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return reflect.Value{}, ErrNotSlice
+	}
+
+	return value, nil
+}
+
+// sliceFields returns the reflect-values of a given field (or a nested field
+// path) of all the elements of a slice of structs.
+func sliceFields(sliceValue reflect.Value, path string) ([]reflect.Value, error) {
+	fields := make([]reflect.Value, sliceValue.Len())
+	for i := range fields {
+		fieldValue, err := getFieldByPath(sliceValue.Index(i), path, false)
+		if err != nil {
+			return nil, err
+		}
+		fields[i] = fieldValue
+	}
+	return fields, nil
+}
+
+// compareValues compares two values of the same type and returns -1, 0 or +1.
+// Numbers, strings, bools (false before true) and time.Time values can be
+// compared, else ErrMismatchValue is returned.
+func compareValues(a, b reflect.Value) (int, error) {
+	if a.Type() == timeType {
+		at, bt := a.Interface().(time.Time), b.Interface().(time.Time)
+		switch {
+		case at.Before(bt):
+			return -1, nil
+		case at.After(bt):
+			return 1, nil
+		}
+		return 0, nil
+	}
+
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return compareOrdered(a.Int() < b.Int(), a.Int() > b.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return compareOrdered(a.Uint() < b.Uint(), a.Uint() > b.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return compareOrdered(a.Float() < b.Float(), a.Float() > b.Float()), nil
+	case reflect.String:
+		return compareOrdered(a.String() < b.String(), a.String() > b.String()), nil
+	case reflect.Bool:
+		return compareOrdered(!a.Bool() && b.Bool(), a.Bool() && !b.Bool()), nil
+	}
+
+	return 0, ErrMismatchValue
+}
+
+// compareOrdered returns the comparison result given the "less" and the
+// "greater" checks.
+func compareOrdered(less, greater bool) int {
+	switch {
+	case less:
+		return -1
+	case greater:
+		return 1
+	}
+	return 0
+}
+
+// fieldSorter sorts a slice along with the values of its sorting field.
+type fieldSorter struct {
+	keys []reflect.Value
+	swap func(i, j int)
+	desc bool
+}
+
+func (s *fieldSorter) Len() int {
+	return len(s.keys)
+}
+
+func (s *fieldSorter) Less(i, j int) bool {
+	// The keys are checked to be comparable before sorting.
+	cmp, _ := compareValues(s.keys[i], s.keys[j])
+	if s.desc {
+		return cmp > 0
+	}
+	return cmp < 0
+}
+
+func (s *fieldSorter) Swap(i, j int) {
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+	s.swap(i, j)
+}
+
+// SortByField sorts a slice of structs (or pointers to structs) in place, by
+// the value of the given field, in ascending or descending ('desc') order. A
+// nested field can be given by a dot separated path, such as "Address.City".
+// The sort is stable, so the elements with equal field values keep their
+// order.
+//
+// The field must be an exported (public) field of a number, string, bool or
+// time.Time type, else ErrMismatchValue is returned.
+func SortByField(slice interface{}, fieldName string, desc bool) error {
+	sliceValue, err := getSliceValue(slice)
+	if err != nil {
+		return err
+	}
+
+	if sliceValue.Kind() == reflect.Array && !sliceValue.CanAddr() {
+		return ErrNotPtr
+	}
+
+	keys, err := sliceFields(sliceValue, fieldName)
+	if err != nil {
+		return err
+	}
+
+	if len(keys) > 0 {
+		if _, err := compareValues(keys[0], keys[0]); err != nil {
+			return err
+		}
+	}
+
+	// Copy the field values, as moving the elements would change them.
+	for i, key := range keys {
+		keys[i] = reflect.ValueOf(key.Interface())
+	}
+
+	swap := func(i, j int) {
+		a, b := sliceValue.Index(i), sliceValue.Index(j)
+		tmp := reflect.New(a.Type()).Elem()
+		tmp.Set(a)
+		a.Set(b)
+		b.Set(tmp)
+	}
+	if sliceValue.Kind() == reflect.Slice {
+		swap = reflect.Swapper(sliceValue.Interface())
+	}
+
+	sort.Stable(&fieldSorter{keys: keys, swap: swap, desc: desc})
+	return nil
+}
+
+// Filter returns a new slice with the elements of a slice of structs (or
+// pointers to structs) for which 'match' returns true on the value of the
+// given field. A nested field can be given by a dot separated path, such as
+// "Address.City". The returned slice is of the same type as the given slice
+// (or a slice of the element type for an array), so it can be type asserted.
+//
+// With the WithParallelism option, the elements are matched by concurrent
+// goroutines, so 'match' must be safe for concurrent use. The matching
+// elements keep their order in the returned slice.
+func Filter(slice interface{}, fieldName string, match func(value interface{}) bool,
+	opts ...Option) (interface{}, error) {
+	sliceValue, err := getSliceValue(slice)
+	if err != nil {
+		return nil, err
+	}
+
+	matched := make([]bool, sliceValue.Len())
+	err = parallelFor(newOptions(opts).parallelism, len(matched), func(start, end int) error {
+		for i := start; i < end; i++ {
+			fieldValue, err := getFieldByPath(sliceValue.Index(i), fieldName, false)
+			if err != nil {
+				return err
+			}
+			matched[i] = match(fieldValue.Interface())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sliceType := sliceValue.Type()
+	if sliceType.Kind() == reflect.Array {
+		sliceType = reflect.SliceOf(sliceType.Elem())
+	}
+
+	result := reflect.MakeSlice(sliceType, 0, 0)
+	for i, ok := range matched {
+		if ok {
+			result = reflect.Append(result, sliceValue.Index(i))
+		}
+	}
+
+	return result.Interface(), nil
+}
+
+// FilterEqual returns a new slice with the elements of a slice of structs (or
+// pointers to structs) whose given field is deeply equal to 'value'. See
+// Filter for more details.
+func FilterEqual(slice interface{}, fieldName string, value interface{}, opts ...Option) (interface{}, error) {
+	return Filter(slice, fieldName, func(fieldValue interface{}) bool {
+		return reflect.DeepEqual(fieldValue, value)
+	}, opts...)
+}
+
+// GroupBy groups the elements of a slice of structs (or pointers to structs) by
+// the value of the given field. It returns a map of the field type to a slice
+// of the elements, such as a map[int][]User for the "Age" field of a []User,
+// so it can be type asserted. The elements keep their order in each group.
+//
+// A nested field can be given by a dot separated path, such as "Address.City".
+// The field values must be usable as map keys, else ErrMismatchValue is
+// returned. For an interface field, this applies to the values it holds.
+func GroupBy(slice interface{}, fieldName string) (interface{}, error) {
+	sliceValue, keyType, fields, err := sliceKeys(slice, fieldName)
+	if err != nil {
+		return nil, err
+	}
+
+	groupType := reflect.SliceOf(sliceValue.Type().Elem())
+	groups := reflect.MakeMap(reflect.MapOf(keyType, groupType))
+	for i, field := range fields {
+		group := groups.MapIndex(field)
+		if !group.IsValid() {
+			group = reflect.MakeSlice(groupType, 0, 1)
+		}
+		groups.SetMapIndex(field, reflect.Append(group, sliceValue.Index(i)))
+	}
+
+	return groups.Interface(), nil
+}
+
+// IndexBy indexes the elements of a slice of structs (or pointers to structs)
+// by the value of the given field. It returns a map of the field type to the
+// element type, such as a map[string]User for the "Username" field of a
+// []User, so it can be type asserted. If more than one element has the same
+// field value, then the last one is kept.
+//
+// A nested field can be given by a dot separated path, such as "Address.City".
+// The field values must be usable as map keys, else ErrMismatchValue is
+// returned. For an interface field, this applies to the values it holds.
+func IndexBy(slice interface{}, fieldName string) (interface{}, error) {
+	sliceValue, keyType, fields, err := sliceKeys(slice, fieldName)
+	if err != nil {
+		return nil, err
+	}
+
+	index := reflect.MakeMap(reflect.MapOf(keyType, sliceValue.Type().Elem()))
+	for i, field := range fields {
+		index.SetMapIndex(field, sliceValue.Index(i))
+	}
+
+	return index.Interface(), nil
+}
+
+// sliceKeys returns the reflect-value of a slice of structs, the type of a
+// given field and the values of that field in all the elements, to use them
+// as map keys.
+func sliceKeys(slice interface{}, fieldName string) (reflect.Value, reflect.Type, []reflect.Value, error) {
+	sliceValue, err := getSliceValue(slice)
+	if err != nil {
+		return sliceValue, nil, nil, err
+	}
+
+	keyType, err := getFieldTypeByPath(sliceValue.Type().Elem(), fieldName)
+	if err != nil {
+		return sliceValue, nil, nil, err
+	}
+
+	if !keyType.Comparable() {
+		return sliceValue, nil, nil, ErrMismatchValue
+	}
+
+	fields, err := sliceFields(sliceValue, fieldName)
+	if err != nil {
+		return sliceValue, nil, nil, err
+	}
+
+	// An interface type is comparable, but the value it holds may not be.
+	for _, field := range fields {
+		if !field.Comparable() {
+			return sliceValue, nil, nil, ErrMismatchValue
+		}
+	}
+	return sliceValue, keyType, fields, nil
+}
+
+// Pluck returns a new slice with the values of the given field of all the
+// elements of a slice of structs (or pointers to structs), in order. The
+// returned slice is of the field type, such as a []string for the "Username"
+// field of a []User, so it can be type asserted. Use PluckAs to get a typed
+// slice directly.
+//
+// A nested field can be given by a dot separated path, such as "Address.City".
+// Use WithParallelism to read the elements with concurrent goroutines.
+func Pluck(slice interface{}, fieldName string, opts ...Option) (interface{}, error) {
+	sliceValue, err := getSliceValue(slice)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldType, err := getFieldTypeByPath(sliceValue.Type().Elem(), fieldName)
+	if err != nil {
+		return nil, err
+	}
+
+	length := sliceValue.Len()
+	result := reflect.MakeSlice(reflect.SliceOf(fieldType), length, length)
+	err = parallelFor(newOptions(opts).parallelism, length, func(start, end int) error {
+		for i := start; i < end; i++ {
+			fieldValue, err := getFieldByPath(sliceValue.Index(i), fieldName, false)
+			if err != nil {
+				return err
+			}
+			result.Index(i).Set(fieldValue)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Interface(), nil
+}
+
+// SetValueEach sets the given value to a field of all the elements of a slice
+// of structs (or pointers to structs), such as to stamp a batch of records. A
+// nested field can be given by a dot separated path, such as "Address.City".
+// The value must be of the field type, else ErrMismatchValue is returned, and
+// an array must be passed by pointer, else ErrNotPtr is returned.
+//
+// Use WithParallelism to set the elements with concurrent goroutines. If an
+// element fails (such as with a nil pointer in the path), the error is
+// returned and the other elements may have been set.
+func SetValueEach(slice interface{}, fieldName string, newValue interface{}, opts ...Option) error {
+	sliceValue, err := getSliceValue(slice)
+	if err != nil {
+		return err
+	}
+
+	if sliceValue.Kind() == reflect.Array && !sliceValue.CanAddr() {
+		return ErrNotPtr
+	}
+
+	fieldType, err := getFieldTypeByPath(sliceValue.Type().Elem(), fieldName)
+	if err != nil {
+		return err
+	}
+
+	value := reflect.ValueOf(newValue)
+	if fieldType != reflect.TypeOf(newValue) {
+		return ErrMismatchValue
+	}
+
+	return parallelFor(newOptions(opts).parallelism, sliceValue.Len(), func(start, end int) error {
+		for i := start; i < end; i++ {
+			fieldValue, err := getFieldByPath(sliceValue.Index(i), fieldName, false)
+			if err != nil {
+				return err
+			}
+			fieldValue.Set(value)
+		}
+		return nil
+	})
+}
+
+// PluckAs is the same as Pluck, but returns a slice of the given type
+// parameter, such as PluckAs[string](users, "Username"). The field type must
+// be assignable to T, else ErrMismatchValue is returned.
+func PluckAs[T any](slice interface{}, fieldName string) ([]T, error) {
+	sliceValue, err := getSliceValue(slice)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldType, err := getFieldTypeByPath(sliceValue.Type().Elem(), fieldName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !fieldType.AssignableTo(reflect.TypeOf((*T)(nil)).Elem()) {
+		return nil, ErrMismatchValue
+	}
+
+	fields, err := sliceFields(sliceValue, fieldName)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]T, len(fields))
+	for i, field := range fields {
+		// Set through reflect, as a type assertion panics on nil interfaces.
+		reflect.ValueOf(&result[i]).Elem().Set(field)
+	}
+
+	return result, nil
+}
+
+// Pair is a pair of matching elements returned by JoinBy.
+type Pair struct {
+	Left  interface{}
+	Right interface{}
+}
+
+// JoinBy matches the elements of two slices of structs (or pointers to
+// structs) whose key fields are equal, like an inner join of two database
+// tables. It returns a pair for every matching combination, in the order of
+// the left slice and then of the right slice.
+//
+// Nested key fields can be given by dot separated paths, such as
+// "Address.City". Both the key fields must be of the same type, which must be
+// usable as a map key, else ErrMismatchValue is returned.
+func JoinBy(left, right interface{}, leftField, rightField string) ([]Pair, error) {
+	leftValue, leftType, leftKeys, err := sliceKeys(left, leftField)
+	if err != nil {
+		return nil, err
+	}
+
+	rightValue, rightType, rightKeys, err := sliceKeys(right, rightField)
+	if err != nil {
+		return nil, err
+	}
+
+	if leftType != rightType {
+		return nil, ErrMismatchValue
+	}
+
+	matches := map[interface{}][]int{}
+	for i, key := range rightKeys {
+		matches[key.Interface()] = append(matches[key.Interface()], i)
+	}
+
+	pairs := []Pair{}
+	for i, key := range leftKeys {
+		for _, j := range matches[key.Interface()] {
+			pairs = append(pairs, Pair{leftValue.Index(i).Interface(), rightValue.Index(j).Interface()})
+		}
+	}
+
+	return pairs, nil
+}
@@ -0,0 +1,344 @@
+package attr
+
+import (
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var users = []User{
+	{"srathi", 30, "a"},
+	{"alice", 25, "b"},
+	{"bob", 30, "c"},
+	{"carol", 41, "d"},
+}
+
+func TestSortByField(t *testing.T) {
+	got := append([]User{}, users...)
+	require.Nil(t, SortByField(&got, "Age", false))
+	require.Equal(t, []User{users[1], users[0], users[2], users[3]}, got, "Ascending sort is not correct")
+
+	require.Nil(t, SortByField(got, "Username", true))
+	require.Equal(t, []User{users[0], users[3], users[2], users[1]}, got, "Descending sort is not correct")
+
+	day := 24 * time.Hour
+	now := time.Now()
+	type Event struct {
+		At    time.Time
+		Where *Address
+	}
+	events := []*Event{
+		{now, &Address{City: "b"}},
+		{now.Add(-day), &Address{City: "c"}},
+		{now.Add(day), &Address{City: "a"}},
+	}
+	require.Nil(t, SortByField(events, "At", false))
+	require.Equal(t, []string{"c", "b", "a"}, []string{
+		events[0].Where.City, events[1].Where.City, events[2].Where.City}, "Time sort is not correct")
+
+	require.Nil(t, SortByField(events, "Where.City", false))
+	require.Equal(t, now.Add(day), events[0].At, "Nested field sort is not correct")
+
+	array := [2]User{users[0], users[1]}
+	require.Nil(t, SortByField(&array, "Age", false))
+	require.Equal(t, [2]User{users[1], users[0]}, array, "Array sort is not correct")
+
+	for _, test := range []struct {
+		slice    interface{}
+		attrName string
+		wantErr  error
+	}{
+		{users, "password", ErrUnexportedField},
+		{users, "ABC", ErrNoField},
+		{[]Employee{{}}, "Tags", ErrMismatchValue},
+		{[]int{1}, "Age", ErrNotSlice},
+		{user, "Age", ErrNotSlice},
+		{array, "Age", ErrNotPtr},
+	} {
+		err := SortByField(test.slice, test.attrName, false)
+		require.Equal(t, test.wantErr, err, "Sort error is not correct")
+	}
+}
+
+func ExampleSortByField() {
+	users := []User{
+		{Username: "srathi", Age: 30},
+		{Username: "alice", Age: 25},
+		{Username: "bob", Age: 35},
+	}
+
+	err := SortByField(users, "Age", true)
+	if err != nil {
+		// Handle error.
+	}
+	for _, u := range users {
+		fmt.Printf("%s ", u.Username)
+	}
+	// Output: bob srathi alice
+}
+
+func TestFilter(t *testing.T) {
+	got, err := Filter(users, "Age", func(v interface{}) bool { return v.(int) >= 30 })
+	require.Nil(t, err)
+	require.Equal(t, []User{users[0], users[2], users[3]}, got, "Filtered slice is not correct")
+
+	ptrs := []*User{&users[0], &users[1]}
+	got, err = Filter(&ptrs, "Username", func(v interface{}) bool { return v == "abc" })
+	require.Nil(t, err)
+	require.Equal(t, []*User{}, got, "Filtered slice is not correct")
+
+	got, err = Filter([2]User{users[0], users[1]}, "Age", func(v interface{}) bool { return v == 25 })
+	require.Nil(t, err)
+	require.Equal(t, []User{users[1]}, got, "Filtered array is not correct")
+
+	_, err = Filter(users, "password", func(v interface{}) bool { return true })
+	require.Equal(t, ErrUnexportedField, err, "Able to filter by a private field")
+
+	_, err = Filter([]*User{nil}, "Age", func(v interface{}) bool { return true })
+	require.Equal(t, ErrNilPointer, err, "Able to filter a nil element")
+}
+
+func TestFilterEqual(t *testing.T) {
+	got, err := FilterEqual(users, "Age", 30)
+	require.Nil(t, err)
+	require.Equal(t, []User{users[0], users[2]}, got, "Filtered slice is not correct")
+
+	_, err = FilterEqual(map[string]User{}, "Age", 30)
+	require.Equal(t, ErrNotSlice, err, "Able to filter a map")
+}
+
+func ExampleFilterEqual() {
+	users := []User{
+		{Username: "srathi", Age: 30},
+		{Username: "alice", Age: 25},
+		{Username: "bob", Age: 30},
+	}
+
+	filtered, err := FilterEqual(users, "Age", 30)
+	if err != nil {
+		// Handle error.
+	}
+	for _, u := range filtered.([]User) {
+		fmt.Printf("%s ", u.Username)
+	}
+	// Output: srathi bob
+}
+
+func TestGroupBy(t *testing.T) {
+	got, err := GroupBy(users, "Age")
+	require.Nil(t, err)
+	require.Equal(t, map[int][]User{
+		25: {users[1]},
+		30: {users[0], users[2]},
+		41: {users[3]},
+	}, got, "Groups are not correct")
+
+	got, err = GroupBy([]*User{}, "Username")
+	require.Nil(t, err)
+	require.Equal(t, map[string][]*User{}, got, "Groups of an empty slice are not correct")
+
+	_, err = GroupBy([]Employee{}, "Tags")
+	require.Equal(t, ErrMismatchValue, err, "Able to group by a slice field")
+
+	employees := []Employee{{Extra: "a"}, {Extra: []int{1}}}
+	_, err = GroupBy(employees, "Extra")
+	require.Equal(t, ErrMismatchValue, err, "Able to group by an uncomparable interface value")
+}
+
+func TestIndexBy(t *testing.T) {
+	got, err := IndexBy(users, "Username")
+	require.Nil(t, err)
+	require.Equal(t, map[string]User{
+		"srathi": users[0], "alice": users[1], "bob": users[2], "carol": users[3],
+	}, got, "Index is not correct")
+
+	got, err = IndexBy(&users, "Age")
+	require.Nil(t, err)
+	require.Equal(t, users[2], got.(map[int]User)[30], "Last duplicate is not kept")
+
+	_, err = IndexBy(users, "Address.City")
+	require.Equal(t, ErrNoField, err, "Able to index by a non-existent field")
+
+	_, err = IndexBy([]Employee{{Extra: map[string]int{}}}, "Extra")
+	require.Equal(t, ErrMismatchValue, err, "Able to index by an uncomparable interface value")
+
+	got, err = IndexBy([]Employee{{Name: "a", Extra: 1}, {Name: "b", Extra: "1"}}, "Extra")
+	require.Nil(t, err)
+	require.Equal(t, 2, len(got.(map[interface{}]Employee)), "Index by an interface field is not correct")
+}
+
+func ExampleGroupBy() {
+	users := []User{
+		{Username: "srathi", Age: 30},
+		{Username: "alice", Age: 25},
+		{Username: "bob", Age: 30},
+	}
+
+	groups, err := GroupBy(users, "Age")
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Println(len(groups.(map[int][]User)[30]))
+	// Output: 2
+}
+
+func TestPluck(t *testing.T) {
+	got, err := Pluck(users, "Username")
+	require.Nil(t, err)
+	require.Equal(t, []string{"srathi", "alice", "bob", "carol"}, got, "Plucked values are not correct")
+
+	employees := []*Employee{{Address: &Address{City: "a"}}, {Address: &Address{City: "b"}}}
+	got, err = Pluck(employees, "Address.City")
+	require.Nil(t, err)
+	require.Equal(t, []string{"a", "b"}, got, "Plucked nested values are not correct")
+
+	got, err = Pluck([]User{}, "Age")
+	require.Nil(t, err)
+	require.Equal(t, []int{}, got, "Plucked values of an empty slice are not correct")
+
+	_, err = Pluck(users, "password")
+	require.Equal(t, ErrUnexportedField, err, "Able to pluck a private field")
+}
+
+func TestSetValueEach(t *testing.T) {
+	got := append([]User{}, users...)
+	require.Nil(t, SetValueEach(got, "Age", 50))
+	require.Equal(t, []int{50, 50, 50, 50}, []int{got[0].Age, got[1].Age, got[2].Age, got[3].Age},
+		"Values are not set")
+
+	employees := []*Employee{{Address: &Address{City: "a"}}, {Address: &Address{City: "b"}}}
+	require.Nil(t, SetValueEach(&employees, "Address.City", "c"))
+	require.Equal(t, "c", employees[1].Address.City, "Nested values are not set")
+
+	array := [2]User{users[0], users[1]}
+	require.Equal(t, ErrNotPtr, SetValueEach(array, "Age", 1), "Able to set an array by value")
+	require.Nil(t, SetValueEach(&array, "Age", 1))
+	require.Equal(t, 1, array[1].Age, "Array values are not set")
+
+	require.Equal(t, ErrMismatchValue, SetValueEach(got, "Age", "50"), "Able to set a wrong type")
+	require.Equal(t, ErrUnexportedField, SetValueEach(got, "password", "x"), "Able to set a private field")
+	require.Equal(t, &NilPathError{"Address", 1},
+		SetValueEach([]*Employee{{}}, "Address.City", "c"), "Able to set through a nil pointer")
+}
+
+func TestWithParallelism(t *testing.T) {
+	many := make([]*User, 1000)
+	for i := range many {
+		many[i] = &User{Username: fmt.Sprint(i), Age: i % 50}
+	}
+
+	want, err := Filter(many, "Age", func(v interface{}) bool { return v.(int) < 10 })
+	require.Nil(t, err)
+	got, err := Filter(many, "Age", func(v interface{}) bool { return v.(int) < 10 }, WithParallelism(4))
+	require.Nil(t, err)
+	require.Equal(t, want, got, "Parallel filter is not correct")
+	require.Len(t, got, 200, "Parallel filter is not correct")
+
+	ages, err := Pluck(many, "Age", WithParallelism(3))
+	require.Nil(t, err)
+	require.Equal(t, 999%50, ages.([]int)[999], "Parallel pluck is not correct")
+
+	require.Nil(t, SetValueEach(many, "Age", 7, WithParallelism(8)))
+	for _, u := range many {
+		require.Equal(t, 7, u.Age, "Parallel set is not correct")
+	}
+
+	// The error of the first failing element is returned.
+	many[900], many[100] = nil, nil
+	_, err = Pluck(many, "Age", WithParallelism(4))
+	require.Equal(t, ErrNilPointer, err)
+	_, err = Pluck(users, "Age", WithParallelism(16))
+	require.Nil(t, err)
+}
+
+func TestPluckAs(t *testing.T) {
+	got, err := PluckAs[int](&users, "Age")
+	require.Nil(t, err)
+	require.Equal(t, []int{30, 25, 30, 41}, got, "Plucked values are not correct")
+
+	anys, err := PluckAs[interface{}](users, "Age")
+	require.Nil(t, err)
+	require.Equal(t, []interface{}{30, 25, 30, 41}, anys, "Plucked values are not correct")
+
+	_, err = PluckAs[string](users, "Age")
+	require.Equal(t, ErrMismatchValue, err, "Able to pluck an int field as a string")
+
+	type Result struct {
+		Err   error
+		Value interface{}
+	}
+	results := []Result{{Err: io.EOF, Value: 1}, {}}
+	errs, err := PluckAs[error](results, "Err")
+	require.Nil(t, err)
+	require.Equal(t, []error{io.EOF, nil}, errs, "Plucked nil interfaces are not correct")
+	values, err := PluckAs[any](results, "Value")
+	require.Nil(t, err)
+	require.Equal(t, []any{1, nil}, values, "Plucked nil interfaces are not correct")
+}
+
+func ExamplePluck() {
+	users := []User{
+		{Username: "srathi", Age: 30},
+		{Username: "alice", Age: 25},
+	}
+
+	names, err := Pluck(users, "Username")
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Println(names.([]string))
+
+	ages, err := PluckAs[int](users, "Age")
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Println(ages)
+	// Output:
+	// [srathi alice]
+	// [30 25]
+}
+
+func TestJoinBy(t *testing.T) {
+	type Login struct {
+		User string
+		Host string
+	}
+	logins := []Login{{"bob", "a"}, {"srathi", "b"}, {"dave", "c"}, {"srathi", "d"}}
+
+	got, err := JoinBy(users, logins, "Username", "User")
+	require.Nil(t, err)
+	require.Equal(t, []Pair{
+		{users[0], logins[1]},
+		{users[0], logins[3]},
+		{users[2], logins[0]},
+	}, got, "Joined pairs are not correct")
+
+	_, err = JoinBy(users, logins, "Age", "User")
+	require.Equal(t, ErrMismatchValue, err, "Able to join keys of different types")
+
+	_, err = JoinBy(users, 10, "Username", "User")
+	require.Equal(t, ErrNotSlice, err, "Able to join with a non-slice")
+}
+
+func ExampleJoinBy() {
+	type Order struct {
+		Buyer string
+		Item  string
+	}
+	users := []User{{Username: "srathi", Age: 30}, {Username: "alice", Age: 25}}
+	orders := []Order{{"alice", "pen"}, {"alice", "book"}}
+
+	pairs, err := JoinBy(users, orders, "Username", "Buyer")
+	if err != nil {
+		// Handle error.
+	}
+	for _, pair := range pairs {
+		fmt.Printf("%s bought %s\n", pair.Left.(User).Username, pair.Right.(Order).Item)
+	}
+	// Output:
+	// alice bought pen
+	// alice bought book
+}
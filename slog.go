@@ -0,0 +1,79 @@
+package attr
+
+import (
+	"log/slog"
+	"reflect"
+)
+
+// SlogValue returns the exported (public) fields of a struct as a log/slog
+// group value, so that any struct can be logged with consistent and safe
+// formatting without implementing slog.LogValuer on its type, such as:
+//
+//	logger.Info("login", "event", attr.SlogValue(event))
+//
+// The "redact" tags are applied like in LogFields, and the nested structs (or
+// non-nil pointers to structs) are added as nested groups. The slices, arrays,
+// maps and interfaces holding structs are added as []interface{} and
+// map[string]interface{} values with the "redact" tags of those structs
+// applied. A struct which
+// references itself through pointers is added as its type name at the second
+// reference. If 'obj' is not a struct, it is returned as slog.AnyValue(obj).
+func SlogValue(obj interface{}) slog.Value {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return slog.AnyValue(obj)
+	}
+
+	t := newTracker(newOptions(nil))
+	t.enter(valueOf(obj))
+	return slogGroup(objValue, t)
+}
+
+// slogGroup returns a group value of the loggable fields of a struct.
+func slogGroup(objValue reflect.Value, t *tracker) slog.Value {
+	objType := objValue.Type()
+	attrs := []slog.Attr{}
+	for i := 0; i < objValue.NumField(); i++ {
+		fieldType := objType.Field(i)
+		fieldValue := objValue.Field(i)
+		if !fieldValue.CanInterface() {
+			continue
+		}
+
+		switch fieldType.Tag.Get("redact") {
+		case "true":
+			attrs = append(attrs, slog.String(fieldType.Name, RedactedValue))
+			continue
+		case "omit":
+			continue
+		}
+
+		ptrValue := fieldValue
+		if fieldValue.Kind() == reflect.Ptr {
+			if fieldValue.IsNil() {
+				attrs = append(attrs, slog.Any(fieldType.Name, nil))
+				continue
+			}
+			fieldValue = fieldValue.Elem()
+		}
+
+		if isLogStruct(fieldValue) {
+			if err := t.enter(ptrValue); err != nil {
+				attrs = append(attrs, slog.String(fieldType.Name, ptrValue.Type().String()))
+				continue
+			}
+			attrs = append(attrs, slog.Attr{Key: fieldType.Name, Value: slogGroup(fieldValue, t)})
+			t.leave(ptrValue)
+			continue
+		}
+
+		if value, ok := redactValue(ptrValue, t); ok {
+			attrs = append(attrs, slog.Any(fieldType.Name, value))
+			continue
+		}
+		if value, ok := logValue(fieldValue); ok {
+			attrs = append(attrs, slog.Any(fieldType.Name, value))
+		}
+	}
+	return slog.GroupValue(attrs...)
+}
@@ -0,0 +1,83 @@
+package attr
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlogValue(t *testing.T) {
+	event := LoginEvent{User: "srathi", Password: "secret", Token: "abc", Client: &Address{City: "San Jose"}}
+	event.Session.Key = "k"
+
+	var out bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&out, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	logger.Info("login", "event", SlogValue(&event))
+	got := out.String()
+	for _, want := range []string{
+		"event.User=srathi",
+		"event.Password=[REDACTED]",
+		"event.Client.City=\"San Jose\"",
+		"event.Session.Key=[REDACTED]",
+		"event.Session.Owner.City=\"\"",
+		"event.Retry=<nil>",
+	} {
+		require.Contains(t, got, want, "Logged value is not correct")
+	}
+	require.False(t, strings.Contains(got, "Token"), "Omitted field is logged")
+	require.False(t, strings.Contains(got, "secret"), "Redacted value is logged")
+
+	type Creds struct {
+		User     string
+		Password string `redact:"true"`
+	}
+	type Config struct {
+		Backups []Creds
+		ByName  map[string]Creds
+	}
+	out.Reset()
+	logger.Info("config", "c", SlogValue(Config{
+		Backups: []Creds{{User: "b", Password: "s3cret"}},
+		ByName:  map[string]Creds{"x": {User: "m", Password: "mapsecret"}},
+	}))
+	got = out.String()
+	require.Contains(t, got, `c.Backups="[map[Password:[REDACTED] User:b]]"`, "Slice of structs is not redacted")
+	require.Contains(t, got, `c.ByName="map[x:map[Password:[REDACTED] User:m]]"`, "Map of structs is not redacted")
+	require.False(t, strings.Contains(got, "secret"), "Redacted value is logged")
+
+	chain := &Chain{Name: "a"}
+	chain.Next = chain
+	value := SlogValue(chain)
+	require.Equal(t, "[Name=a Next=*attr.Chain]", value.String(), "Self-referencing struct is not correct")
+
+	require.Equal(t, slog.IntValue(10).Any(), SlogValue(10).Any(), "Non-struct value is not correct")
+}
+
+func ExampleSlogValue() {
+	type Login struct {
+		User     string
+		Password string `redact:"true"`
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	logger.Info("login", "event", SlogValue(Login{"srathi", "secret"}))
+	// Output: level=INFO msg=login event.User=srathi event.Password=[REDACTED]
+}
@@ -0,0 +1,368 @@
+package attr
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Reflect types of the database/sql interfaces of the custom column types.
+var (
+	scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	valuerType  = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+)
+
+// isScanner returns true if a pointer to the given type implements
+// sql.Scanner, so that a value of any type can be scanned into it.
+func isScanner(typ reflect.Type) bool {
+	return reflect.PtrTo(typ).Implements(scannerType)
+}
+
+// scanValue converts a value to a type implementing sql.Scanner (such as
+// sql.NullString or a decimal type) with its Scan method. The error returned
+// by Scan is returned as is.
+func scanValue(value interface{}, typ reflect.Type) (reflect.Value, error) {
+	scanned := reflect.New(typ)
+	if err := scanned.Interface().(sql.Scanner).Scan(value); err != nil {
+		return reflect.Value{}, err
+	}
+	return scanned.Elem(), nil
+}
+
+// nullValue returns the value held by a field of a database/sql null type
+// (such as sql.NullString or sql.Null[T]), or nil if its Valid field is false.
+// It returns false if the field is not of such a type.
+func nullValue(fieldValue reflect.Value) (interface{}, bool) {
+	typ := fieldValue.Type()
+	if typ.Kind() != reflect.Struct || typ.PkgPath() != scannerType.PkgPath() ||
+		typ.NumField() != 2 || typ.Field(1).Name != "Valid" {
+		return nil, false
+	}
+
+	if !fieldValue.Field(1).Bool() {
+		return nil, true
+	}
+	return fieldValue.Field(0).Interface(), true
+}
+
+// driverValue returns the value of a database column for a SQL statement. If
+// the field type implements driver.Valuer, the value returned by its Value
+// method is used, and a nil pointer is returned as nil. Errors of the Value
+// method are returned in a *FieldError.
+func driverValue(column dbColumn) (interface{}, error) {
+	if !column.value.Type().Implements(valuerType) {
+		return column.value.Interface(), nil
+	}
+
+	if column.value.Kind() == reflect.Ptr && column.value.IsNil() {
+		return nil, nil
+	}
+
+	value, err := column.value.Interface().(driver.Valuer).Value()
+	if err != nil {
+		return nil, &FieldError{column.Field.Name, err}
+	}
+	return value, nil
+}
+
+// Column is an exported (public) struct field mapped to a database column, as
+// returned by Columns.
+type Column struct {
+	// Name is the name of the column.
+	Name string
+	// Field is the struct field. Its Index is the index sequence of the field
+	// for reflect.Value.FieldByIndex, including the indexes of the embedded
+	// structs it is promoted from.
+	Field reflect.StructField
+	// OmitEmpty is true if the field is tagged with ",omitempty".
+	OmitEmpty bool
+}
+
+// Columns returns the database columns of all the exported (public) fields of
+// a struct, in the order of its fields, as used by InsertSQL and NamedArgs.
+// Column names are taken from the 'tagKey' tags (such as "db"), or are the
+// lower case field names if there is no tag. Fields tagged with "-" are
+// skipped, and the fields of the embedded structs without the tag (exported
+// or not) are promoted to the parent struct. The columns of a struct type are
+// computed once and cached.
+func Columns(obj interface{}, tagKey string) ([]Column, error) {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]Column(nil), typeColumns(objValue.Type(), tagKey, nil)...), nil
+}
+
+// typeColumns returns the database columns of a struct type, with their
+// names converted with the naming strategy (lower case if nil). The columns
+// without a naming strategy are cached per tag key.
+func typeColumns(objType reflect.Type, tagKey string, naming KeyNaming) []Column {
+	if naming != nil {
+		return collectColumns(objType, tagKey, naming, nil)
+	}
+
+	fields := cachedFields(objType)
+	if cached, found := fields.columns.Load(tagKey); found {
+		return cached.([]Column)
+	}
+	cached, _ := fields.columns.LoadOrStore(tagKey, collectColumns(objType, tagKey, nil, nil))
+	return cached.([]Column)
+}
+
+// collectColumns returns the database columns of a struct type. 'index' is the
+// index sequence of an embedded struct whose fields are promoted.
+func collectColumns(objType reflect.Type, tagKey string, naming KeyNaming, index []int) []Column {
+	columns := []Column{}
+	for i := 0; i < objType.NumField(); i++ {
+		field := objType.Field(i)
+		tag, hasTag := field.Tag.Lookup(tagKey)
+		if tag == "-" {
+			continue
+		}
+
+		field.Index = append(append([]int{}, index...), i)
+		if field.Anonymous && !hasTag && field.Type.Kind() == reflect.Struct {
+			columns = append(columns, collectColumns(field.Type, tagKey, naming, field.Index)...)
+			continue
+		}
+
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name := tagName(tag)
+		if name == "" {
+			name = keyName(field.Name, naming, strings.ToLower)
+		}
+		columns = append(columns, Column{
+			Name:      name,
+			Field:     field,
+			OmitEmpty: strings.Contains(tag, ",omitempty"),
+		})
+	}
+	return columns
+}
+
+// dbColumn is a database column along with the value of its field.
+type dbColumn struct {
+	Column
+	value reflect.Value
+}
+
+// dbColumns returns the database columns of all the exported fields of a
+// struct along with their values, like Columns, with the column names
+// converted with the naming strategy (lower case if nil).
+func dbColumns(objValue reflect.Value, tagKey string, naming KeyNaming) []dbColumn {
+	columns := []dbColumn{}
+	for _, column := range typeColumns(objValue.Type(), tagKey, naming) {
+		columns = append(columns, dbColumn{column, objValue.FieldByIndex(column.Field.Index)})
+	}
+	return columns
+}
+
+// sqlParams generates the parameter placeholders of a SQL statement.
+type sqlParams struct {
+	dollar bool
+	count  int
+}
+
+// next returns the placeholder of the next parameter.
+func (p *sqlParams) next() string {
+	p.count++
+	if p.dollar {
+		return fmt.Sprintf("$%d", p.count)
+	}
+	return "?"
+}
+
+// InsertSQL returns a parameterized INSERT statement for the given struct and
+// table, along with the values of its parameters in order. Column names are
+// taken from the "db" tags of the exported (public) fields, or are the lower
+// case field names if there is no tag. Fields tagged with `db:"-"` are
+// skipped, and so are the zero valued fields tagged with ",omitempty".
+//
+// Parameters are written as "?", or as "$1", "$2", etc. with the
+// WithDollarParams option. The values of the fields implementing
+// driver.Valuer are taken from their Value method, and its error is returned
+// in a *FieldError.
+func InsertSQL(obj interface{}, table string, opts ...Option) (string, []interface{}, error) {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return "", nil, err
+	}
+
+	o := newOptions(opts)
+	params := &sqlParams{dollar: o.dollarParams}
+	names := []string{}
+	placeholders := []string{}
+	args := []interface{}{}
+	for _, column := range dbColumns(objValue, "db", o.keyNaming) {
+		if column.OmitEmpty && column.value.IsZero() {
+			continue
+		}
+		arg, err := driverValue(column)
+		if err != nil {
+			return "", nil, err
+		}
+		names = append(names, column.Name)
+		placeholders = append(placeholders, params.next())
+		args = append(args, arg)
+	}
+
+	if len(names) == 0 {
+		return "", nil, ErrNoValues
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table,
+		strings.Join(names, ", "), strings.Join(placeholders, ", "))
+	return query, args, nil
+}
+
+// UpdateSQL returns a parameterized UPDATE statement for the given struct and
+// table, along with the values of its parameters in order. The given key
+// fields (by their field names) are used in the WHERE clause, and all the other
+// exported (public) fields with a non-zero value are updated. Column names and
+// values are derived like InsertSQL.
+//
+// At least one key field is required, else ErrNoField is returned. ErrNoValues
+// is returned if there is no field to update.
+func UpdateSQL(obj interface{}, table string, keyFields []string, opts ...Option) (string, []interface{}, error) {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if len(keyFields) == 0 {
+		return "", nil, ErrNoField
+	}
+
+	o := newOptions(opts)
+	columns := dbColumns(objValue, "db", o.keyNaming)
+	keyColumns := []dbColumn{}
+	for _, keyField := range keyFields {
+		column, err := findColumn(objValue, columns, keyField)
+		if err != nil {
+			return "", nil, err
+		}
+		keyColumns = append(keyColumns, column)
+	}
+
+	params := &sqlParams{dollar: o.dollarParams}
+	sets := []string{}
+	args := []interface{}{}
+	for _, column := range columns {
+		if column.value.IsZero() || containsString(keyFields, column.Field.Name) {
+			continue
+		}
+		arg, err := driverValue(column)
+		if err != nil {
+			return "", nil, err
+		}
+		sets = append(sets, column.Name+" = "+params.next())
+		args = append(args, arg)
+	}
+
+	if len(sets) == 0 {
+		return "", nil, ErrNoValues
+	}
+
+	conditions := []string{}
+	for _, column := range keyColumns {
+		arg, err := driverValue(column)
+		if err != nil {
+			return "", nil, err
+		}
+		conditions = append(conditions, column.Name+" = "+params.next())
+		args = append(args, arg)
+	}
+
+	query := fmt.Sprintf("UPDATE %s SET %s WHERE %s", table,
+		strings.Join(sets, ", "), strings.Join(conditions, " AND "))
+	return query, args, nil
+}
+
+// findColumn returns the database column of the given field name.
+func findColumn(objValue reflect.Value, columns []dbColumn, fieldName string) (dbColumn, error) {
+	for _, column := range columns {
+		if column.Field.Name == fieldName {
+			return column, nil
+		}
+	}
+
+	field, found := cachedField(objValue.Type(), fieldName)
+	if found && field.PkgPath != "" {
+		return dbColumn{}, ErrUnexportedField
+	}
+	return dbColumn{}, ErrNoField
+}
+
+// containsString returns true if the given string is in the slice.
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// NamedArgs returns a map of the column names to the values of all the exported
+// (public) fields of a struct, for the queries with named parameters (such as
+// the ones of sqlx). Column names are taken from the 'tagKey' tags (such as
+// "db"), or are the lower case field names if there is no tag. Fields tagged
+// with "-" are skipped. The values of the fields implementing driver.Valuer
+// are taken from their Value method.
+func NamedArgs(obj interface{}, tagKey string) (map[string]interface{}, error) {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	args := map[string]interface{}{}
+	for _, column := range dbColumns(objValue, tagKey, nil) {
+		arg, err := driverValue(column)
+		if err != nil {
+			return nil, err
+		}
+		args[column.Name] = arg
+	}
+
+	return args, nil
+}
+
+// OrderedArgs returns the values of the fields of a struct for the given column
+// names, in the same order, for the queries with positional parameters. Column
+// names are matched with the "db" tags of the exported (public) fields, or
+// with the lower case field names if there is no tag. The values of the
+// fields implementing driver.Valuer are taken from their Value method.
+//
+// ErrNoField is returned if a column doesn't match any field.
+func OrderedArgs(obj interface{}, columns []string) ([]interface{}, error) {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]interface{}{}
+	for _, column := range dbColumns(objValue, "db", nil) {
+		value, err := driverValue(column)
+		if err != nil {
+			return nil, err
+		}
+		values[column.Name] = value
+	}
+
+	args := make([]interface{}, 0, len(columns))
+	for _, column := range columns {
+		value, found := values[column]
+		if !found {
+			return nil, ErrNoField
+		}
+		args = append(args, value)
+	}
+
+	return args, nil
+}
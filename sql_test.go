@@ -0,0 +1,302 @@
+package attr
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Base struct {
+	ID int64 `db:"id"`
+}
+
+type Account struct {
+	Base
+	Name     string  `db:"name"`
+	Email    string  `db:"email,omitempty"`
+	Balance  float64 `db:"balance"`
+	Internal string  `db:"-"`
+	Active   bool
+	token    string
+}
+
+func TestInsertSQL(t *testing.T) {
+	account := Account{Base: Base{ID: 7}, Name: "srathi", Balance: 10.5}
+	query, args, err := InsertSQL(&account, "accounts")
+	require.Nil(t, err)
+	require.Equal(t, "INSERT INTO accounts (id, name, balance, active) VALUES (?, ?, ?, ?)", query,
+		"Insert statement is not correct")
+	require.Equal(t, []interface{}{int64(7), "srathi", 10.5, false}, args, "Insert arguments are not correct")
+
+	account.Email = "srathi@example.com"
+	query, args, err = InsertSQL(account, "accounts", WithDollarParams())
+	require.Nil(t, err)
+	require.Equal(t, "INSERT INTO accounts (id, name, email, balance, active) VALUES ($1, $2, $3, $4, $5)",
+		query, "Insert statement is not correct")
+	require.Equal(t, 5, len(args), "Insert arguments are not correct")
+
+	type Empty struct {
+		Skipped string `db:"-"`
+	}
+	_, _, err = InsertSQL(Empty{}, "empty")
+	require.Equal(t, ErrNoValues, err, "Able to insert without any column")
+}
+
+func TestUpdateSQL(t *testing.T) {
+	account := Account{Base: Base{ID: 7}, Name: "srathi", Active: true}
+	query, args, err := UpdateSQL(&account, "accounts", []string{"ID"}, WithDollarParams())
+	require.Nil(t, err)
+	require.Equal(t, "UPDATE accounts SET name = $1, active = $2 WHERE id = $3", query,
+		"Update statement is not correct")
+	require.Equal(t, []interface{}{"srathi", true, int64(7)}, args, "Update arguments are not correct")
+
+	for _, test := range []struct {
+		keyFields []string
+		wantErr   error
+		errMsg    string
+	}{
+		{[]string{}, ErrNoField, "Able to update without a key"},
+		{[]string{"ABC"}, ErrNoField, "Able to update with a non-existent key"},
+		{[]string{"token"}, ErrUnexportedField, "Able to update with a private key"},
+		{[]string{"ID", "Name", "Active"}, ErrNoValues, "Able to update without any column"},
+	} {
+		_, _, err := UpdateSQL(&account, "accounts", test.keyFields)
+		require.Equal(t, test.wantErr, err, test.errMsg)
+	}
+}
+
+func ExampleInsertSQL() {
+	// type User struct {
+	// 	Username string `json:"username" db:"uname"`
+	// 	Age      int    `json:"age" meta:"important"`
+	// 	password string
+	// }
+	testUser := User{Username: "srathi", password: "secret", Age: 30}
+
+	query, args, err := InsertSQL(&testUser, "users")
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Println(query)
+	fmt.Println(args)
+	// Output:
+	// INSERT INTO users (uname, age) VALUES (?, ?)
+	// [srathi 30]
+}
+
+func TestNamedArgs(t *testing.T) {
+	account := Account{Base: Base{ID: 7}, Name: "srathi", Balance: 10.5}
+	want := map[string]interface{}{
+		"id": int64(7), "name": "srathi", "email": "", "balance": 10.5, "active": false}
+	got, err := NamedArgs(&account, "db")
+	require.Nil(t, err)
+	require.Equal(t, want, got, "Named arguments are not correct")
+
+	got, err = NamedArgs(user, "json")
+	require.Nil(t, err)
+	require.Equal(t, map[string]interface{}{"username": "srathi", "age": 30}, got,
+		"Named arguments by json tag are not correct")
+}
+
+func TestOrderedArgs(t *testing.T) {
+	account := Account{Base: Base{ID: 7}, Name: "srathi", Balance: 10.5}
+	got, err := OrderedArgs(&account, []string{"balance", "id", "active"})
+	require.Nil(t, err)
+	require.Equal(t, []interface{}{10.5, int64(7), false}, got, "Ordered arguments are not correct")
+
+	_, err = OrderedArgs(&account, []string{"id", "internal"})
+	require.Equal(t, ErrNoField, err, "Able to get the argument of an unknown column")
+}
+
+func TestColumns(t *testing.T) {
+	got, err := Columns(Account{}, "db")
+	require.Nil(t, err)
+	names := []string{}
+	for _, column := range got {
+		names = append(names, column.Name)
+	}
+	require.Equal(t, []string{"id", "name", "email", "balance", "active"}, names, "Columns are not correct")
+	require.Equal(t, []int{0, 0}, got[0].Field.Index, "Index of a promoted field is not correct")
+	require.True(t, got[2].OmitEmpty, "Omitempty column is not correct")
+
+	type audit struct {
+		CreatedBy string
+	}
+	type Note struct {
+		audit
+		Text string
+	}
+	got, err = Columns(&Note{}, "db")
+	require.Nil(t, err)
+	require.Equal(t, 2, len(got), "Columns of an unexported embedded struct are not correct")
+	require.Equal(t, "createdby", got[0].Name, "Columns of an unexported embedded struct are not correct")
+
+	_, err = Columns(1, "db")
+	require.Equal(t, ErrNotStruct, err, "Able to get the columns of a non-struct")
+}
+
+func ExampleColumns() {
+	testUser := User{Username: "srathi", password: "secret", Age: 30}
+
+	columns, err := Columns(testUser, "db")
+	if err != nil {
+		// Handle error.
+	}
+	for _, column := range columns {
+		fmt.Println(column.Name, column.Field.Name)
+	}
+	// Output:
+	// uname Username
+	// age Age
+}
+
+func ExampleNamedArgs() {
+	testUser := User{Username: "srathi", password: "secret", Age: 30}
+
+	args, err := NamedArgs(&testUser, "db")
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Println(args)
+	// Output: map[age:30 uname:srathi]
+}
+
+// Money is an amount in cents, stored as a decimal string in the database.
+type Money int64
+
+func (m *Money) Scan(src interface{}) error {
+	var text string
+	switch src := src.(type) {
+	case string:
+		text = src
+	case []byte:
+		text = string(src)
+	case int64:
+		*m = Money(src * 100)
+		return nil
+	case nil:
+		*m = 0
+		return nil
+	default:
+		return fmt.Errorf("can't scan %T into Money", src)
+	}
+
+	var units, cents int64
+	if _, err := fmt.Sscanf(text, "%d.%02d", &units, &cents); err != nil {
+		return err
+	}
+	*m = Money(units*100 + cents)
+	return nil
+}
+
+func (m Money) Value() (driver.Value, error) {
+	if m < 0 {
+		return nil, errors.New("negative amount")
+	}
+	return fmt.Sprintf("%d.%02d", m/100, m%100), nil
+}
+
+type Invoice struct {
+	ID     int64  `db:"id"`
+	Amount Money  `db:"amount"`
+	Tax    *Money `db:"tax"`
+}
+
+func TestScannerValuer(t *testing.T) {
+	var invoice Invoice
+	require.Nil(t, SetValue(&invoice, "Amount", "12.34"))
+	require.Equal(t, Money(1234), invoice.Amount, "Field not set through Scan")
+	require.Nil(t, SetValue(&invoice, "Amount", Money(5)))
+	require.Equal(t, Money(5), invoice.Amount, "Field of the same type not set")
+
+	err := SetValue(&invoice, "Amount", 1.5)
+	require.EqualError(t, err, "can't scan float64 into Money", "Scan error not returned")
+	require.Equal(t, Money(5), invoice.Amount, "Field changed on a scan error")
+	require.Equal(t, ErrMismatchValue, SetValue(&invoice, "ID", "7"), "Able to set a non-scanner field")
+
+	require.Nil(t, FromMap(&invoice, map[string]interface{}{"amount": []byte("3.50")}, "db"))
+	require.Equal(t, Money(350), invoice.Amount, "Field not set through Scan by FromMap")
+	err = FromMap(&invoice, map[string]interface{}{"amount": "abc"}, "db")
+	require.Equal(t, &FieldError{"Amount", ErrInvalidValue}, err, "Scan error not reported by FromMap")
+
+	invoice = Invoice{ID: 7, Amount: 1234}
+	args, err := NamedArgs(&invoice, "db")
+	require.Nil(t, err)
+	require.Equal(t, map[string]interface{}{"id": int64(7), "amount": "12.34", "tax": nil}, args,
+		"Named arguments are not taken from Value")
+
+	tax := Money(99)
+	invoice.Tax = &tax
+	_, insertArgs, err := InsertSQL(invoice, "invoices")
+	require.Nil(t, err)
+	require.Equal(t, []interface{}{int64(7), "12.34", "0.99"}, insertArgs, "Insert arguments are not taken from Value")
+
+	invoice.Amount = -1
+	_, _, err = InsertSQL(invoice, "invoices")
+	require.Equal(t, &FieldError{"Amount", errors.New("negative amount")}, err, "Value error not reported")
+}
+
+type Subscriber struct {
+	Name  sql.NullString
+	Age   sql.NullInt64
+	Seen  sql.NullTime
+	Score sql.Null[float64]
+}
+
+func TestNullValues(t *testing.T) {
+	seen := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	var subscriber Subscriber
+	require.Nil(t, SetValue(&subscriber, "Name", "srathi"))
+	require.Nil(t, SetValue(&subscriber, "Age", 30))
+	require.Nil(t, SetValue(&subscriber, "Seen", seen))
+	require.Nil(t, SetValue(&subscriber, "Score", 9.5))
+	require.Equal(t, Subscriber{
+		Name:  sql.NullString{String: "srathi", Valid: true},
+		Age:   sql.NullInt64{Int64: 30, Valid: true},
+		Seen:  sql.NullTime{Time: seen, Valid: true},
+		Score: sql.Null[float64]{V: 9.5, Valid: true},
+	}, subscriber, "Null fields not set from their values")
+
+	got, err := GetValue(&subscriber, "Age", WithNullValues())
+	require.Nil(t, err)
+	require.Equal(t, int64(30), got, "Value of a valid null field is not correct")
+
+	require.Nil(t, SetValue(&subscriber, "Name", nil))
+	require.Equal(t, sql.NullString{}, subscriber.Name, "Null field not set to nil")
+
+	got, err = GetValue(&subscriber, "Name", WithNullValues())
+	require.Nil(t, err)
+	require.Nil(t, got, "Value of an invalid null field is not nil")
+
+	got, err = GetValue(&subscriber, "Age")
+	require.Nil(t, err)
+	require.Equal(t, sql.NullInt64{Int64: 30, Valid: true}, got, "Null field bridged without the option")
+
+	values, err := Values(subscriber, WithNullValues())
+	require.Nil(t, err)
+	require.Equal(t, map[string]interface{}{"Name": nil, "Age": int64(30), "Seen": seen, "Score": 9.5}, values,
+		"Values of the null fields are not correct")
+}
+
+func ExampleWithNullValues() {
+	type Customer struct {
+		Name  string
+		Email sql.NullString
+	}
+	var customer Customer
+
+	if err := SetValue(&customer, "Email", "srathi@example.com"); err != nil {
+		// Handle error.
+	}
+	email, err := GetValue(customer, "Email", WithNullValues())
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Println(customer.Email.Valid, email)
+	// Output: true srathi@example.com
+}
@@ -0,0 +1,80 @@
+package attr
+
+import "reflect"
+
+// StructStats holds the statistics of a struct type, as returned by Stats. It
+// is tagged to be serialized with lower case keys, like StructInfo.
+type StructStats struct {
+	// Fields is the number of fields of the struct, both exported and
+	// unexported, with an embedded struct counted as a single field.
+	Fields     int `json:"fields" yaml:"fields"`
+	Exported   int `json:"exported" yaml:"exported"`
+	Unexported int `json:"unexported" yaml:"unexported"`
+	// Kinds counts the fields by kind, such as "string" or "struct".
+	Kinds map[string]int `json:"kinds" yaml:"kinds"`
+	// MaxDepth is the number of levels of the deepest nested struct, where 1
+	// means that no field refers to a struct.
+	MaxDepth int `json:"maxDepth" yaml:"maxDepth"`
+	// TagKeys counts the fields by the keys of their tags, such as "json",
+	// and Tags is the total number of the tag keys of all the fields.
+	TagKeys map[string]int `json:"tagKeys" yaml:"tagKeys"`
+	Tags    int            `json:"tags" yaml:"tags"`
+}
+
+// Stats returns the statistics of a struct, such as for auditing the models of
+// a codebase or for tests enforcing a complexity budget on them: the number of
+// its fields by export status and by kind, the number of its tag keys and the
+// depth of its nested structs. The counts are of the fields of the struct
+// itself, including the unexported ones, while the depth follows the struct
+// types of the fields through the pointers, slices, arrays and maps. time.Time
+// fields are not counted as nested structs, and a recursive type (such as a
+// linked list) is followed only once.
+//
+// 'obj' can be passed by value or by pointer.
+func Stats(obj interface{}) (StructStats, error) {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return StructStats{}, err
+	}
+
+	objType := objValue.Type()
+	stats := StructStats{
+		Kinds:    map[string]int{},
+		TagKeys:  map[string]int{},
+		MaxDepth: structDepth(objType, map[reflect.Type]bool{}),
+	}
+	for i := 0; i < objType.NumField(); i++ {
+		fieldType := objType.Field(i)
+		stats.Fields++
+		if fieldType.PkgPath == "" {
+			stats.Exported++
+		} else {
+			stats.Unexported++
+		}
+		stats.Kinds[fieldType.Type.Kind().String()]++
+
+		tags, _ := ParseTag(fieldType.Tag)
+		for key := range tags {
+			stats.TagKeys[key]++
+			stats.Tags++
+		}
+	}
+	return stats, nil
+}
+
+// structDepth returns the number of levels of nested structs of a struct type.
+// 'inProgress' holds the types of the enclosing structs, to stop on recursive
+// types.
+func structDepth(objType reflect.Type, inProgress map[reflect.Type]bool) int {
+	inProgress[objType] = true
+	defer delete(inProgress, objType)
+
+	depth := 0
+	for i := 0; i < objType.NumField(); i++ {
+		target, _ := graphTarget(objType.Field(i).Type)
+		if target != nil && !inProgress[target] {
+			depth = max(depth, structDepth(target, inProgress))
+		}
+	}
+	return depth + 1
+}
@@ -0,0 +1,44 @@
+package attr
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStats(t *testing.T) {
+	got, err := Stats(&Employee{})
+	require.Nil(t, err)
+	require.Equal(t, StructStats{
+		Fields:     8,
+		Exported:   7,
+		Unexported: 1,
+		Kinds:      map[string]int{"string": 3, "slice": 1, "ptr": 2, "map": 1, "interface": 1},
+		MaxDepth:   2,
+		TagKeys:    map[string]int{"json": 7, "required": 1},
+		Tags:       8,
+	}, got, "Stats are not correct")
+
+	got, err = Stats(Node{})
+	require.Nil(t, err)
+	require.Equal(t, 1, got.MaxDepth, "Depth of a recursive type is not correct")
+
+	got, err = Stats(Manager{})
+	require.Nil(t, err)
+	require.Equal(t, 2, got.MaxDepth, "Depth with embedded struct is not correct")
+	require.Equal(t, map[string]int{"struct": 1, "slice": 1}, got.Kinds, "Kinds are not correct")
+
+	_, err = Stats(42)
+	require.Equal(t, ErrNotStruct, err)
+}
+
+func ExampleStats() {
+	stats, err := Stats(User{})
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Printf("fields: %d, exported: %d, depth: %d, json tags: %d\n",
+		stats.Fields, stats.Exported, stats.MaxDepth, stats.TagKeys["json"])
+	// Output: fields: 3, exported: 2, depth: 1, json tags: 2
+}
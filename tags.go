@@ -0,0 +1,312 @@
+package attr
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// TagProblem identifies the type of a problem reported by CheckTags.
+type TagProblem int
+
+// Tag problems reported by CheckTags.
+const (
+	// TagDuplicate means that two or more fields use the same tag value.
+	TagDuplicate TagProblem = iota + 1
+	// TagMalformed means that the raw struct tag does not follow the
+	// conventional `key:"value" key2:"value2"` format.
+	TagMalformed
+	// TagMissing means that an exported field does not have the tag key.
+	TagMissing
+)
+
+// String returns a short name of the tag problem.
+func (p TagProblem) String() string {
+	switch p {
+	case TagDuplicate:
+		return "duplicate"
+	case TagMalformed:
+		return "malformed"
+	case TagMissing:
+		return "missing"
+	}
+	return "unknown"
+}
+
+// TagIssue describes a single problem found by CheckTags in a struct field.
+type TagIssue struct {
+	Field   string
+	Problem TagProblem
+	Message string
+}
+
+// String returns a human readable description of the issue.
+func (i TagIssue) String() string {
+	return fmt.Sprintf("%s: %s: %s", i.Field, i.Problem, i.Message)
+}
+
+// tagPair is a single key-value pair of a struct tag.
+type tagPair struct {
+	key   string
+	value string
+}
+
+// parseTag splits a raw struct tag into its key-value pairs, in the order they
+// appear in the tag. It follows the same rules as reflect.StructTag.Lookup, but
+// returns an error instead of silently stopping at the first malformed pair.
+func parseTag(tag reflect.StructTag) ([]tagPair, error) {
+	pairs := []tagPair{}
+	raw := string(tag)
+	for raw != "" {
+		// Skip leading space.
+		i := 0
+		for i < len(raw) && raw[i] == ' ' {
+			i++
+		}
+		raw = raw[i:]
+		if raw == "" {
+			break
+		}
+
+		// Scan to colon. A space, a quote or a control character is a syntax error.
+		i = 0
+		for i < len(raw) && raw[i] > ' ' && raw[i] != ':' && raw[i] != '"' && raw[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(raw) || raw[i] != ':' || raw[i+1] != '"' {
+			return pairs, fmt.Errorf("bad syntax for struct tag pair near %q", raw)
+		}
+		key := raw[:i]
+		raw = raw[i+1:]
+
+		// Scan quoted string to find value.
+		i = 1
+		for i < len(raw) && raw[i] != '"' {
+			if raw[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(raw) {
+			return pairs, fmt.Errorf("bad syntax for struct tag value of key %q", key)
+		}
+		qvalue := raw[:i+1]
+		raw = raw[i+1:]
+
+		value, err := strconv.Unquote(qvalue)
+		if err != nil {
+			return pairs, fmt.Errorf("bad syntax for struct tag value of key %q", key)
+		}
+		pairs = append(pairs, tagPair{key, value})
+	}
+
+	return pairs, nil
+}
+
+// tagName returns the name part of a tag value, i.e. the part before the
+// first comma, such as "id" in `json:"id,omitempty"`.
+func tagName(value string) string {
+	if idx := strings.Index(value, ","); idx != -1 {
+		return value[:idx]
+	}
+	return value
+}
+
+// hasTagOption returns true if a tag value has the given option after its
+// name, such as "squash" in `mapstructure:",squash"`.
+func hasTagOption(value, option string) bool {
+	opts := strings.Split(value, ",")
+	for _, opt := range opts[1:] {
+		if opt == option {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseTag parses a raw struct tag into a map of its keys to their values. If
+// a key is repeated, its first value is kept. An error is returned along with
+// the pairs parsed so far if the tag does not follow the conventional
+// `key:"value" key2:"value2"` format.
+func ParseTag(tag reflect.StructTag) (map[string]string, error) {
+	pairs, err := parseTag(tag)
+	tags := map[string]string{}
+	for _, pair := range pairs {
+		if _, found := tags[pair.key]; !found {
+			tags[pair.key] = pair.value
+		}
+	}
+	return tags, err
+}
+
+// TagKeys returns the keys of all the tags of a given field of a struct, in the
+// order they appear in the struct tag, such as ["json", "db"] for
+// `json:"id" db:"uid"`. A repeated key is listed once. Only exported (public)
+// fields can be inspected, and ErrInvalidTag is returned if the struct tag of
+// the field is malformed.
+func TagKeys(obj interface{}, fieldName string) ([]string, error) {
+	field, err := exportedField(obj, fieldName)
+	if err != nil {
+		return nil, err
+	}
+
+	pairs, err := parseTag(field.Tag)
+	if err != nil {
+		return nil, ErrInvalidTag
+	}
+
+	keys := []string{}
+	seen := map[string]bool{}
+	for _, pair := range pairs {
+		if !seen[pair.key] {
+			seen[pair.key] = true
+			keys = append(keys, pair.key)
+		}
+	}
+	return keys, nil
+}
+
+// AllTags returns a map of all the tag keys of a given field of a struct to
+// their values, like ParseTag. Only exported (public) fields can be inspected,
+// and ErrInvalidTag is returned if the struct tag of the field is malformed.
+func AllTags(obj interface{}, fieldName string) (map[string]string, error) {
+	field, err := exportedField(obj, fieldName)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := ParseTag(field.Tag)
+	if err != nil {
+		return nil, ErrInvalidTag
+	}
+	return tags, nil
+}
+
+// TagMatrix returns the tags of all the exported (public) fields of a struct
+// in one pass, as a map of the field names to their tag keys and values, such
+// as for the documentation tables of a model. A field without tags gets an
+// empty map.
+// Use WithSquash to promote the fields of the embedded structs, and
+// WithUnexported to include the unexported fields.
+//
+// A *FieldError wrapping ErrInvalidTag is returned for the first field whose
+// struct tag is malformed.
+func TagMatrix(obj interface{}, opts ...Option) (map[string]map[string]string, error) {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	matrix := map[string]map[string]string{}
+	for _, field := range structFields(objValue, newOptions(opts)) {
+		tags, err := ParseTag(field.Tag)
+		if err != nil {
+			return nil, &FieldError{field.Name, ErrInvalidTag}
+		}
+		matrix[field.Name] = tags
+	}
+
+	return matrix, nil
+}
+
+// exportedField returns the reflect.StructField of an exported field of a
+// struct, like GetTag looks it up.
+func exportedField(obj interface{}, fieldName string) (reflect.StructField, error) {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return reflect.StructField{}, err
+	}
+
+	field, found := cachedField(objValue.Type(), fieldName)
+	if !found {
+		return reflect.StructField{}, ErrNoField
+	}
+
+	if field.PkgPath != "" {
+		return reflect.StructField{}, ErrUnexportedField
+	}
+	return field, nil
+}
+
+// CheckTags inspects the given tag key on all the exported (public) fields of a
+// struct and returns the problems found in them. It flags fields which share
+// the same tag value (such as two fields with json:"id"), fields whose struct
+// tag is syntactically invalid, and fields which do not have the tag at all.
+// A field without a name in its tag (or without the tag) is named after the
+// field, so json:"ID" on one field clashes with json:",omitempty" on a field
+// named ID. Fields with a "-" tag value are considered as intentionally
+// skipped.
+//
+// An empty slice is returned if no problems are found.
+func CheckTags(obj interface{}, tagKey string) ([]TagIssue, error) {
+	objValue, err := getReflectValue(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	objType := objValue.Type()
+	fields := make([]reflect.StructField, objType.NumField())
+	for i := range fields {
+		fields[i] = objType.Field(i)
+	}
+	return CheckFieldTags(fields, tagKey), nil
+}
+
+// CheckFieldTags is the same as CheckTags, but inspects the given list of
+// struct fields, such as the fields of a struct type which is not available at
+// runtime. Only the Name, PkgPath and Tag of the fields are used.
+func CheckFieldTags(fields []reflect.StructField, tagKey string) []TagIssue {
+	issues := []TagIssue{}
+	seen := map[string]string{}
+	for _, fieldType := range fields {
+		if fieldType.PkgPath != "" {
+			continue
+		}
+
+		if _, err := parseTag(fieldType.Tag); err != nil {
+			issues = append(issues, TagIssue{
+				Field:   fieldType.Name,
+				Problem: TagMalformed,
+				Message: err.Error(),
+			})
+			continue
+		}
+
+		value, found := fieldType.Tag.Lookup(tagKey)
+		if !found {
+			issues = append(issues, TagIssue{
+				Field:   fieldType.Name,
+				Problem: TagMissing,
+				Message: fmt.Sprintf("%q tag is not present", tagKey),
+			})
+			// The field still takes its own name, which a tag of another field
+			// may clash with.
+			if _, dup := seen[fieldType.Name]; !dup {
+				seen[fieldType.Name] = fieldType.Name
+			}
+			continue
+		}
+
+		// A tag without a name (such as json:",omitempty") keeps the field name.
+		name := tagName(value)
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = fieldType.Name
+		}
+
+		if other, dup := seen[name]; dup {
+			issues = append(issues, TagIssue{
+				Field:   fieldType.Name,
+				Problem: TagDuplicate,
+				Message: fmt.Sprintf("%q tag value %q is also used by field %s", tagKey, name, other),
+			})
+			continue
+		}
+		seen[name] = fieldType.Name
+	}
+
+	return issues
+}
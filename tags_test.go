@@ -0,0 +1,206 @@
+package attr
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckTags(t *testing.T) {
+	got, err := CheckTags(&user, "json")
+	require.Nil(t, err)
+	require.Empty(t, got, "Unexpected tag issues on a valid struct")
+
+	got, err = CheckTags(&user, "db")
+	require.Nil(t, err)
+	require.Equal(t, []TagIssue{{"Age", TagMissing, `"db" tag is not present`}},
+		got, "Missing tag not reported")
+
+	// Build the struct at runtime so that "go vet" doesn't complain about the
+	// intentionally broken tags.
+	badType := reflect.StructOf([]reflect.StructField{
+		{Name: "ID", Type: reflect.TypeOf(0), Tag: `json:"id"`},
+		{Name: "UserID", Type: reflect.TypeOf(0), Tag: `json:"id,omitempty"`},
+		{Name: "Name", Type: reflect.TypeOf(""), Tag: `json:name`},
+		{Name: "Skipped", Type: reflect.TypeOf(""), Tag: `json:"-"`},
+	})
+	got, err = CheckTags(reflect.New(badType).Interface(), "json")
+	require.Nil(t, err)
+	require.Equal(t, 2, len(got), "Incorrect number of tag issues")
+	require.Equal(t, "UserID", got[0].Field)
+	require.Equal(t, TagDuplicate, got[0].Problem, "Duplicate tag not reported")
+	require.Equal(t, "Name", got[1].Field)
+	require.Equal(t, TagMalformed, got[1].Problem, "Malformed tag not reported")
+
+	_, err = CheckTags(10, "json")
+	require.Equal(t, ErrNotStruct, err, "Able to check tags of a non-struct")
+}
+
+func ExampleCheckTags() {
+	// type User struct {
+	// 	Username string `json:"username" db:"uname"`
+	// 	password string `json:"password" db:"pw"`
+	// 	Age      int    `json:"age" meta:"important"`
+	// }
+	testUser := User{Username: "srathi", password: "secret", Age: 30}
+
+	issues, err := CheckTags(&testUser, "db")
+	if err != nil {
+		// Handle error.
+	}
+	for _, issue := range issues {
+		fmt.Println(issue)
+	}
+	// Output: Age: missing: "db" tag is not present
+}
+
+func TestParseTag(t *testing.T) {
+	got, err := ParseTag(`json:"id,omitempty" db:"id" json:"other"`)
+	require.Nil(t, err)
+	require.Equal(t, map[string]string{"json": "id,omitempty", "db": "id"}, got,
+		"Parsed tags are not correct")
+
+	got, err = ParseTag(`db:"id" json:name`)
+	require.NotNil(t, err, "Malformed tag not reported")
+	require.Equal(t, map[string]string{"db": "id"}, got, "Tags parsed before the error are not returned")
+}
+
+func ExampleParseTag() {
+	tags, err := ParseTag(`json:"id,omitempty" db:"product_id"`)
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Println(tags)
+	// Output: map[db:product_id json:id,omitempty]
+}
+
+func TestTagKeys(t *testing.T) {
+	got, err := TagKeys(&user, "Username")
+	require.Nil(t, err)
+	require.Equal(t, []string{"json", "db"}, got, "Tag keys are not correct")
+
+	got, err = TagKeys(user, "Age")
+	require.Nil(t, err)
+	require.Equal(t, []string{"json", "meta"}, got, "Tag keys are not correct")
+
+	badType := reflect.StructOf([]reflect.StructField{
+		{Name: "Name", Type: reflect.TypeOf(""), Tag: `json:name`},
+	})
+	_, err = TagKeys(reflect.New(badType).Interface(), "Name")
+	require.Equal(t, ErrInvalidTag, err, "Malformed tag not reported")
+
+	_, err = TagKeys(&user, "password")
+	require.Equal(t, ErrUnexportedField, err, "Able to get tag keys of a private field")
+
+	_, err = TagKeys(&user, "ABC")
+	require.Equal(t, ErrNoField, err, "Able to get tag keys of a missing field")
+}
+
+func TestAllTags(t *testing.T) {
+	got, err := AllTags(&user, "Username")
+	require.Nil(t, err)
+	require.Equal(t, map[string]string{"json": "username", "db": "uname"}, got, "Tags are not correct")
+
+	got, err = AllTags(Signup{}, "Email")
+	require.Nil(t, err)
+	require.Equal(t, map[string]string{}, got, "Tags of an untagged field are not empty")
+
+	_, err = AllTags(10, "Username")
+	require.Equal(t, ErrNotStruct, err, "Able to get tags of a non-struct")
+}
+
+func ExampleAllTags() {
+	// type User struct {
+	// 	Username string `json:"username" db:"uname"`
+	// 	password string `json:"password" db:"pw"`
+	// 	Age      int    `json:"age" meta:"important"`
+	// }
+	testUser := User{Username: "srathi", password: "secret", Age: 30}
+
+	keys, err := TagKeys(&testUser, "Age")
+	if err != nil {
+		// Handle error.
+	}
+	tags, err := AllTags(&testUser, "Age")
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Println(keys, tags)
+	// Output: [json meta] map[json:age meta:important]
+}
+
+func TestTagMatrix(t *testing.T) {
+	got, err := TagMatrix(&user)
+	require.Nil(t, err)
+	require.Equal(t, map[string]map[string]string{
+		"Username": {"json": "username", "db": "uname"},
+		"Age":      {"json": "age", "meta": "important"},
+	}, got, "Tag matrix is not correct")
+
+	got, err = TagMatrix(Manager{}, WithSquash())
+	require.Nil(t, err)
+	require.Equal(t, map[string]string{"json": "reports"}, got["Reports"], "Tags of a field are not correct")
+	require.Equal(t, map[string]string{"json": "age", "meta": "important"}, got["Age"],
+		"Tags of a promoted field are not correct")
+
+	badType := reflect.StructOf([]reflect.StructField{
+		{Name: "ID", Type: reflect.TypeOf(0)},
+		{Name: "Name", Type: reflect.TypeOf(""), Tag: `json:name`},
+	})
+	_, err = TagMatrix(reflect.New(badType).Interface())
+	require.Equal(t, &FieldError{"Name", ErrInvalidTag}, err, "Malformed tag not reported")
+}
+
+func ExampleTagMatrix() {
+	type Product struct {
+		ID    int    `json:"id" db:"product_id"`
+		Title string `json:"title"`
+	}
+
+	matrix, err := TagMatrix(Product{})
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Println(matrix["ID"], matrix["Title"])
+	// Output: map[db:product_id json:id] map[json:title]
+}
+
+func TestCheckFieldTags(t *testing.T) {
+	got := CheckFieldTags([]reflect.StructField{
+		{Name: "ID", Tag: `json:"id"`},
+		{Name: "Key", Tag: `json:"id"`},
+		{Name: "secret", PkgPath: "main"},
+	}, "json")
+	require.Equal(t, []TagIssue{
+		{"Key", TagDuplicate, `"json" tag value "id" is also used by field ID`}}, got,
+		"Tag issues of the field list are not correct")
+
+	got = CheckFieldTags([]reflect.StructField{
+		{Name: "ID", Tag: `json:",omitempty"`},
+		{Name: "Key", Tag: `json:"ID"`},
+		{Name: "Name"},
+		{Name: "Title", Tag: `json:"Name"`},
+		{Name: "Skipped", Tag: `json:"-"`},
+		{Name: "Other", Tag: `json:"Skipped"`},
+	}, "json")
+	require.Equal(t, []TagIssue{
+		{"Key", TagDuplicate, `"json" tag value "ID" is also used by field ID`},
+		{"Name", TagMissing, `"json" tag is not present`},
+		{"Title", TagDuplicate, `"json" tag value "Name" is also used by field Name`}}, got,
+		"Duplicates of the field names are not reported")
+}
+
+func ExampleCheckFieldTags() {
+	// The fields of a struct type which is not available at runtime, such as
+	// one loaded with go/types. Only the Name, PkgPath and Tag are used.
+	fields := []reflect.StructField{
+		{Name: "ID", Tag: `json:"id"`},
+		{Name: "Key", Tag: `json:"id"`},
+	}
+	for _, issue := range CheckFieldTags(fields, "json") {
+		fmt.Println(issue)
+	}
+	// Output: Key: duplicate: "json" tag value "id" is also used by field ID
+}
@@ -0,0 +1,43 @@
+package attr
+
+import "text/template"
+
+// TemplateFuncs returns the template functions to access struct fields in a
+// text/template or an html/template (after converting it to
+// html/template.FuncMap):
+//
+//	getattr OBJ PATH      value of a field, such as {{getattr . "Address.City"}}
+//	hasattr OBJ PATH      true if the field exists and can be read
+//	tags OBJ TAGKEY       map of field names to their tag values, like Tags
+//	values OBJ            map of field names to their values, like Values
+//
+// A nested field is given by a dot separated path of field names. Only the
+// exported (public) fields can be accessed, and an error from getattr, tags or
+// values stops the template execution.
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"getattr": func(obj interface{}, path string) (interface{}, error) {
+			objValue, err := getReflectValue(obj)
+			if err != nil {
+				return nil, err
+			}
+
+			fieldValue, err := getFieldByPath(objValue, path, false)
+			if err != nil {
+				return nil, err
+			}
+			return fieldValue.Interface(), nil
+		},
+		"hasattr": func(obj interface{}, path string) bool {
+			objValue, err := getReflectValue(obj)
+			if err != nil {
+				return false
+			}
+
+			_, err = getFieldByPath(objValue, path, false)
+			return err == nil
+		},
+		"tags":   Tags,
+		"values": Values,
+	}
+}
@@ -0,0 +1,47 @@
+package attr
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateFuncs(t *testing.T) {
+	employee := Employee{Name: "srathi", Address: &Address{City: "San Jose"}}
+	for _, test := range []struct {
+		text    string
+		want    string
+		wantErr bool
+	}{
+		{`{{getattr . "Name"}} lives in {{getattr . "Address.City"}}`, "srathi lives in San Jose", false},
+		{`{{hasattr . "Address.Zip"}} {{hasattr . "Address.Country"}} {{hasattr . "internal"}}`,
+			"true false false", false},
+		{`{{range $k, $v := tags . "json"}}{{$k}}={{$v}};{{end}}`,
+			"Address=address;Extra=extra;Ignored=-;Labels=labels;Manager=manager;Name=name;Tags=tags;", false},
+		{`{{(values .).Name}}`, "srathi", false},
+		{`{{getattr . "Manager.Name"}}`, "", true},
+	} {
+		tmpl := template.Must(template.New("test").Funcs(TemplateFuncs()).Parse(test.text))
+		var got strings.Builder
+		err := tmpl.Execute(&got, &employee)
+		require.Equal(t, test.wantErr, err != nil, "Template error is not correct for %q", test.text)
+		if !test.wantErr {
+			require.Equal(t, test.want, got.String(), "Template output is not correct")
+		}
+	}
+}
+
+func ExampleTemplateFuncs() {
+	testUser := User{Username: "srathi", password: "secret", Age: 30}
+
+	tmpl := template.Must(template.New("user").Funcs(TemplateFuncs()).Parse(
+		`{{getattr . "Username"}} is {{getattr . "Age"}} years old`))
+	err := tmpl.Execute(os.Stdout, testUser)
+	if err != nil {
+		// Handle error.
+	}
+	// Output: srathi is 30 years old
+}
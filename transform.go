@@ -0,0 +1,252 @@
+package attr
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// TransformStrings replaces the value of every exported (public) string field
+// of a struct with the result of 'fn' on its current value. Nested structs,
+// including the ones referenced by non-nil pointers, are transformed too,
+// except the ones deeper than the WithMaxDepth option. A struct referenced
+// through pointers more than once is transformed only once, and
+// ErrCycleDetected is returned if a struct references itself through pointers
+// (after transforming the fields visited before the cycle).
+//
+// NOTE: 'obj' struct must be passed by pointer for this API to work. Passing by
+// value results in ErrNotPtr.
+func TransformStrings(obj interface{}, fn func(string) string, opts ...Option) error {
+	objValue, err := getStructByPtr(obj)
+	if err != nil {
+		return err
+	}
+
+	t := newTracker(newOptions(opts))
+	t.enter(valueOf(obj))
+	return transformFields(objValue, reflect.String, map[visitKey]bool{}, t,
+		func(_ reflect.StructField, fieldValue reflect.Value) error {
+			fieldValue.SetString(fn(fieldValue.String()))
+			return nil
+		})
+}
+
+// TransformKind replaces the value of every exported (public) field of the
+// given kind in a struct with the result of 'fn' on its current value. Nested
+// structs are transformed too, and the shared structs and the cycles are
+// handled like TransformStrings.
+//
+// The value returned by 'fn' must be of the same type as the field, or be
+// convertible to it while being of the same kind (such as a string for a field
+// of type "type Email string"), else ErrMismatchValue is returned.
+//
+// NOTE: 'obj' struct must be passed by pointer for this API to work. Passing by
+// value results in ErrNotPtr.
+func TransformKind(obj interface{}, kind reflect.Kind, fn func(interface{}) interface{},
+	opts ...Option) error {
+	objValue, err := getStructByPtr(obj)
+	if err != nil {
+		return err
+	}
+
+	t := newTracker(newOptions(opts))
+	t.enter(valueOf(obj))
+	return transformFields(objValue, kind, map[visitKey]bool{}, t,
+		func(_ reflect.StructField, fieldValue reflect.Value) error {
+			newValue := reflect.ValueOf(fn(fieldValue.Interface()))
+			if !newValue.IsValid() {
+				if !isNillable(fieldValue.Kind()) {
+					return ErrMismatchValue
+				}
+				newValue = reflect.Zero(fieldValue.Type())
+			}
+
+			if newValue.Type() != fieldValue.Type() {
+				if newValue.Kind() != fieldValue.Kind() ||
+					!newValue.Type().ConvertibleTo(fieldValue.Type()) {
+					return ErrMismatchValue
+				}
+				newValue = newValue.Convert(fieldValue.Type())
+			}
+
+			fieldValue.Set(newValue)
+			return nil
+		})
+}
+
+// transformFields calls 'fn' on every exported field of the given kind in a
+// struct, recursing into the nested structs and the non-nil pointers to
+// structs. 'visited' holds the structs already transformed through a pointer,
+// keyed by the type too since a struct and its first field share an address.
+// The struct (or the pointer to it) must be entered in the tracker.
+func transformFields(objValue reflect.Value, kind reflect.Kind, visited map[visitKey]bool,
+	t *tracker, fn func(reflect.StructField, reflect.Value) error) error {
+	if err := t.err(); err != nil {
+		return err
+	}
+
+	objType := objValue.Type()
+	for i := 0; i < objValue.NumField(); i++ {
+		fieldValue := objValue.Field(i)
+		if !fieldValue.CanSet() {
+			continue
+		}
+
+		if fieldValue.Kind() == kind {
+			if err := fn(objType.Field(i), fieldValue); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !t.canDescend() {
+			continue
+		}
+
+		ptrValue := fieldValue
+		if fieldValue.Kind() == reflect.Ptr && !fieldValue.IsNil() {
+			fieldValue = fieldValue.Elem()
+		}
+		if fieldValue.Kind() != reflect.Struct {
+			continue
+		}
+
+		if err := t.enter(ptrValue); err != nil {
+			return err
+		}
+		if ptrValue.Kind() == reflect.Ptr {
+			if !markVisited(ptrValue, visited) {
+				t.leave(ptrValue)
+				continue
+			}
+		}
+		err := transformFields(fieldValue, kind, visited, t, fn)
+		t.leave(ptrValue)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isNillable returns true if a value of the given kind can be nil.
+func isNillable(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface, reflect.Func, reflect.Chan:
+		return true
+	}
+	return false
+}
+
+// taggedFields calls 'fn' with the path, the tag value and the reflect-value of
+// every exported field of a struct which has the given tag key. The untagged
+// fields are searched for the nested structs, including the ones referenced by
+// non-nil pointers, held by interfaces and the elements of slices, arrays and
+// maps, whose paths are given like "History[0].Email" and "Contacts[home].Email".
+// A struct which can't be modified in place, such as a struct value held by a
+// map or an interface (rather than a pointer to it), returns a *FieldError
+// with ErrNotPtr if it or its nested structs have any tagged fields. 'visited' holds the structs
+// already searched through a pointer, keyed like in transformFields.
+func taggedFields(value reflect.Value, tagKey, path string, visited map[visitKey]bool,
+	fn func(path, tag string, fieldValue reflect.Value) error) error {
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() || !markVisited(value, visited) {
+			return nil
+		}
+		return taggedFields(value.Elem(), tagKey, path, visited, fn)
+
+	case reflect.Interface:
+		if value.IsNil() {
+			return nil
+		}
+		return taggedFields(value.Elem(), tagKey, path, visited, fn)
+
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			itemPath := path + "[" + strconv.Itoa(i) + "]"
+			if err := taggedFields(value.Index(i), tagKey, itemPath, visited, fn); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Map:
+		iter := value.MapRange()
+		for iter.Next() {
+			itemPath := path + "[" + fmt.Sprint(iter.Key().Interface()) + "]"
+			if err := taggedFields(iter.Value(), tagKey, itemPath, visited, fn); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Struct:
+		objType := value.Type()
+		if !value.CanAddr() && hasTaggedFields(objType, tagKey) {
+			return &FieldError{path, ErrNotPtr}
+		}
+
+		if path != "" {
+			path += pathSeparator
+		}
+		for i := 0; i < value.NumField(); i++ {
+			fieldType := objType.Field(i)
+			fieldValue := value.Field(i)
+			if !fieldValue.CanInterface() {
+				continue
+			}
+
+			tag, found := fieldType.Tag.Lookup(tagKey)
+			if !found {
+				if err := taggedFields(fieldValue, tagKey, path+fieldType.Name, visited, fn); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if err := fn(path+fieldType.Name, tag, fieldValue); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// hasTaggedFields returns true if a struct type, or any of its nested struct
+// types, has a field with the given tag key.
+func hasTaggedFields(objType reflect.Type, tagKey string) bool {
+	return checkTypeTags(objType, tagKey, map[reflect.Type]bool{},
+		func(string, reflect.Type) bool { return false }) != nil
+}
+
+// checkTypeTags returns ErrInvalidTag if 'valid' returns false for a field of
+// a struct type, or of its nested struct types, which has the given tag key.
+func checkTypeTags(objType reflect.Type, tagKey string, checked map[reflect.Type]bool,
+	valid func(tag string, fieldType reflect.Type) bool) error {
+	checked[objType] = true
+	for i := 0; i < objType.NumField(); i++ {
+		fieldType := objType.Field(i)
+		if tag, found := fieldType.Tag.Lookup(tagKey); found && !valid(tag, fieldType.Type) {
+			return ErrInvalidTag
+		}
+
+		nestedType := fieldType.Type
+		for nestedType.Kind() == reflect.Ptr || nestedType.Kind() == reflect.Slice ||
+			nestedType.Kind() == reflect.Array || nestedType.Kind() == reflect.Map {
+			nestedType = nestedType.Elem()
+		}
+		if nestedType.Kind() == reflect.Struct && !checked[nestedType] {
+			if err := checkTypeTags(nestedType, tagKey, checked, valid); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// isStringOrBytes returns true for the string and []byte kinds of types.
+func isStringOrBytes(typ reflect.Type) bool {
+	return typ.Kind() == reflect.String ||
+		(typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.Uint8)
+}
@@ -0,0 +1,97 @@
+package attr
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Email string
+
+type Signup struct {
+	Name    string
+	Email   Email
+	Age     int
+	Address *Address
+	Billing Address
+	comment string
+}
+
+func TestTransformStrings(t *testing.T) {
+	signup := Signup{
+		Name:    "  srathi ",
+		Email:   " SRATHI@Example.com",
+		Address: &Address{City: " San Jose "},
+		Billing: Address{Zip: " 95134"},
+		comment: " keep ",
+	}
+	want := Signup{
+		Name:    "srathi",
+		Email:   "SRATHI@Example.com",
+		Address: &Address{City: "San Jose"},
+		Billing: Address{Zip: "95134"},
+		comment: " keep ",
+	}
+	require.Nil(t, TransformStrings(&signup, strings.TrimSpace))
+	require.Equal(t, want, signup, "Strings not transformed")
+
+	require.Equal(t, ErrNotPtr, TransformStrings(signup, strings.TrimSpace),
+		"Able to transform a struct passed by value")
+}
+
+func TestTransformSharedAddress(t *testing.T) {
+	type Wrapper struct {
+		Address Address
+		Note    string
+	}
+	type Order struct {
+		Shipping *Address
+		Wrapper  *Wrapper
+	}
+
+	// The address is the first field of the wrapper, so both share a pointer.
+	wrapper := &Wrapper{Address: Address{City: " Austin "}, Note: " fragile "}
+	order := Order{Shipping: &wrapper.Address, Wrapper: wrapper}
+	require.Nil(t, TransformStrings(&order, strings.TrimSpace))
+	require.Equal(t, "Austin", wrapper.Address.City)
+	require.Equal(t, "fragile", wrapper.Note, "Struct sharing an address was skipped")
+}
+
+func TestTransformKind(t *testing.T) {
+	signup := Signup{Name: "srathi", Email: "SRATHI@example.com", Age: 30}
+	err := TransformKind(&signup, reflect.String, func(v interface{}) interface{} {
+		if email, ok := v.(Email); ok {
+			return strings.ToLower(string(email))
+		}
+		return v
+	})
+	require.Nil(t, err)
+	require.Equal(t, Email("srathi@example.com"), signup.Email, "Named string type not transformed")
+
+	err = TransformKind(&signup, reflect.Int, func(v interface{}) interface{} {
+		return v.(int) * 2
+	})
+	require.Nil(t, err)
+	require.Equal(t, 60, signup.Age, "Int field not transformed")
+
+	err = TransformKind(&signup, reflect.Int, func(v interface{}) interface{} {
+		return "abc"
+	})
+	require.Equal(t, ErrMismatchValue, err, "Able to set a string to an int field")
+}
+
+func ExampleTransformStrings() {
+	testUser := User{Username: "  SRathi ", password: "secret", Age: 30}
+
+	err := TransformStrings(&testUser, func(s string) string {
+		return strings.ToLower(strings.TrimSpace(s))
+	})
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Printf("Username: %q\n", testUser.Username)
+	// Output: Username: "srathi"
+}
@@ -0,0 +1,146 @@
+package attr
+
+import "reflect"
+
+// TypeAccessor gives access to the fields of the structs of type T, as
+// returned by For. The metadata of T (its field names, indexes and tags) is
+// computed once when the accessor is created, so the methods avoid the
+// per-call lookups of the functions taking an interface{}, and take a *T
+// rather than an interface{} for type safety. A TypeAccessor can be shared by
+// multiple goroutines, and is typically kept in a package variable:
+//
+//	var userAttr = attr.For[User]()
+type TypeAccessor[T any] struct {
+	// names holds the exported (public) fields of T, in their declaration
+	// order, as listed by Names.
+	names []string
+	// fields holds the exported fields by name, including the ones promoted
+	// from the embedded structs.
+	fields map[string]reflect.StructField
+	// err is ErrNotStruct if T is not a struct type, returned by the methods.
+	err error
+}
+
+// For returns the TypeAccessor of the struct type T. If T is not a struct
+// type, the methods of the accessor return ErrNotStruct.
+func For[T any]() *TypeAccessor[T] {
+	objType := reflect.TypeOf((*T)(nil)).Elem()
+	if objType.Kind() != reflect.Struct {
+		return &TypeAccessor[T]{err: ErrNotStruct}
+	}
+
+	a := &TypeAccessor[T]{fields: map[string]reflect.StructField{}}
+	for _, field := range reflect.VisibleFields(objType) {
+		if field.PkgPath != "" {
+			continue
+		}
+		a.fields[field.Name] = field
+		if len(field.Index) == 1 {
+			a.names = append(a.names, field.Name)
+		}
+	}
+	return a
+}
+
+// Names returns the names of the exported (public) fields of T, like Names.
+func (a *TypeAccessor[T]) Names() ([]string, error) {
+	if a.err != nil {
+		return nil, a.err
+	}
+	return append([]string{}, a.names...), nil
+}
+
+// Has returns true if T has an exported field of the given name, including a
+// field promoted from an embedded struct.
+func (a *TypeAccessor[T]) Has(fieldName string) bool {
+	_, found := a.fields[fieldName]
+	return found
+}
+
+// Tags returns the values of a given tag key of the exported fields of T, like
+// Tags.
+func (a *TypeAccessor[T]) Tags(tagKey string) (map[string]string, error) {
+	if a.err != nil {
+		return nil, a.err
+	}
+
+	tags := make(map[string]string, len(a.names))
+	for _, name := range a.names {
+		tags[name] = a.fields[name].Tag.Get(tagKey)
+	}
+	return tags, nil
+}
+
+// Get returns the value of a given exported field of 'obj', like GetValue.
+// ErrNoField is returned if T has no such exported field, and ErrNilPointer if
+// 'obj' is nil or the field is promoted from a nil embedded pointer.
+func (a *TypeAccessor[T]) Get(obj *T, fieldName string) (interface{}, error) {
+	fieldValue, err := a.field(obj, fieldName)
+	if err != nil {
+		return nil, err
+	}
+	return fieldValue.Interface(), nil
+}
+
+// Set sets the given value to a given exported field of 'obj', like SetValue.
+// The value must be of the field type, or be scanned by the field type if it
+// implements sql.Scanner, else ErrMismatchValue is returned.
+func (a *TypeAccessor[T]) Set(obj *T, fieldName string, newValue interface{}) error {
+	fieldValue, err := a.field(obj, fieldName)
+	if err != nil {
+		return err
+	}
+
+	value := reflect.ValueOf(newValue)
+	if fieldValue.Type() != reflect.TypeOf(newValue) {
+		if !isScanner(fieldValue.Type()) {
+			return ErrMismatchValue
+		}
+		if value, err = scanValue(newValue, fieldValue.Type()); err != nil {
+			return err
+		}
+	}
+
+	fieldValue.Set(value)
+	return nil
+}
+
+// Values returns the values of the exported fields of 'obj' by name, like
+// Values. ErrNilPointer is returned if 'obj' is nil.
+func (a *TypeAccessor[T]) Values(obj *T) (map[string]interface{}, error) {
+	if a.err != nil {
+		return nil, a.err
+	}
+	if obj == nil {
+		return nil, ErrNilPointer
+	}
+
+	objValue := reflect.ValueOf(obj).Elem()
+	values := make(map[string]interface{}, len(a.names))
+	for _, name := range a.names {
+		values[name] = objValue.Field(a.fields[name].Index[0]).Interface()
+	}
+	return values, nil
+}
+
+// field returns the reflect-value of a given exported field of 'obj'.
+func (a *TypeAccessor[T]) field(obj *T, fieldName string) (reflect.Value, error) {
+	if a.err != nil {
+		return reflect.Value{}, a.err
+	}
+
+	field, found := a.fields[fieldName]
+	if !found {
+		return reflect.Value{}, ErrNoField
+	}
+
+	if obj == nil {
+		return reflect.Value{}, ErrNilPointer
+	}
+
+	fieldValue, ok := fieldByIndex(reflect.ValueOf(obj).Elem(), field.Index)
+	if !ok {
+		return reflect.Value{}, ErrNilPointer
+	}
+	return fieldValue, nil
+}
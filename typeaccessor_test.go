@@ -0,0 +1,74 @@
+package attr
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFor(t *testing.T) {
+	userAttr := For[User]()
+
+	names, err := userAttr.Names()
+	require.Nil(t, err)
+	require.Equal(t, []string{"Username", "Age"}, names, "Names are not correct")
+	require.True(t, userAttr.Has("Age"))
+	require.False(t, userAttr.Has("password"), "Private field is found")
+
+	tags, err := userAttr.Tags("json")
+	require.Nil(t, err)
+	require.Equal(t, map[string]string{"Username": "username", "Age": "age"}, tags, "Tags are not correct")
+
+	testUser := user
+	value, err := userAttr.Get(&testUser, "Username")
+	require.Nil(t, err)
+	require.Equal(t, "srathi", value, "Value is not correct")
+
+	require.Nil(t, userAttr.Set(&testUser, "Age", 40))
+	require.Equal(t, 40, testUser.Age, "Value is not set")
+	require.Equal(t, ErrMismatchValue, userAttr.Set(&testUser, "Age", "40"))
+
+	values, err := userAttr.Values(&testUser)
+	require.Nil(t, err)
+	require.Equal(t, map[string]interface{}{"Username": "srathi", "Age": 40}, values, "Values are not correct")
+
+	_, err = userAttr.Get(&testUser, "password")
+	require.Equal(t, ErrNoField, err, "Able to get a private field")
+	_, err = userAttr.Get(nil, "Age")
+	require.Equal(t, ErrNilPointer, err)
+	_, err = userAttr.Values(nil)
+	require.Equal(t, ErrNilPointer, err)
+
+	// The fields promoted from the embedded structs can be read and set.
+	managerAttr := For[Manager]()
+	manager := Manager{User: user}
+	value, err = managerAttr.Get(&manager, "Username")
+	require.Nil(t, err)
+	require.Equal(t, "srathi", value, "Promoted value is not correct")
+	require.Nil(t, managerAttr.Set(&manager, "Age", 50))
+	require.Equal(t, 50, manager.Age, "Promoted value is not set")
+	names, _ = managerAttr.Names()
+	require.Equal(t, []string{"User", "Reports"}, names, "Names are not correct")
+
+	intAttr := For[int]()
+	_, err = intAttr.Names()
+	require.Equal(t, ErrNotStruct, err)
+	_, err = intAttr.Get(new(int), "Age")
+	require.Equal(t, ErrNotStruct, err)
+}
+
+func ExampleFor() {
+	userAttr := For[User]()
+
+	testUser := User{Username: "srathi", Age: 30}
+	if err := userAttr.Set(&testUser, "Age", 31); err != nil {
+		// Handle error.
+	}
+	age, err := userAttr.Get(&testUser, "Age")
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Println(age)
+	// Output: 31
+}
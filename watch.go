@@ -0,0 +1,207 @@
+package attr
+
+import (
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Change describes a change in the value of a struct field, as reported by
+// Watch. Field is the field name, or a dot separated path of names for a field
+// of a nested struct, such as "Address.City".
+type Change struct {
+	Field string
+	Old   interface{}
+	New   interface{}
+}
+
+// Watch polls the exported (public) fields of a struct at every 'interval' and
+// sends a Change on the returned channel for each field whose value differs
+// from the previous poll. Fields of nested structs are compared individually.
+//
+// The returned stop function ends the polling and closes the channel. It is
+// safe to call it more than once. ErrInvalidValue is returned if 'interval' is
+// not positive.
+//
+// NOTE: 'obj' struct must be passed by pointer for this API to work. Polling
+// doesn't synchronize with the goroutines modifying the struct, so the caller
+// must make sure that the struct isn't modified concurrently with a poll.
+func Watch(obj interface{}, interval time.Duration) (<-chan Change, func(), error) {
+	objValue, err := getStructByPtr(obj)
+	if err != nil {
+		return nil, nil, err
+	}
+	if interval <= 0 {
+		return nil, nil, ErrInvalidValue
+	}
+
+	changes := make(chan Change)
+	done := make(chan struct{})
+	var once sync.Once
+	stop := func() {
+		once.Do(func() { close(done) })
+	}
+
+	t := newTracker(newOptions(nil))
+	last := map[string]interface{}{}
+	snapshotFields(objValue, "", last, t)
+
+	go func() {
+		defer close(changes)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+
+			current := map[string]interface{}{}
+			snapshotFields(objValue, "", current, t)
+			for _, change := range diffSnapshots(objValue.Type(), "", last, current) {
+				select {
+				case changes <- change:
+				case <-done:
+					return
+				}
+			}
+			last = current
+		}
+	}()
+
+	return changes, stop, nil
+}
+
+// snapshotFields records the values of all the exported fields of a struct in
+// the given map, keyed by the field path. Nested structs are recorded field by
+// field, except for time.Time which is recorded as a single value. The values
+// are deep copies, so that maps, slices and pointed-to values modified in place
+// are still reported as changed on the next poll. The snapshot is incomplete
+// if the context of the tracker is done, which the caller must check.
+func snapshotFields(objValue reflect.Value, prefix string, snapshot map[string]interface{}, t *tracker) {
+	copies := map[visitKey]reflect.Value{}
+	objType := objValue.Type()
+	for i := 0; i < objValue.NumField(); i++ {
+		if t.err() != nil {
+			return
+		}
+
+		fieldType := objType.Field(i)
+		fieldValue := objValue.Field(i)
+		if !fieldValue.CanInterface() {
+			continue
+		}
+
+		path := prefix + fieldType.Name
+		if compareByField(fieldValue.Type()) {
+			snapshotFields(fieldValue, path+".", snapshot, t)
+			continue
+		}
+		snapshot[path] = deepCopy(fieldValue, copies, t).Interface()
+	}
+}
+
+// deepCopy returns a copy of a value that shares no memory with it, following
+// pointers, interfaces, slices, arrays, maps and exported struct fields. Map
+// keys and unexported fields are copied as is. 'copies' holds the pointers and
+// maps already copied, so that shared references and cycles are preserved.
+// Once the context of the tracker is done, the values are returned as is, and
+// the caller must discard the copy.
+func deepCopy(value reflect.Value, copies map[visitKey]reflect.Value, t *tracker) reflect.Value {
+	if t.err() != nil {
+		return value
+	}
+
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() {
+			return value
+		}
+		key := visitKey{value.Pointer(), value.Type()}
+		if copied, ok := copies[key]; ok {
+			return copied
+		}
+		copied := reflect.New(value.Type().Elem())
+		copies[key] = copied
+		copied.Elem().Set(deepCopy(value.Elem(), copies, t))
+		return copied
+
+	case reflect.Map:
+		if value.IsNil() {
+			return value
+		}
+		key := visitKey{value.Pointer(), value.Type()}
+		if copied, ok := copies[key]; ok {
+			return copied
+		}
+		copied := reflect.MakeMapWithSize(value.Type(), value.Len())
+		copies[key] = copied
+		iter := value.MapRange()
+		for iter.Next() {
+			copied.SetMapIndex(iter.Key(), deepCopy(iter.Value(), copies, t))
+		}
+		return copied
+
+	case reflect.Slice:
+		if value.IsNil() {
+			return value
+		}
+		copied := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		for i := 0; i < value.Len(); i++ {
+			copied.Index(i).Set(deepCopy(value.Index(i), copies, t))
+		}
+		return copied
+
+	case reflect.Array:
+		copied := reflect.New(value.Type()).Elem()
+		for i := 0; i < value.Len(); i++ {
+			copied.Index(i).Set(deepCopy(value.Index(i), copies, t))
+		}
+		return copied
+
+	case reflect.Struct:
+		copied := reflect.New(value.Type()).Elem()
+		copied.Set(value)
+		for i := 0; i < value.NumField(); i++ {
+			if copied.Field(i).CanSet() {
+				copied.Field(i).Set(deepCopy(value.Field(i), copies, t))
+			}
+		}
+		return copied
+
+	case reflect.Interface:
+		if value.IsNil() {
+			return value
+		}
+		copied := reflect.New(value.Type()).Elem()
+		copied.Set(deepCopy(value.Elem(), copies, t))
+		return copied
+	}
+
+	return value
+}
+
+// diffSnapshots returns the changes between two snapshots of a struct type, in
+// the declaration order of the fields.
+func diffSnapshots(objType reflect.Type, prefix string, old, new map[string]interface{}) []Change {
+	changes := []Change{}
+	for i := 0; i < objType.NumField(); i++ {
+		fieldType := objType.Field(i)
+		if fieldType.PkgPath != "" {
+			continue
+		}
+
+		path := prefix + fieldType.Name
+		if compareByField(fieldType.Type) {
+			changes = append(changes, diffSnapshots(fieldType.Type, path+".", old, new)...)
+			continue
+		}
+
+		if !valuesEqual(old[path], new[path]) {
+			changes = append(changes, Change{path, old[path], new[path]})
+		}
+	}
+	return changes
+}
@@ -0,0 +1,85 @@
+package attr
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Config struct {
+	Name    string
+	Retries int
+	Limits  struct {
+		Max int
+	}
+	Updated time.Time
+	secret  string
+}
+
+func TestWatch(t *testing.T) {
+	config := Config{Name: "test", Retries: 3}
+	changes, stop, err := Watch(&config, time.Millisecond)
+	require.Nil(t, err)
+
+	// Nothing changes, so the channel must only get closed on stop.
+	time.Sleep(5 * time.Millisecond)
+	stop()
+	stop()
+	_, ok := <-changes
+	require.False(t, ok, "Changes reported on an unmodified struct")
+
+	_, _, err = Watch(config, time.Millisecond)
+	require.Equal(t, ErrNotPtr, err, "Able to watch a struct passed by value")
+
+	_, _, err = Watch(&config, 0)
+	require.Equal(t, ErrInvalidValue, err, "Able to watch with a zero interval")
+}
+
+func TestWatchSnapshots(t *testing.T) {
+	// The polling loop can't be tested with a concurrent update without a
+	// data race, so test the comparison of two snapshots directly.
+	config := Config{Name: "test", Retries: 3}
+	old := map[string]interface{}{}
+	snapshotFields(reflect.ValueOf(config), "", old, newTracker(newOptions(nil)))
+
+	now := time.Now()
+	config.Retries = 5
+	config.Limits.Max = 10
+	config.Updated = now
+	config.secret = "hidden"
+	current := map[string]interface{}{}
+	snapshotFields(reflect.ValueOf(config), "", current, newTracker(newOptions(nil)))
+
+	want := []Change{{"Retries", 3, 5}, {"Limits.Max", 0, 10}, {"Updated", time.Time{}, now}}
+	got := diffSnapshots(reflect.TypeOf(config), "", old, current)
+	require.Equal(t, want, got, "Field changes are not correct")
+}
+
+func TestWatchSnapshotsInPlace(t *testing.T) {
+	type Settings struct {
+		Labels map[string]string
+		Hosts  []string
+		Limit  *int
+	}
+
+	limit := 1
+	settings := Settings{Labels: map[string]string{"env": "dev"}, Hosts: []string{"a"}, Limit: &limit}
+	old := map[string]interface{}{}
+	snapshotFields(reflect.ValueOf(settings), "", old, newTracker(newOptions(nil)))
+
+	// Modify the map, slice and pointed-to value in place.
+	settings.Labels["env"] = "prod"
+	settings.Hosts[0] = "b"
+	limit = 2
+	current := map[string]interface{}{}
+	snapshotFields(reflect.ValueOf(settings), "", current, newTracker(newOptions(nil)))
+
+	got := diffSnapshots(reflect.TypeOf(settings), "", old, current)
+	require.Equal(t, 3, len(got), "In place changes are not reported")
+	require.Equal(t, Change{"Labels", map[string]string{"env": "dev"}, map[string]string{"env": "prod"}}, got[0])
+	require.Equal(t, Change{"Hosts", []string{"a"}, []string{"b"}}, got[1])
+	require.Equal(t, 1, *got[2].Old.(*int), "Pointed-to value is not copied")
+	require.Equal(t, 2, *got[2].New.(*int), "Pointed-to value is not copied")
+}
@@ -0,0 +1,98 @@
+package attr
+
+import "strings"
+
+// XMLTag is a parsed "xml" struct tag, following the rules of encoding/xml.
+type XMLTag struct {
+	// Name is the local name of the element or attribute, or "" if the tag
+	// doesn't give one.
+	Name string
+	// Namespace is the namespace given before the name, such as "urn:x" in
+	// `xml:"urn:x name"`.
+	Namespace string
+	// Parents are the names of the parent elements of a nested element, such
+	// as "a" and "b" in `xml:"a>b>c"`.
+	Parents []string
+	// Skip is true for the fields tagged with "-".
+	Skip bool
+	// The flags after the name.
+	Attr      bool
+	CharData  bool
+	CData     bool
+	InnerXML  bool
+	Comment   bool
+	Any       bool
+	OmitEmpty bool
+}
+
+// ParseXMLTag parses the value of an "xml" struct tag, such as "name,attr" or
+// "a>b>c,omitempty".
+func ParseXMLTag(value string) XMLTag {
+	if value == "-" {
+		return XMLTag{Skip: true}
+	}
+
+	opts := strings.Split(value, ",")
+	tag := XMLTag{Name: opts[0]}
+	if idx := strings.LastIndex(tag.Name, " "); idx != -1 {
+		tag.Namespace, tag.Name = tag.Name[:idx], tag.Name[idx+1:]
+	}
+	if strings.Contains(tag.Name, ">") {
+		names := strings.Split(tag.Name, ">")
+		tag.Parents, tag.Name = names[:len(names)-1], names[len(names)-1]
+	}
+
+	for _, opt := range opts[1:] {
+		switch opt {
+		case "attr":
+			tag.Attr = true
+		case "chardata":
+			tag.CharData = true
+		case "cdata":
+			tag.CData = true
+		case "innerxml":
+			tag.InnerXML = true
+		case "comment":
+			tag.Comment = true
+		case "any":
+			tag.Any = true
+		case "omitempty":
+			tag.OmitEmpty = true
+		}
+	}
+	return tag
+}
+
+// XMLName returns the name of the XML element or attribute of a given field of
+// a struct, as encoded by encoding/xml: the name in its "xml" tag (without the
+// parent elements of a nested element) or its field name. An empty name is
+// returned for the fields which are skipped or which are not encoded as a
+// named element or attribute, such as the ",chardata" fields.
+// Only exported (public) fields can be inspected.
+func XMLName(obj interface{}, fieldName string) (string, error) {
+	field, err := exportedField(obj, fieldName)
+	if err != nil {
+		return "", err
+	}
+
+	tag := ParseXMLTag(field.Tag.Get("xml"))
+	switch {
+	case tag.Skip, tag.CharData, tag.CData, tag.InnerXML, tag.Comment:
+		return "", nil
+	case tag.Name != "":
+		return tag.Name, nil
+	}
+	return field.Name, nil
+}
+
+// IsXMLAttr returns true if a given field of a struct is encoded as an XML
+// attribute by encoding/xml, i.e. it is tagged with ",attr".
+// Only exported (public) fields can be inspected.
+func IsXMLAttr(obj interface{}, fieldName string) (bool, error) {
+	field, err := exportedField(obj, fieldName)
+	if err != nil {
+		return false, err
+	}
+
+	return ParseXMLTag(field.Tag.Get("xml")).Attr, nil
+}
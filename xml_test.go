@@ -0,0 +1,86 @@
+package attr
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Feed struct {
+	ID      string `xml:"id,attr"`
+	Lang    string `xml:"http://www.w3.org/XML/1998/namespace lang,attr,omitempty"`
+	Title   string `xml:"channel>title"`
+	Body    string `xml:",chardata"`
+	Note    string `xml:",comment"`
+	Author  string
+	Skipped string `xml:"-"`
+	secret  string
+}
+
+func TestParseXMLTag(t *testing.T) {
+	for _, test := range []struct {
+		value string
+		want  XMLTag
+	}{
+		{"id,attr", XMLTag{Name: "id", Attr: true}},
+		{"ns lang,attr,omitempty", XMLTag{Name: "lang", Namespace: "ns", Attr: true, OmitEmpty: true}},
+		{"a>b>c", XMLTag{Name: "c", Parents: []string{"a", "b"}}},
+		{",chardata", XMLTag{CharData: true}},
+		{",cdata", XMLTag{CData: true}},
+		{",innerxml", XMLTag{InnerXML: true}},
+		{",any", XMLTag{Any: true}},
+		{"-", XMLTag{Skip: true}},
+		{"", XMLTag{}},
+	} {
+		require.Equal(t, test.want, ParseXMLTag(test.value), "Parsed tag of %q is not correct", test.value)
+	}
+}
+
+func TestXMLName(t *testing.T) {
+	for field, want := range map[string]string{
+		"ID":      "id",
+		"Lang":    "lang",
+		"Title":   "title",
+		"Body":    "",
+		"Note":    "",
+		"Author":  "Author",
+		"Skipped": "",
+	} {
+		got, err := XMLName(&Feed{}, field)
+		require.Nil(t, err)
+		require.Equal(t, want, got, "XML name of %s is not correct", field)
+	}
+
+	_, err := XMLName(Feed{}, "secret")
+	require.Equal(t, ErrUnexportedField, err, "Able to get the XML name of a private field")
+}
+
+func TestIsXMLAttr(t *testing.T) {
+	for field, want := range map[string]bool{"ID": true, "Lang": true, "Title": false, "Author": false} {
+		got, err := IsXMLAttr(Feed{}, field)
+		require.Nil(t, err)
+		require.Equal(t, want, got, "Attribute flag of %s is not correct", field)
+	}
+
+	_, err := IsXMLAttr(Feed{}, "ABC")
+	require.Equal(t, ErrNoField, err, "Able to inspect a missing field")
+}
+
+func ExampleXMLName() {
+	type Item struct {
+		ID    int    `xml:"id,attr"`
+		Title string `xml:"info>title"`
+	}
+
+	name, err := XMLName(Item{}, "Title")
+	if err != nil {
+		// Handle error.
+	}
+	attr, err := IsXMLAttr(Item{}, "ID")
+	if err != nil {
+		// Handle error.
+	}
+	fmt.Println(name, attr)
+	// Output: title true
+}